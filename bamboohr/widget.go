@@ -7,6 +7,12 @@ import (
 	"github.com/senorprogrammer/wtf/wtf"
 )
 
+const ConfigDefaults = `
+apiKey: ""
+refreshInterval: 900
+subdomain: ""
+`
+
 type Widget struct {
 	wtf.TextWidget
 }
@@ -32,7 +38,7 @@ func (widget *Widget) Refresh() {
 	widget.UpdateRefreshedAt()
 	widget.View.SetTitle(widget.ContextualTitle(widget.Name))
 
-	widget.View.SetText(widget.contentFrom(todayItems))
+	widget.SetText(widget.contentFrom(todayItems))
 }
 
 /* -------------------- Unexported Functions -------------------- */
@@ -11,6 +11,12 @@ import (
 	"github.com/senorprogrammer/wtf/wtf"
 )
 
+const ConfigDefaults = `
+graphIcon: "💀"
+graphStars: 25
+refreshInterval: 30
+`
+
 var started = false
 var ok = true
 
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 
 	"github.com/olebedev/config"
 	"github.com/senorprogrammer/wtf/logger"
@@ -16,6 +17,14 @@ const ConfigDirV1 = "~/.wtf/"
 // ConfigDirV2 defines the path to the second version of the configuration. Use this.
 const ConfigDirV2 = "~/.config/wtf/"
 
+// CacheDirName defines the name of the directory, in the config directory, that API
+// responses are cached in
+const CacheDirName = "cache"
+
+// StateFileName defines the name of the file, in the config directory, that the UI state
+// is persisted to between runs
+const StateFileName = "state.yml"
+
 /* -------------------- Config Migration -------------------- */
 
 // MigrateOldConfig copies any existing configuration from the old location
@@ -63,6 +72,16 @@ func ConfigDir() (string, error) {
 	return configDir, nil
 }
 
+// CacheDir returns the absolute path to the response cache directory
+func CacheDir() (string, error) {
+	configDir, err := ConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(configDir, CacheDirName), nil
+}
+
 // CreateConfigDir creates the wtf/ directory in the user's home dir
 func CreateConfigDir() {
 	configDir, _ := ConfigDir()
@@ -87,7 +106,7 @@ func CreateConfigFile() {
 	file, _ := os.Stat(filePath)
 
 	if file.Size() == 0 {
-		if ioutil.WriteFile(filePath, []byte(simpleConfig), 0644) != nil {
+		if ioutil.WriteFile(filePath, []byte(fmt.Sprintf(simpleConfig, ConfigVersion)), 0644) != nil {
 			panic(err)
 		}
 	}
@@ -121,11 +140,25 @@ func CreateFile(fileName string) (string, error) {
 	return filePath, nil
 }
 
-// LoadConfigFile loads the config.yml file to configure the app
+// LoadConfigFile loads the config.yml file to configure the app. A config file
+// written for an older config version is migrated as it's loaded, but the file
+// itself is left as it is
 func LoadConfigFile(filePath string) *config.Config {
 	absPath, _ := wtf.ExpandHomeDir(filePath)
 
-	cfg, err := config.ParseYamlFile(absPath)
+	fileData, err := ioutil.ReadFile(absPath)
+	if err == nil {
+		// If the config can't be migrated, it's loaded as written, so that any
+		// error parsing it is reported below
+		if migratedData, _, migrateErr := MigrateConfig(fileData); migrateErr == nil {
+			fileData = migratedData
+		}
+	}
+
+	var cfg *config.Config
+	if err == nil {
+		cfg, err = config.ParseYamlBytes(fileData)
+	}
 	if err != nil {
 		fmt.Println("\n\n\033[1m ERROR:\033[0m Could not load '\033[0;33mconfig.yml\033[0m'.\n Please add a \033[0;33mconfig.yml\033[0m file to your \033[0;33m~/.config/wtf\033[0m directory.\n See \033[1;34mhttps://github.com/senorprogrammer/wtf\033[0m for details.")
 		fmt.Printf(" %s\n", err.Error())
@@ -141,6 +174,7 @@ const simpleConfig = `wtf:
       focusable: darkslateblue
       focused: orange
       normal: gray
+  configVersion: %d
   grid:
     columns: [40, 40]
     rows: [13, 13, 4]
@@ -0,0 +1,265 @@
+package cfg
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"gopkg.in/yaml.v2"
+)
+
+// ConfigVersion is the version of the config file format this version of the app
+// reads. Config files without a 'wtf.configVersion' are version 0
+const ConfigVersion = 2
+
+// Migration rewrites the settings of a config file that are in the format that
+// preceded Version. Migrate returns the rewritten config and a description of
+// each change it made
+type Migration struct {
+	Version     int
+	Description string
+	Migrate     func(root yaml.MapSlice) (yaml.MapSlice, []string)
+}
+
+// Migrations is the list of migrations, in the order they are applied
+var Migrations = []Migration{
+	{
+		Version:     1,
+		Description: "Rename single-source settings to their list form",
+		Migrate:     migrateSingleSources,
+	},
+	{
+		Version:     2,
+		Description: "Move the todo module's colors to the top-level colors, where they are read from",
+		Migrate:     migrateTodoColors,
+	},
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// MigrateConfig applies, in order, all the migrations newer than the config's
+// version to the config, and returns the migrated config and a description of
+// each change made. If there is nothing to change, the config is returned as is
+func MigrateConfig(fileData []byte) (migratedData []byte, changes []string, err error) {
+	migratedData, changes, _, err = migrateConfig(fileData)
+	return migratedData, changes, err
+}
+
+// MigrateConfigFile applies, in order, all the migrations newer than the config
+// file's version. Before the file is rewritten, the original is copied to a
+// backup file, whose path is returned. If dryRun is true, the changes are
+// returned but nothing is written. If there is nothing to change, the file
+// is left untouched.
+//
+// The file is rewritten from its parsed settings, so comments aren't kept. This
+// is only done by 'wtf config migrate': when the app starts, LoadConfigFile
+// migrates the config in memory instead
+func MigrateConfigFile(filePath string, dryRun bool) (changes []string, backupPath string, err error) {
+	fileData, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return nil, "", err
+	}
+
+	migratedData, changes, version, err := migrateConfig(fileData)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %v", filePath, err)
+	}
+
+	if len(changes) == 0 || dryRun {
+		return changes, "", nil
+	}
+
+	backupPath = fmt.Sprintf("%s.v%d.bak", filePath, version)
+	if err := ioutil.WriteFile(backupPath, fileData, 0644); err != nil {
+		return nil, "", err
+	}
+
+	return changes, backupPath, ioutil.WriteFile(filePath, migratedData, 0644)
+}
+
+/* -------------------- Migrations -------------------- */
+
+// migrateSingleSources rewrites the singular form of the multi-source modules'
+// settings, i.e.: textfile's 'filePath', as the list form, i.e.: 'filePaths'
+func migrateSingleSources(root yaml.MapSlice) (yaml.MapSlice, []string) {
+	changes := []string{}
+
+	for _, source := range []struct{ module, singular, plural string }{
+		{"git", "repository", "repositories"},
+		{"textfile", "filePath", "filePaths"},
+		{"twitter", "screenName", "screenNames"},
+	} {
+		var value interface{}
+		var ok bool
+
+		root, value, ok = remove(root, "wtf", "mods", source.module, source.singular)
+		if !ok {
+			continue
+		}
+
+		list, _ := lookup(root, "wtf", "mods", source.module, source.plural)
+		values, _ := list.([]interface{})
+
+		root = assign(root, append(values, value), "wtf", "mods", source.module, source.plural)
+
+		changes = append(changes, fmt.Sprintf(
+			"moved wtf.mods.%s.%s into wtf.mods.%s.%s",
+			source.module, source.singular, source.module, source.plural,
+		))
+	}
+
+	return root, changes
+}
+
+// migrateTodoColors moves the todo module's 'checked' and 'highlight' colors to
+// the top-level colors, unless those are already set
+func migrateTodoColors(root yaml.MapSlice) (yaml.MapSlice, []string) {
+	changes := []string{}
+
+	for _, name := range []string{"checked", "highlight"} {
+		if _, ok := lookup(root, "wtf", "mods", "todo", "colors", name); !ok {
+			continue
+		}
+
+		if _, ok := lookup(root, "wtf", "colors", name); ok {
+			changes = append(changes, fmt.Sprintf(
+				"left wtf.mods.todo.colors.%s unused because wtf.colors.%s is already set",
+				name, name,
+			))
+			continue
+		}
+
+		var value interface{}
+		root, value, _ = remove(root, "wtf", "mods", "todo", "colors", name)
+		root = assign(root, value, "wtf", "colors", name)
+
+		changes = append(changes, fmt.Sprintf("moved wtf.mods.todo.colors.%s to wtf.colors.%s", name, name))
+	}
+
+	return root, changes
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// migrateConfig does the work of MigrateConfig, and also returns the version the
+// config was migrated from
+func migrateConfig(fileData []byte) ([]byte, []string, int, error) {
+	root := yaml.MapSlice{}
+	if err := yaml.Unmarshal(fileData, &root); err != nil {
+		return nil, nil, 0, fmt.Errorf("could not parse the config: %v", err)
+	}
+
+	version := 0
+	if value, ok := lookup(root, "wtf", "configVersion"); ok {
+		if version, ok = value.(int); !ok {
+			return nil, nil, 0, fmt.Errorf("wtf.configVersion must be a number, not '%v'", value)
+		}
+	}
+
+	if version > ConfigVersion {
+		return nil, nil, 0, fmt.Errorf("config version %d is newer than this version of wtf understands, which is up to version %d", version, ConfigVersion)
+	}
+
+	changes := []string{}
+	for _, migration := range Migrations {
+		if migration.Version <= version {
+			continue
+		}
+
+		var migrated []string
+		root, migrated = migration.Migrate(root)
+
+		for _, change := range migrated {
+			changes = append(changes, fmt.Sprintf("v%d: %s", migration.Version, change))
+		}
+	}
+
+	if len(changes) == 0 {
+		return fileData, changes, version, nil
+	}
+
+	root = assign(root, ConfigVersion, "wtf", "configVersion")
+
+	migratedData, err := yaml.Marshal(root)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	return migratedData, changes, version, nil
+}
+
+// assign sets the value at the path, creating any missing parents, and returns
+// the updated node
+func assign(node yaml.MapSlice, value interface{}, path ...string) yaml.MapSlice {
+	for idx, item := range node {
+		if item.Key != path[0] {
+			continue
+		}
+
+		if len(path) == 1 {
+			node[idx].Value = value
+		} else {
+			child, _ := item.Value.(yaml.MapSlice)
+			node[idx].Value = assign(child, value, path[1:]...)
+		}
+
+		return node
+	}
+
+	if len(path) == 1 {
+		return append(node, yaml.MapItem{Key: path[0], Value: value})
+	}
+
+	return append(node, yaml.MapItem{Key: path[0], Value: assign(yaml.MapSlice{}, value, path[1:]...)})
+}
+
+// lookup returns the value at the path, and whether or not it exists
+func lookup(node yaml.MapSlice, path ...string) (interface{}, bool) {
+	for _, item := range node {
+		if item.Key != path[0] {
+			continue
+		}
+
+		if len(path) == 1 {
+			return item.Value, true
+		}
+
+		child, ok := item.Value.(yaml.MapSlice)
+		if !ok {
+			return nil, false
+		}
+
+		return lookup(child, path[1:]...)
+	}
+
+	return nil, false
+}
+
+// remove deletes the value at the path, and any parents it leaves empty. Returns
+// the updated node, the value, and whether or not it existed
+func remove(node yaml.MapSlice, path ...string) (yaml.MapSlice, interface{}, bool) {
+	for idx, item := range node {
+		if item.Key != path[0] {
+			continue
+		}
+
+		if len(path) == 1 {
+			return append(node[:idx:idx], node[idx+1:]...), item.Value, true
+		}
+
+		child, ok := item.Value.(yaml.MapSlice)
+		if !ok {
+			return node, nil, false
+		}
+
+		child, value, found := remove(child, path[1:]...)
+		if found && len(child) == 0 {
+			return append(node[:idx:idx], node[idx+1:]...), value, true
+		}
+
+		node[idx].Value = child
+
+		return node, value, found
+	}
+
+	return node, nil, false
+}
@@ -0,0 +1,317 @@
+package cfg
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/olebedev/config"
+)
+
+// maskedValue replaces the values of secrets when displaying the config
+const maskedValue = "********"
+
+// secretKey matches the names of the settings that hold credentials
+var secretKey = regexp.MustCompile(`(?i)(key|password|secret|token)$`)
+
+// modsLine matches the line that opens the 'mods' section of the config file
+var modsLine = regexp.MustCompile(`(?m)^( *)mods:[ \t]*(#.*)?$`)
+
+// ModulePosition defines where in the grid a module is displayed
+type ModulePosition struct {
+	Top    int
+	Left   int
+	Width  int
+	Height int
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// AddModule inserts the named module into the config file at filePath, enabled,
+// at the given position, and configured with its default settings. The rest
+// of the file, including its comments, is left untouched
+func AddModule(filePath, name, defaults string, position ModulePosition) error {
+	fileData, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return err
+	}
+
+	existing, err := config.ParseYamlBytes(fileData)
+	if err != nil {
+		return fmt.Errorf("could not parse %s: %v", filePath, err)
+	}
+
+	if _, err := existing.Get("wtf.mods." + name); err == nil {
+		return fmt.Errorf("'%s' is already in %s", name, filePath)
+	}
+
+	updated, err := insertModule(string(fileData), name, defaults, position)
+	if err != nil {
+		return err
+	}
+
+	if _, err := config.ParseYaml(updated); err != nil {
+		return fmt.Errorf("adding '%s' would make %s invalid: %v", name, filePath, err)
+	}
+
+	return ioutil.WriteFile(filePath, []byte(updated), 0644)
+}
+
+// EffectiveConfig returns the config as the app sees it: each module's settings
+// merged over its default settings, as returned by defaultsFor, with the values
+// of secrets such as API keys and passwords masked
+func EffectiveConfig(cfg *config.Config, defaultsFor func(string) string) (string, error) {
+	effective, err := cfg.Copy()
+	if err != nil {
+		return "", err
+	}
+
+	mods, _ := effective.Map("wtf.mods")
+	for name, settings := range mods {
+		userSettings, ok := settings.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		defaults, err := config.ParseYaml(defaultsFor(name))
+		if err != nil {
+			return "", fmt.Errorf("invalid defaults for '%s': %v", name, err)
+		}
+
+		if defaultSettings, ok := defaults.Root.(map[string]interface{}); ok {
+			mods[name] = mergeSettings(defaultSettings, userSettings)
+		}
+	}
+
+	maskSecrets(effective.Root)
+
+	return config.RenderYaml(effective.Root)
+}
+
+// FreeGridCell returns the top-left-most position in the grid where a module of
+// the given size fits without overlapping any enabled module
+func FreeGridCell(cfg *config.Config, width, height int) (ModulePosition, error) {
+	rows := len(cfg.UList("wtf.grid.rows"))
+	columns := len(cfg.UList("wtf.grid.columns"))
+
+	taken := make([][]bool, rows)
+	for row := range taken {
+		taken[row] = make([]bool, columns)
+	}
+
+	mods, _ := cfg.Map("wtf.mods")
+	for name := range mods {
+		if !cfg.UBool("wtf.mods."+name+".enabled", false) {
+			continue
+		}
+
+		top := cfg.UInt("wtf.mods." + name + ".position.top")
+		left := cfg.UInt("wtf.mods." + name + ".position.left")
+
+		for row := top; row < top+cfg.UInt("wtf.mods."+name+".position.height", 1) && row < rows; row++ {
+			for column := left; column < left+cfg.UInt("wtf.mods."+name+".position.width", 1) && column < columns; column++ {
+				if row >= 0 && column >= 0 {
+					taken[row][column] = true
+				}
+			}
+		}
+	}
+
+	for top := 0; top+height <= rows; top++ {
+		for left := 0; left+width <= columns; left++ {
+			if isFree(taken, top, left, width, height) {
+				return ModulePosition{Top: top, Left: left, Width: width, Height: height}, nil
+			}
+		}
+	}
+
+	return ModulePosition{}, fmt.Errorf("there is no free %dx%d space in the grid; use --at to place the module", width, height)
+}
+
+// InitConfigFile writes the skeleton of a config file, with an empty grid and no
+// modules, to filePath. An existing config file is only overwritten if force is true
+func InitConfigFile(filePath string, force bool) error {
+	if file, err := os.Stat(filePath); err == nil && file.Size() > 0 && !force {
+		return fmt.Errorf("%s already exists; use --force to overwrite it", filePath)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(filePath), os.ModePerm); err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(filePath, []byte(fmt.Sprintf(configSkeleton, ConfigVersion)), 0644)
+}
+
+// ParsePosition parses a grid position given as "row,column", i.e.: "2,0"
+func ParsePosition(at string) (top, left int, err error) {
+	if _, err := fmt.Sscanf(at, "%d,%d", &top, &left); err != nil {
+		return 0, 0, fmt.Errorf("invalid position '%s', expected row,column, i.e.: 2,0", at)
+	}
+
+	return top, left, nil
+}
+
+// ParseSize parses a module size given as "widthxheight", i.e.: "2x1"
+func ParseSize(size string) (width, height int, err error) {
+	if _, err := fmt.Sscanf(size, "%dx%d", &width, &height); err != nil || width < 1 || height < 1 {
+		return 0, 0, fmt.Errorf("invalid size '%s', expected widthxheight, i.e.: 2x1", size)
+	}
+
+	return width, height, nil
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// insertModule returns the config file text with the module inserted into the
+// 'mods' section, in alphabetical order
+func insertModule(text, name, defaults string, position ModulePosition) (string, error) {
+	loc := modsLine.FindStringSubmatchIndex(text)
+	if loc == nil {
+		return "", fmt.Errorf("could not find the 'mods' section of the config file")
+	}
+
+	modsIndent := loc[3] - loc[2]
+	indent := strings.Repeat(" ", modsIndent+2)
+
+	lines := strings.SplitAfter(text[loc[1]:], "\n")
+	offset := loc[1]
+
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		lineIndent := len(line) - len(strings.TrimLeft(line, " "))
+
+		if trimmed != "" && !strings.HasPrefix(trimmed, "#") {
+			if lineIndent <= modsIndent {
+				break
+			}
+
+			if lineIndent == modsIndent+2 && strings.TrimSuffix(strings.Fields(trimmed)[0], ":") > name {
+				break
+			}
+		}
+
+		offset += len(line)
+	}
+
+	prefix := text[:offset]
+	if !strings.HasSuffix(prefix, "\n") {
+		prefix = prefix + "\n"
+	}
+
+	return prefix + moduleYaml(name, defaults, position, indent) + text[offset:], nil
+}
+
+func isFree(taken [][]bool, top, left, width, height int) bool {
+	for row := top; row < top+height; row++ {
+		for column := left; column < left+width; column++ {
+			if taken[row][column] {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// maskSecrets replaces, in place, the values of all the settings that look like
+// credentials
+func maskSecrets(node interface{}) {
+	switch typed := node.(type) {
+	case map[string]interface{}:
+		for key, value := range typed {
+			if str, ok := value.(string); ok && str != "" && secretKey.MatchString(key) {
+				typed[key] = maskedValue
+				continue
+			}
+
+			maskSecrets(value)
+		}
+	case []interface{}:
+		for _, value := range typed {
+			maskSecrets(value)
+		}
+	}
+}
+
+// mergeSettings returns the default settings overridden by the user's settings.
+// Nested settings are merged, anything else is replaced
+func mergeSettings(defaults, overrides map[string]interface{}) map[string]interface{} {
+	merged := map[string]interface{}{}
+
+	for key, value := range defaults {
+		merged[key] = value
+	}
+
+	for key, value := range overrides {
+		defaultMap, defaultIsMap := merged[key].(map[string]interface{})
+		overrideMap, overrideIsMap := value.(map[string]interface{})
+
+		if defaultIsMap && overrideIsMap {
+			merged[key] = mergeSettings(defaultMap, overrideMap)
+		} else {
+			merged[key] = value
+		}
+	}
+
+	return merged
+}
+
+// moduleYaml renders the module's config block: its default settings plus its
+// enabled state and position, with the top-level settings in alphabetical order
+func moduleYaml(name, defaults string, position ModulePosition, indent string) string {
+	settings := map[string]string{
+		"enabled": "enabled: true\n",
+		"position": fmt.Sprintf(
+			"position:\n  top: %d\n  left: %d\n  height: %d\n  width: %d\n",
+			position.Top, position.Left, position.Height, position.Width,
+		),
+	}
+
+	key := ""
+	for _, line := range strings.SplitAfter(strings.TrimSpace(defaults)+"\n", "\n") {
+		if line == "" {
+			continue
+		}
+
+		if !strings.HasPrefix(line, " ") && !strings.HasPrefix(line, "-") {
+			key = strings.SplitN(line, ":", 2)[0]
+		}
+
+		settings[key] = settings[key] + line
+	}
+
+	keys := []string{}
+	for key := range settings {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	str := indent + name + ":\n"
+	for _, key := range keys {
+		for _, line := range strings.SplitAfter(settings[key], "\n") {
+			if line != "" {
+				str = str + indent + "  " + line
+			}
+		}
+	}
+
+	return str
+}
+
+const configSkeleton = `wtf:
+  colors:
+    border:
+      focusable: darkslateblue
+      focused: orange
+      normal: gray
+  configVersion: %d
+  grid:
+    columns: [40, 40]
+    rows: [13, 13, 4]
+  refreshInterval: 1
+  mods:
+`
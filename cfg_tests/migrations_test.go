@@ -0,0 +1,101 @@
+package cfg_tests
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/olebedev/config"
+	. "github.com/senorprogrammer/wtf/cfg"
+	. "github.com/stretchr/testify/assert"
+)
+
+const oldConfig = `wtf:
+  grid:
+    columns: [40]
+    rows: [10]
+  mods:
+    textfile:
+      filePath: "~/notes.md"
+      filePaths:
+      - "~/todo.md"
+    todo:
+      colors:
+        checked: gray
+`
+
+/* -------------------- MigrateConfigFile() -------------------- */
+
+func TestMigrateConfigFile(t *testing.T) {
+	filePath, cleanup := tempConfigFile(t)
+	defer cleanup()
+
+	ioutil.WriteFile(filePath, []byte(oldConfig), 0644)
+
+	changes, backupPath, err := MigrateConfigFile(filePath, false)
+	Nil(t, err)
+	Equal(t, 2, len(changes))
+	Equal(t, filePath+".v0.bak", backupPath)
+
+	backupData, _ := ioutil.ReadFile(backupPath)
+	Equal(t, oldConfig, string(backupData))
+
+	migrated, err := config.ParseYamlFile(filePath)
+	Nil(t, err)
+	Equal(t, ConfigVersion, migrated.UInt("wtf.configVersion"))
+	Equal(t, []interface{}{"~/todo.md", "~/notes.md"}, migrated.UList("wtf.mods.textfile.filePaths"))
+	Equal(t, "", migrated.UString("wtf.mods.textfile.filePath"))
+	Equal(t, "gray", migrated.UString("wtf.colors.checked"))
+
+	_, err = migrated.Get("wtf.mods.todo.colors")
+	NotNil(t, err)
+
+	changes, _, err = MigrateConfigFile(filePath, false)
+	Nil(t, err)
+	Empty(t, changes)
+}
+
+func TestMigrateConfigFileDryRun(t *testing.T) {
+	filePath, cleanup := tempConfigFile(t)
+	defer cleanup()
+
+	ioutil.WriteFile(filePath, []byte(oldConfig), 0644)
+
+	changes, backupPath, err := MigrateConfigFile(filePath, true)
+	Nil(t, err)
+	Equal(t, 2, len(changes))
+	Equal(t, "", backupPath)
+
+	fileData, _ := ioutil.ReadFile(filePath)
+	Equal(t, oldConfig, string(fileData))
+}
+
+func TestMigrateConfigFileUpToDate(t *testing.T) {
+	filePath, cleanup := tempConfigFile(t)
+	defer cleanup()
+
+	changes, backupPath, err := MigrateConfigFile(filePath, false)
+	Nil(t, err)
+	Empty(t, changes)
+	Equal(t, "", backupPath)
+
+	fileData, _ := ioutil.ReadFile(filePath)
+	Equal(t, testConfig, string(fileData))
+}
+
+/* -------------------- LoadConfigFile() -------------------- */
+
+func TestLoadConfigFileMigratesInMemory(t *testing.T) {
+	filePath, cleanup := tempConfigFile(t)
+	defer cleanup()
+
+	commented := "# My dashboard\n" + oldConfig
+	ioutil.WriteFile(filePath, []byte(commented), 0644)
+
+	loaded := LoadConfigFile(filePath)
+	Equal(t, []interface{}{"~/todo.md", "~/notes.md"}, loaded.UList("wtf.mods.textfile.filePaths"))
+	Equal(t, "gray", loaded.UString("wtf.colors.checked"))
+
+	// The file, and its comments, are left as they are
+	fileData, _ := ioutil.ReadFile(filePath)
+	Equal(t, commented, string(fileData))
+}
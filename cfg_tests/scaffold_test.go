@@ -0,0 +1,115 @@
+package cfg_tests
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/olebedev/config"
+	. "github.com/senorprogrammer/wtf/cfg"
+	. "github.com/stretchr/testify/assert"
+)
+
+const testConfig = `wtf:
+  grid:
+    columns: [40, 40]
+    rows: [10, 10]
+  mods:
+    # The clock
+    clocks:
+      enabled: true
+      position:
+        top: 0
+        left: 0
+        height: 1
+        width: 2
+    jira:
+      apiKey: "s3cr3t"
+      enabled: true
+      position:
+        top: 1
+        left: 0
+        height: 1
+        width: 1
+`
+
+func tempConfigFile(t *testing.T) (string, func()) {
+	dir, err := ioutil.TempDir("", "wtf")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	filePath := filepath.Join(dir, "config.yml")
+	ioutil.WriteFile(filePath, []byte(testConfig), 0644)
+
+	return filePath, func() { os.RemoveAll(dir) }
+}
+
+/* -------------------- AddModule() -------------------- */
+
+func TestAddModule(t *testing.T) {
+	filePath, cleanup := tempConfigFile(t)
+	defer cleanup()
+
+	position := ModulePosition{Top: 1, Left: 1, Width: 1, Height: 1}
+	err := AddModule(filePath, "git", "commitCount: 10\nrepositories:\n- \"~/src/wtf\"\n", position)
+	Nil(t, err)
+
+	fileData, _ := ioutil.ReadFile(filePath)
+	text := string(fileData)
+
+	True(t, strings.Contains(text, "# The clock"))
+	True(t, strings.Index(text, "    git:") < strings.Index(text, "    jira:"))
+
+	cfg, err := config.ParseYaml(text)
+	Nil(t, err)
+	Equal(t, true, cfg.UBool("wtf.mods.git.enabled"))
+	Equal(t, 10, cfg.UInt("wtf.mods.git.commitCount"))
+	Equal(t, 1, cfg.UInt("wtf.mods.git.position.left"))
+	Equal(t, []interface{}{"~/src/wtf"}, cfg.UList("wtf.mods.git.repositories"))
+
+	NotNil(t, AddModule(filePath, "git", "", position))
+}
+
+/* -------------------- EffectiveConfig() -------------------- */
+
+func TestEffectiveConfig(t *testing.T) {
+	cfg, _ := config.ParseYaml(testConfig)
+
+	str, err := EffectiveConfig(cfg, func(name string) string {
+		return "jql: \"\"\nverifyServerCertificate: true\n"
+	})
+	Nil(t, err)
+
+	effective, _ := config.ParseYaml(str)
+	Equal(t, "********", effective.UString("wtf.mods.jira.apiKey"))
+	Equal(t, true, effective.UBool("wtf.mods.jira.verifyServerCertificate"))
+	Equal(t, true, effective.UBool("wtf.mods.clocks.enabled"))
+}
+
+/* -------------------- FreeGridCell() -------------------- */
+
+func TestFreeGridCell(t *testing.T) {
+	cfg, _ := config.ParseYaml(testConfig)
+
+	position, err := FreeGridCell(cfg, 1, 1)
+	Nil(t, err)
+	Equal(t, ModulePosition{Top: 1, Left: 1, Width: 1, Height: 1}, position)
+
+	_, err = FreeGridCell(cfg, 2, 1)
+	NotNil(t, err)
+}
+
+/* -------------------- ParseSize() -------------------- */
+
+func TestParseSize(t *testing.T) {
+	width, height, err := ParseSize("2x1")
+	Nil(t, err)
+	Equal(t, 2, width)
+	Equal(t, 1, height)
+
+	_, _, err = ParseSize("wide")
+	NotNil(t, err)
+}
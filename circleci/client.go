@@ -47,7 +47,7 @@ func circleRequest(path string) (*http.Response, error) {
 		return nil, err
 	}
 
-	httpClient := &http.Client{}
+	httpClient := wtf.NewHTTPClient("circleci", nil)
 	resp, err := httpClient.Do(req)
 	if err != nil {
 		return nil, err
@@ -7,6 +7,11 @@ import (
 	"github.com/senorprogrammer/wtf/wtf"
 )
 
+const ConfigDefaults = `
+apiKey: ""
+refreshInterval: 900
+`
+
 type Widget struct {
 	wtf.TextWidget
 }
@@ -16,6 +21,8 @@ func NewWidget(app *tview.Application) *Widget {
 		TextWidget: wtf.NewTextWidget(app, "CircleCI", "circleci", false),
 	}
 
+	widget.SetRateLimitHost(circleAPIURL.Host)
+
 	return &widget
 }
 
@@ -41,7 +48,7 @@ func (widget *Widget) Refresh() {
 		content = widget.contentFrom(builds)
 	}
 
-	widget.View.SetText(content)
+	widget.SetText(content)
 }
 
 /* -------------------- Unexported Functions -------------------- */
@@ -54,8 +61,9 @@ func (widget *Widget) contentFrom(builds []*Build) string {
 		}
 
 		str = str + fmt.Sprintf(
-			"[%s] %s-%d (%s) [white]%s\n",
+			"[%s] %s%s-%d (%s) [white]%s\n",
 			buildColor(build),
+			wtf.StatusMarker(buildColor(build)),
 			build.Reponame,
 			build.BuildNum,
 			build.Branch,
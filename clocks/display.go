@@ -8,7 +8,7 @@ import (
 
 func (widget *Widget) display(clocks []Clock) {
 	if len(clocks) == 0 {
-		widget.View.SetText(fmt.Sprintf("\n%s", " no timezone data available"))
+		widget.SetText(fmt.Sprintf("\n%s", " no timezone data available"))
 		return
 	}
 
@@ -23,5 +23,5 @@ func (widget *Widget) display(clocks []Clock) {
 		)
 	}
 
-	widget.View.SetText(str)
+	widget.SetText(str)
 }
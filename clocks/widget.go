@@ -8,6 +8,17 @@ import (
 	"github.com/senorprogrammer/wtf/wtf"
 )
 
+const ConfigDefaults = `
+colors:
+  rows:
+    even: "white"
+    odd: "lightblue"
+locations:
+  UTC: "Etc/UTC"
+refreshInterval: 15
+sort: "alphabetical"
+`
+
 type Widget struct {
 	wtf.TextWidget
 
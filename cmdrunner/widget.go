@@ -2,42 +2,77 @@ package cmdrunner
 
 import (
 	"fmt"
-	"os/exec"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/rivo/tview"
 	"github.com/senorprogrammer/wtf/wtf"
 )
 
+const ConfigDefaults = `
+args: []
+cmd: "uptime"
+maxLines: 200
+refreshInterval: 30
+stream: false
+`
+
 type Widget struct {
 	wtf.TextWidget
 
 	args   []string
 	cmd    string
 	result string
+
+	lines    []string
+	maxLines int
+	mutex    sync.Mutex
+	running  *wtf.Command
+	stream   bool
 }
 
 func NewWidget(app *tview.Application) *Widget {
 	widget := Widget{
-		TextWidget: wtf.NewTextWidget(app, "CmdRunner", "cmdrunner", false),
+		TextWidget: wtf.NewTextWidget(app, "CmdRunner", "cmdrunner", true),
 
-		args: wtf.ToStrs(wtf.Config.UList("wtf.mods.cmdrunner.args")),
-		cmd:  wtf.Config.UString("wtf.mods.cmdrunner.cmd"),
+		args:     wtf.ToStrs(wtf.Config.UList("wtf.mods.cmdrunner.args")),
+		cmd:      wtf.Config.UString("wtf.mods.cmdrunner.cmd"),
+		maxLines: wtf.Config.UInt("wtf.mods.cmdrunner.maxLines", 200),
+		stream:   wtf.Config.UBool("wtf.mods.cmdrunner.stream", false),
 	}
 
+	widget.SetFilterMode(wtf.FilterMatches)
 	widget.View.SetWrap(true)
 
 	return &widget
 }
 
+// Disable stops the streamed command, if it's running, along with the refreshes
+func (widget *Widget) Disable() {
+	widget.mutex.Lock()
+	widget.TextWidget.Disable()
+	running := widget.running
+	widget.mutex.Unlock()
+
+	if running != nil {
+		running.Stop()
+	}
+}
+
 func (widget *Widget) Refresh() {
 	widget.UpdateRefreshedAt()
-	widget.execute()
 
 	title := tview.TranslateANSI(wtf.Config.UString("wtf.mods.cmdrunner.title", widget.String()))
 	widget.View.SetTitle(title)
 
-	widget.View.SetText(widget.result)
+	if widget.stream {
+		widget.startStream()
+		return
+	}
+
+	widget.execute()
+	widget.SetText(widget.result)
 }
 
 func (widget *Widget) String() string {
@@ -50,7 +85,85 @@ func (widget *Widget) String() string {
 	return fmt.Sprintf(" %s ", widget.cmd)
 }
 
+/* -------------------- Unexported Functions -------------------- */
+
+// addLine appends a line of streamed output, dropping the oldest lines once there
+// are more than maxLines
+func (widget *Widget) addLine(line string) {
+	widget.mutex.Lock()
+	widget.lines = append(widget.lines, tview.TranslateANSI(line))
+	if widget.maxLines > 0 && len(widget.lines) > widget.maxLines {
+		widget.lines = widget.lines[len(widget.lines)-widget.maxLines:]
+	}
+	text := strings.Join(widget.lines, "\n")
+	widget.mutex.Unlock()
+
+	widget.SetText(text)
+	widget.View.ScrollToEnd()
+}
+
+// command returns the command to run. Streamed commands run until they exit
+// unless a timeout is configured
+func (widget *Widget) command() *wtf.Command {
+	cmd := wtf.NewCommand(widget.cmd, widget.args...)
+
+	defaultTimeout := int(cmd.Timeout / time.Second)
+	if widget.stream {
+		defaultTimeout = 0
+	}
+
+	cmd.Timeout = time.Duration(wtf.Config.UInt("wtf.mods.cmdrunner.timeout", defaultTimeout)) * time.Second
+
+	return cmd
+}
+
 func (widget *Widget) execute() {
-	cmd := exec.Command(widget.cmd, widget.args...)
-	widget.result = tview.TranslateANSI(wtf.ExecuteCommand(cmd))
+	result := widget.command().Run()
+
+	widget.result = tview.TranslateANSI(result.Stdout)
+	if result.Err != nil {
+		widget.result = widget.result + failure(result)
+	}
+}
+
+// startStream runs the command in the background, displaying its output as it's
+// written. If the command is still running from a previous refresh, it's left be.
+// The running command is kept so that disabling the widget can stop it
+func (widget *Widget) startStream() {
+	widget.mutex.Lock()
+	if widget.running != nil || widget.Disabled() {
+		widget.mutex.Unlock()
+		return
+	}
+	cmd := widget.command()
+	widget.running = cmd
+	widget.lines = []string{}
+	widget.mutex.Unlock()
+
+	go func() {
+		result := cmd.Stream(widget.addLine)
+
+		widget.mutex.Lock()
+		widget.running = nil
+		widget.mutex.Unlock()
+
+		if result.Err != nil && !result.Stopped {
+			widget.addLine(failure(result))
+		}
+	}()
+}
+
+// failure describes why the command failed, followed by what it wrote to stderr
+func failure(result wtf.CommandResult) string {
+	reason := result.Err.Error()
+	if result.ExitCode > 0 && !result.TimedOut {
+		reason = fmt.Sprintf("exited with code %d", result.ExitCode)
+	}
+
+	str := fmt.Sprintf("\n[red]%s[white]", tview.Escape(reason))
+	if stderr := strings.TrimSpace(result.Stderr); stderr != "" {
+		str = str + "\n" + tview.Escape(stderr)
+	}
+
+	return str
 }
@@ -8,11 +8,11 @@ import (
 
 func (widget *Widget) display() {
 	if ok == false {
-		widget.View.SetText(errorText)
+		widget.SetText(errorText)
 		return
 	}
 
-	widget.View.SetText(summaryText(&widget.summaryList, &widget.TextColors))
+	widget.SetText(summaryText(&widget.summaryList, &widget.TextColors))
 }
 
 func summaryText(list *summaryList, colors *TextColors) string {
@@ -11,6 +11,24 @@ import (
 	"github.com/senorprogrammer/wtf/wtf"
 )
 
+const ConfigDefaults = `
+colors:
+  base:
+    displayName: "grey"
+    name: "red"
+  market:
+    field: "coral"
+    name: "red"
+    value: "white"
+refreshInterval: 5
+summary:
+  BTC:
+    displayName: "Bitcoin"
+    market:
+    - "LTC"
+    - "ETH"
+`
+
 type TextColors struct {
 	base struct {
 		name        string
@@ -11,6 +11,16 @@ import (
 	"github.com/senorprogrammer/wtf/wtf"
 )
 
+const ConfigDefaults = `
+colors:
+  drop: "red"
+  grows: "green"
+  name: "blue"
+device_token: ""
+displayHoldings: true
+refreshInterval: 400
+`
+
 type Widget struct {
 	wtf.TextWidget
 
@@ -37,7 +47,7 @@ func (widget *Widget) Refresh() {
 		return
 	}
 
-	widget.View.SetText(contentFrom(positions))
+	widget.SetText(contentFrom(positions))
 }
 
 /* -------------------- Unexported Functions -------------------- */
@@ -10,6 +10,37 @@ import (
 	"github.com/senorprogrammer/wtf/wtf"
 )
 
+const ConfigDefaults = `
+colors:
+  from:
+    displayName: "grey"
+    name: "coral"
+  to:
+    name: "white"
+    price: "green"
+  top:
+    from:
+      displayName: "grey"
+      name: "coral"
+    to:
+      field: "white"
+      name: "red"
+      value: "value"
+currencies:
+  BTC:
+    displayName: "Bitcoin"
+    to:
+    - "USD"
+    - "EUR"
+refreshInterval: 15
+top:
+  BTC:
+    displayName: "Bitcoin"
+    limit: 1
+    to:
+    - "USD"
+`
+
 // Widget define wtf widget to register widget later
 type Widget struct {
 	wtf.TextWidget
@@ -53,5 +84,5 @@ func display(widget *Widget) {
 	str := ""
 	str += widget.priceWidget.Result
 	str += widget.toplistWidget.Result
-	widget.View.SetText(fmt.Sprintf("\n%s", str))
+	widget.SetText(fmt.Sprintf("\n%s", str))
 }
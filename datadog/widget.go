@@ -8,6 +8,14 @@ import (
 	datadog "github.com/zorkian/go-datadog-api"
 )
 
+const ConfigDefaults = `
+apiKey: ""
+applicationKey: ""
+monitors:
+  tags: []
+refreshInterval: 300
+`
+
 type Widget struct {
 	wtf.TextWidget
 }
@@ -38,7 +46,7 @@ func (widget *Widget) Refresh() {
 		content = widget.contentFrom(monitors)
 	}
 
-	widget.View.SetText(content)
+	widget.SetText(content)
 }
 
 /* -------------------- Unexported Functions -------------------- */
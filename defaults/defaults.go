@@ -0,0 +1,110 @@
+package defaults
+
+import (
+	"sort"
+
+	"github.com/senorprogrammer/wtf/bamboohr"
+	"github.com/senorprogrammer/wtf/bargraph"
+	"github.com/senorprogrammer/wtf/circleci"
+	"github.com/senorprogrammer/wtf/clocks"
+	"github.com/senorprogrammer/wtf/cmdrunner"
+	"github.com/senorprogrammer/wtf/cryptoexchanges/bittrex"
+	"github.com/senorprogrammer/wtf/cryptoexchanges/blockfolio"
+	"github.com/senorprogrammer/wtf/cryptoexchanges/cryptolive"
+	"github.com/senorprogrammer/wtf/datadog"
+	"github.com/senorprogrammer/wtf/gcal"
+	"github.com/senorprogrammer/wtf/gerrit"
+	"github.com/senorprogrammer/wtf/git"
+	"github.com/senorprogrammer/wtf/github"
+	"github.com/senorprogrammer/wtf/gitlab"
+	"github.com/senorprogrammer/wtf/gitter"
+	"github.com/senorprogrammer/wtf/gspreadsheets"
+	"github.com/senorprogrammer/wtf/hackernews"
+	"github.com/senorprogrammer/wtf/ipaddresses/ipapi"
+	"github.com/senorprogrammer/wtf/ipaddresses/ipinfo"
+	"github.com/senorprogrammer/wtf/jenkins"
+	"github.com/senorprogrammer/wtf/jira"
+	"github.com/senorprogrammer/wtf/logger"
+	"github.com/senorprogrammer/wtf/newrelic"
+	"github.com/senorprogrammer/wtf/opsgenie"
+	"github.com/senorprogrammer/wtf/power"
+	"github.com/senorprogrammer/wtf/security"
+	"github.com/senorprogrammer/wtf/spotify"
+	"github.com/senorprogrammer/wtf/status"
+	"github.com/senorprogrammer/wtf/system"
+	"github.com/senorprogrammer/wtf/textfile"
+	"github.com/senorprogrammer/wtf/todo"
+	"github.com/senorprogrammer/wtf/todoist"
+	"github.com/senorprogrammer/wtf/travisci"
+	"github.com/senorprogrammer/wtf/trello"
+	"github.com/senorprogrammer/wtf/twitter"
+	"github.com/senorprogrammer/wtf/weatherservices/prettyweather"
+	"github.com/senorprogrammer/wtf/weatherservices/weather"
+	"github.com/senorprogrammer/wtf/zendesk"
+)
+
+// moduleDefaults maps each module's config key onto its default settings. Each
+// module lists its settings, and their default values, as YAML in a ConfigDefaults
+// constant next to its HelpText. 'wtf config add' inserts them into the config
+// file, so a module's settings belong in ConfigDefaults as well as in its docs
+var moduleDefaults = map[string]string{
+	"bamboohr":      bamboohr.ConfigDefaults,
+	"bargraph":      bargraph.ConfigDefaults,
+	"bittrex":       bittrex.ConfigDefaults,
+	"blockfolio":    blockfolio.ConfigDefaults,
+	"circleci":      circleci.ConfigDefaults,
+	"clocks":        clocks.ConfigDefaults,
+	"cmdrunner":     cmdrunner.ConfigDefaults,
+	"cryptolive":    cryptolive.ConfigDefaults,
+	"datadog":       datadog.ConfigDefaults,
+	"gcal":          gcal.ConfigDefaults,
+	"gerrit":        gerrit.ConfigDefaults,
+	"git":           git.ConfigDefaults,
+	"github":        github.ConfigDefaults,
+	"gitlab":        gitlab.ConfigDefaults,
+	"gitter":        gitter.ConfigDefaults,
+	"gspreadsheets": gspreadsheets.ConfigDefaults,
+	"hackernews":    hackernews.ConfigDefaults,
+	"ipapi":         ipapi.ConfigDefaults,
+	"ipinfo":        ipinfo.ConfigDefaults,
+	"jenkins":       jenkins.ConfigDefaults,
+	"jira":          jira.ConfigDefaults,
+	"logger":        logger.ConfigDefaults,
+	"newrelic":      newrelic.ConfigDefaults,
+	"opsgenie":      opsgenie.ConfigDefaults,
+	"power":         power.ConfigDefaults,
+	"prettyweather": prettyweather.ConfigDefaults,
+	"security":      security.ConfigDefaults,
+	"spotify":       spotify.ConfigDefaults,
+	"status":        status.ConfigDefaults,
+	"system":        system.ConfigDefaults,
+	"textfile":      textfile.ConfigDefaults,
+	"todo":          todo.ConfigDefaults,
+	"todoist":       todoist.ConfigDefaults,
+	"travisci":      travisci.ConfigDefaults,
+	"trello":        trello.ConfigDefaults,
+	"twitter":       twitter.ConfigDefaults,
+	"weather":       weather.ConfigDefaults,
+	"zendesk":       zendesk.ConfigDefaults,
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// For returns the default settings of the named module, and whether or not
+// such a module exists
+func For(moduleName string) (string, bool) {
+	defaults, ok := moduleDefaults[moduleName]
+	return defaults, ok
+}
+
+// Modules returns the config keys of all the modules, in alphabetical order
+func Modules() []string {
+	names := []string{}
+	for name := range moduleDefaults {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
@@ -0,0 +1,167 @@
+package flags
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/senorprogrammer/wtf/cfg"
+	"github.com/senorprogrammer/wtf/defaults"
+)
+
+// starterModules are the modules, and their positions, that 'wtf config init'
+// puts in a new config file
+var starterModules = []struct {
+	name     string
+	position cfg.ModulePosition
+}{
+	{"clocks", cfg.ModulePosition{Top: 0, Left: 0, Width: 1, Height: 1}},
+	{"security", cfg.ModulePosition{Top: 1, Left: 0, Width: 1, Height: 1}},
+	{"status", cfg.ModulePosition{Top: 2, Left: 0, Width: 2, Height: 1}},
+	{"system", cfg.ModulePosition{Top: 0, Left: 1, Width: 1, Height: 1}},
+	{"textfile", cfg.ModulePosition{Top: 1, Left: 1, Width: 1, Height: 1}},
+}
+
+// ConfigCommand groups the subcommands that create, update and inspect the config file
+type ConfigCommand struct {
+	Add     ConfigAddCommand     `command:"add" description:"Add a module, with its default settings, to the config file"`
+	Init    ConfigInitCommand    `command:"init" description:"Create a new config file"`
+	Migrate ConfigMigrateCommand `command:"migrate" description:"Update the config file to the current config version"`
+	Show    ConfigShowCommand    `command:"show" description:"Print the effective config, with secrets masked"`
+}
+
+// ConfigAddCommand implements 'wtf config add <module>'
+type ConfigAddCommand struct {
+	At   string `long:"at" description:"Grid cell to place the module in, as row,column, i.e.: '2,0'. Defaults to the first free cell"`
+	Size string `long:"size" default:"1x1" description:"Size of the module, in grid cells, as widthxheight, i.e.: '2x1'"`
+	Args struct {
+		Module string `positional-arg-name:"module" required:"yes"`
+	} `positional-args:"yes"`
+
+	flags *Flags
+}
+
+// ConfigInitCommand implements 'wtf config init'
+type ConfigInitCommand struct {
+	Force bool `long:"force" description:"Overwrite an existing config file"`
+
+	flags *Flags
+}
+
+// ConfigMigrateCommand implements 'wtf config migrate'
+type ConfigMigrateCommand struct {
+	DryRun bool `long:"dry-run" description:"Show the changes without making them"`
+
+	flags *Flags
+}
+
+// ConfigShowCommand implements 'wtf config show'
+type ConfigShowCommand struct {
+	flags *Flags
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// Execute adds the module to the config file
+func (cmd *ConfigAddCommand) Execute(args []string) error {
+	name := cmd.Args.Module
+
+	moduleDefaults, ok := defaults.For(name)
+	if !ok {
+		return fmt.Errorf("unknown module '%s'. Modules are: %s", name, strings.Join(defaults.Modules(), ", "))
+	}
+
+	width, height, err := cfg.ParseSize(cmd.Size)
+	if err != nil {
+		return err
+	}
+
+	position := cfg.ModulePosition{Width: width, Height: height}
+
+	if cmd.At != "" {
+		position.Top, position.Left, err = cfg.ParsePosition(cmd.At)
+	} else {
+		position, err = cfg.FreeGridCell(cfg.LoadConfigFile(cmd.flags.Config), width, height)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := cfg.AddModule(cmd.flags.Config, name, moduleDefaults, position); err != nil {
+		return err
+	}
+
+	fmt.Printf("Added %s to %s at %d,%d\n", name, cmd.flags.Config, position.Top, position.Left)
+
+	return nil
+}
+
+// Execute writes a new config file containing a few starter modules
+func (cmd *ConfigInitCommand) Execute(args []string) error {
+	if err := cfg.InitConfigFile(cmd.flags.Config, cmd.Force); err != nil {
+		return err
+	}
+
+	for _, module := range starterModules {
+		moduleDefaults, _ := defaults.For(module.name)
+
+		if err := cfg.AddModule(cmd.flags.Config, module.name, moduleDefaults, module.position); err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("Wrote %s\nAdd more modules with 'wtf config add <module>'\n", cmd.flags.Config)
+
+	return nil
+}
+
+// Execute migrates the config file, or previews the migration
+func (cmd *ConfigMigrateCommand) Execute(args []string) error {
+	changes, backupPath, err := cfg.MigrateConfigFile(cmd.flags.Config, cmd.DryRun)
+	if err != nil {
+		return err
+	}
+
+	if len(changes) == 0 {
+		fmt.Printf("%s is up to date\n", cmd.flags.Config)
+		return nil
+	}
+
+	for _, change := range changes {
+		fmt.Printf("  %s\n", change)
+	}
+
+	if cmd.DryRun {
+		fmt.Printf("Run without --dry-run to migrate %s to config version %d\n", cmd.flags.Config, cfg.ConfigVersion)
+	} else {
+		fmt.Printf("Migrated %s to config version %d. Comments aren't kept: the original is in %s\n", cmd.flags.Config, cfg.ConfigVersion, backupPath)
+	}
+
+	return nil
+}
+
+// Execute prints the config as the app sees it
+func (cmd *ConfigShowCommand) Execute(args []string) error {
+	str, err := cfg.EffectiveConfig(
+		cfg.LoadConfigFile(cmd.flags.Config),
+		func(name string) string {
+			moduleDefaults, _ := defaults.For(name)
+			return moduleDefaults
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	fmt.Print(str)
+
+	return nil
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+func (cmd *ConfigCommand) setFlags(flags *Flags) {
+	cmd.Add.flags = flags
+	cmd.Init.flags = flags
+	cmd.Migrate.flags = flags
+	cmd.Show.flags = flags
+}
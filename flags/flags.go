@@ -11,14 +11,22 @@ import (
 )
 
 type Flags struct {
-	Config  string `short:"c" long:"config" optional:"yes" description:"Path to config file"`
-	Module  string `short:"m" long:"module" optional:"yes" description:"Display info about a specific module, i.e.: 'wtf -m=todo'"`
-	Profile bool   `short:"p" long:"profile" optional:"yes" description:"Profile application memory usage"`
-	Version bool   `short:"v" long:"version" description:"Show version info"`
+	Config     string `short:"c" long:"config" optional:"yes" description:"Path to config file"`
+	Module     string `short:"m" long:"module" optional:"yes" description:"Display info about a specific module, i.e.: 'wtf -m=todo'"`
+	Offline    bool   `long:"offline" optional:"yes" description:"Only display cached data, never connect to the network"`
+	Profile    bool   `short:"p" long:"profile" optional:"yes" description:"Profile application memory usage"`
+	ResetState bool   `long:"reset-state" optional:"yes" description:"Clear the saved UI state (focused widget, selections, etc.)"`
+	Version    bool   `short:"v" long:"version" description:"Show version info"`
+
+	ConfigCmd ConfigCommand `command:"config" description:"Create, add modules to, and inspect the config file"`
+
+	command goFlags.Commander
 }
 
 func NewFlags() *Flags {
 	flags := Flags{}
+	flags.ConfigCmd.setFlags(&flags)
+
 	return &flags
 }
 
@@ -29,6 +37,15 @@ func (flags *Flags) ConfigFilePath() string {
 }
 
 func (flags *Flags) Display(version string) {
+	if flags.HasCommand() {
+		if err := flags.command.Execute([]string{}); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		os.Exit(0)
+	}
+
 	if flags.HasModule() {
 		help.Display(flags.Module)
 		os.Exit(0)
@@ -40,6 +57,11 @@ func (flags *Flags) Display(version string) {
 	}
 }
 
+// HasCommand returns true if a subcommand, i.e.: 'wtf config show', was given
+func (flags *Flags) HasCommand() bool {
+	return flags.command != nil
+}
+
 func (flags *Flags) HasConfig() bool {
 	return len(flags.Config) > 0
 }
@@ -48,16 +70,36 @@ func (flags *Flags) HasModule() bool {
 	return len(flags.Module) > 0
 }
 
+func (flags *Flags) HasOffline() bool {
+	return flags.Offline == true
+}
+
+func (flags *Flags) HasResetState() bool {
+	return flags.ResetState == true
+}
+
 func (flags *Flags) HasVersion() bool {
 	return flags.Version == true
 }
 
 func (flags *Flags) Parse() {
 	parser := goFlags.NewParser(flags, goFlags.Default)
+	parser.SubcommandsOptional = true
+
+	// Subcommands are run by Display, once the config file path is known
+	parser.CommandHandler = func(command goFlags.Commander, args []string) error {
+		flags.command = command
+		return nil
+	}
+
 	if _, err := parser.Parse(); err != nil {
 		if flagsErr, ok := err.(*goFlags.Error); ok && flagsErr.Type == goFlags.ErrHelp {
 			os.Exit(0)
 		}
+
+		if parser.Active != nil {
+			os.Exit(1)
+		}
 	}
 
 	// If no config file is explicitly passed in as a param,
@@ -34,7 +34,7 @@ func (widget *Widget) display() {
 
 	_, timedEvents := widget.sortedEvents()
 	widget.View.SetTitle(widget.ContextualTitle(widget.Name))
-	widget.View.SetText(widget.contentFrom(timedEvents))
+	widget.SetText(widget.contentFrom(timedEvents))
 }
 
 func (widget *Widget) contentFrom(calEvents []*CalEvent) string {
@@ -121,13 +121,13 @@ func (widget *Widget) eventSummary(calEvent *CalEvent, conflict bool) string {
 	if calEvent.Now() {
 		summary = fmt.Sprintf(
 			"%s %s",
-			wtf.Config.UString("wtf.mods.gcal.currentIcon", "🔸"),
+			wtf.Config.UString("wtf.mods.gcal.currentIcon", wtf.Glyph("🔸", "*")),
 			summary,
 		)
 	}
 
 	if conflict {
-		return fmt.Sprintf("%s %s", wtf.Config.UString("wtf.mods.gcal.conflictIcon", "🚨"), summary)
+		return fmt.Sprintf("%s %s", wtf.Config.UString("wtf.mods.gcal.conflictIcon", wtf.Glyph("🚨", "!!")), summary)
 	}
 
 	return summary
@@ -215,9 +215,9 @@ func (widget *Widget) responseIcon(calEvent *CalEvent) string {
 
 	switch calEvent.ResponseFor(wtf.Config.UString("wtf.mods.gcal.email")) {
 	case "accepted":
-		return icon + "✔︎"
+		return icon + wtf.Glyph("✔︎", "y")
 	case "declined":
-		return icon + "✘"
+		return icon + wtf.Glyph("✘", "n")
 	case "needsAction":
 		return icon + "?"
 	case "tentative":
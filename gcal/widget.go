@@ -8,6 +8,24 @@ import (
 	"github.com/senorprogrammer/wtf/wtf"
 )
 
+const ConfigDefaults = `
+colors:
+  day: "forestgreen"
+  description: "white"
+  highlights: []
+  past: "gray"
+  title: "white"
+displayLocation: true
+displayResponseStatus: true
+email: ""
+eventCount: 10
+multiCalendar: false
+refreshInterval: 300
+secretFile: "~/.config/wtf/gcal/client_secret.json"
+showDeclined: false
+textInterval: 30
+`
+
 type Widget struct {
 	wtf.TextWidget
 
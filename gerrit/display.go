@@ -2,7 +2,9 @@ package gerrit
 
 import (
 	"fmt"
+	"strings"
 
+	glb "github.com/andygrunwald/go-gerrit"
 	"github.com/senorprogrammer/wtf/wtf"
 )
 
@@ -10,7 +12,7 @@ func (widget *Widget) display() {
 
 	project := widget.currentGerritProject()
 	if project == nil {
-		widget.View.SetText(fmt.Sprintf("%s", " Gerrit project data is unavailable (1)"))
+		widget.SetText(fmt.Sprintf("%s", " Gerrit project data is unavailable (1)"))
 		return
 	}
 
@@ -26,7 +28,7 @@ func (widget *Widget) display() {
 	str = str + " [red]My Outgoing Reviews[white]\n"
 	str = str + widget.displayMyOutgoingReviews(project, wtf.Config.UString("wtf.mods.gerrit.username"))
 
-	widget.View.SetText(str)
+	widget.SetText(str)
 }
 
 func (widget *Widget) displayMyIncomingReviews(project *GerritProject, username string) string {
@@ -36,7 +38,7 @@ func (widget *Widget) displayMyIncomingReviews(project *GerritProject, username
 
 	str := ""
 	for idx, r := range project.IncomingReviews {
-		str = str + fmt.Sprintf(" [%s] [green]%d[white] [%s] %s\n", widget.rowColor(idx), r.Number, widget.rowColor(idx), r.Subject)
+		str = str + widget.displayReview(project, &r, idx)
 	}
 
 	return str
@@ -49,12 +51,28 @@ func (widget *Widget) displayMyOutgoingReviews(project *GerritProject, username
 
 	str := ""
 	for idx, r := range project.OutgoingReviews {
-		str = str + fmt.Sprintf(" [%s] [green]%d[white] [%s] %s\n", widget.rowColor(idx+len(project.IncomingReviews)), r.Number, widget.rowColor(idx+len(project.IncomingReviews)), r.Subject)
+		str = str + widget.displayReview(project, &r, idx+len(project.IncomingReviews))
 	}
 
 	return str
 }
 
+// displayReview formats the review's line: whether it can be merged, its number
+// and subject, and the Code-Review and Verified votes on it
+func (widget *Widget) displayReview(project *GerritProject, change *glb.ChangeInfo, row int) string {
+	return fmt.Sprintf(
+		`["%d"][""] [%s] %s%s[green]%d[white] [%s] %s %s`+"\n",
+		row,
+		widget.rowColor(row),
+		widget.selectionIndicator(row),
+		mergeString(project, change),
+		change.Number,
+		widget.rowColor(row),
+		change.Subject,
+		votesString(change),
+	)
+}
+
 func (widget *Widget) displayStats(project *GerritProject) string {
 	str := fmt.Sprintf(
 		" Reviews: %d\n",
@@ -71,6 +89,73 @@ func (widget *Widget) rowColor(index int) string {
 	return wtf.RowColor("gerrit", index)
 }
 
+func (widget *Widget) selectionIndicator(index int) string {
+	return wtf.SelectionIndicator(widget.View.HasFocus() && (index == widget.selected))
+}
+
 func (widget *Widget) title(project *GerritProject) string {
 	return fmt.Sprintf("[green]%s [white]", project.Path)
 }
+
+var mergeIcons = map[bool]string{
+	false: "[red]✖[white] ",
+	true:  "[green]✔[white] ",
+}
+
+var asciiMergeIcons = map[bool]string{
+	false: "[red][CONFLICT[][white] ",
+	true:  "[green][MERGEABLE[][white] ",
+}
+
+// mergeString marks whether the change can be merged into its branch without
+// conflicts. It's empty if Gerrit didn't say whether it can be
+func mergeString(project *GerritProject, change *glb.ChangeInfo) string {
+	mergeable, ok := project.Mergeable[change.Number]
+	if !ok {
+		return ""
+	}
+
+	icons := mergeIcons
+	if wtf.Accessible() {
+		icons = asciiMergeIcons
+	}
+
+	return icons[mergeable]
+}
+
+// votesString sums up the votes on the change's Code-Review and Verified labels,
+// i.e.: CR+2 V+1. Labels the project doesn't have are left out
+func votesString(change *glb.ChangeInfo) string {
+	strs := []string{}
+
+	for _, name := range []string{codeReviewLabel, verifiedLabel} {
+		label, ok := change.Labels[name]
+		if !ok {
+			continue
+		}
+
+		// Labels nobody's voted on are greyed out, without a vote
+		vote := LabelVote(label)
+
+		switch {
+		case vote > 0:
+			strs = append(strs, fmt.Sprintf("[green]%s%s[white]", labelAbbreviations[name], formatVote(vote)))
+		case vote < 0:
+			strs = append(strs, fmt.Sprintf("[red]%s%s[white]", labelAbbreviations[name], formatVote(vote)))
+		default:
+			strs = append(strs, fmt.Sprintf("[grey]%s[white]", labelAbbreviations[name]))
+		}
+	}
+
+	return strings.Join(strs, " ")
+}
+
+// ownerName returns the full name of the change's owner or, if it's not known,
+// their username
+func ownerName(change *glb.ChangeInfo) string {
+	if change.Owner.Name != "" {
+		return change.Owner.Name
+	}
+
+	return change.Owner.Username
+}
@@ -0,0 +1,240 @@
+package gerrit
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	glb "github.com/andygrunwald/go-gerrit"
+	"github.com/gdamore/tcell"
+	"github.com/rivo/tview"
+	"github.com/senorprogrammer/wtf/wtf"
+)
+
+// filesPageName is the name of the page a change's files are displayed on
+const filesPageName = "changeFiles"
+
+const filesPageHelp = "j/k: select a file   return: open the file   o: open the change   v: vote   esc: close"
+
+// fileStatuses describes the statuses Gerrit gives changed files. Modified files
+// don't have one
+var fileStatuses = map[string]string{
+	"":  "[yellow]M",
+	"A": "[green]A",
+	"C": "[green]C",
+	"D": "[red]D",
+	"R": "[yellow]R",
+	"W": "[yellow]W",
+}
+
+// filesPage lists the files changed by a change's current patch set on a page of
+// its own. The files can be selected, and opened in a browser, and the change
+// voted on
+type filesPage struct {
+	change   *glb.ChangeInfo
+	files    []changeFile
+	project  *GerritProject
+	selected int
+	widget   *Widget
+
+	frame *tview.Frame
+	view  *tview.TextView
+}
+
+func newFilesPage(widget *Widget, project *GerritProject, change *glb.ChangeInfo) *filesPage {
+	page := filesPage{
+		change:   change,
+		project:  project,
+		selected: -1,
+		widget:   widget,
+	}
+
+	page.view = tview.NewTextView()
+	page.view.SetDynamicColors(true)
+	page.view.SetRegions(true)
+	page.view.SetScrollable(true)
+	page.view.SetInputCapture(page.keyboardIntercept)
+
+	page.frame = tview.NewFrame(page.view)
+	page.frame.SetBorder(true)
+	page.frame.SetBorders(0, 0, 0, 1, 1, 1)
+	page.frame.SetTitle(fmt.Sprintf(" %s %d ", tview.Escape(change.Project), change.Number))
+	page.setStatus(filesPageHelp, tcell.ColorGrey)
+
+	return &page
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+func (page *filesPage) close() {
+	page.widget.pages.RemovePage(filesPageName)
+	page.widget.app.SetFocus(page.widget.View)
+	page.widget.display()
+}
+
+func (page *filesPage) keyboardIntercept(event *tcell.EventKey) *tcell.EventKey {
+	switch string(event.Rune()) {
+	case "j":
+		page.selectFile(1)
+		return nil
+	case "k":
+		page.selectFile(-1)
+		return nil
+	case "o":
+		wtf.OpenFile(reviewURL(page.change))
+		return nil
+	case "q":
+		page.close()
+		return nil
+	case "v":
+		page.vote()
+		return nil
+	}
+
+	switch event.Key() {
+	case tcell.KeyDown:
+		page.selectFile(1)
+		return nil
+	case tcell.KeyEnter:
+		page.open()
+		return nil
+	case tcell.KeyEsc:
+		page.close()
+		return nil
+	case tcell.KeyUp:
+		page.selectFile(-1)
+		return nil
+	default:
+		return event
+	}
+}
+
+// open opens the selected file's diff or, if no file is selected, the change
+func (page *filesPage) open() {
+	if page.selected >= 0 && page.selected < len(page.files) {
+		wtf.OpenFile(fileURL(page.change, page.files[page.selected].path))
+		return
+	}
+
+	wtf.OpenFile(reviewURL(page.change))
+}
+
+func (page *filesPage) render(err error) {
+	change := page.change
+
+	str := fmt.Sprintf(" [green]%d[white] %s\n", change.Number, tview.Escape(change.Subject))
+	str = str + fmt.Sprintf(
+		" [grey]%s wants to merge into %s[white] %s\n",
+		tview.Escape(ownerName(change)),
+		tview.Escape(change.Branch),
+		votesString(change),
+	)
+
+	if err != nil {
+		str = str + fmt.Sprintf("\n [red]%s[white]\n", tview.Escape(err.Error()))
+		page.view.SetText(str)
+		return
+	}
+
+	str = str + "\n [red]Files[white]\n"
+	if len(page.files) == 0 {
+		str = str + " [grey]none[white]\n"
+	}
+
+	pathWidth := 0
+	for _, file := range page.files {
+		if len(file.path) > pathWidth {
+			pathWidth = len(file.path)
+		}
+	}
+
+	for idx, file := range page.files {
+		selected := idx == page.selected
+
+		rowColor := wtf.DefaultRowColor()
+		if selected {
+			rowColor = wtf.DefaultFocussedRowColor()
+		}
+
+		path := tview.Escape(file.path) + strings.Repeat(" ", pathWidth-len(file.path))
+		if file.OldPath != "" {
+			path = path + fmt.Sprintf(" [grey](from %s)", tview.Escape(file.OldPath))
+		}
+
+		str = str + fmt.Sprintf(
+			`["%d"][""] [%s]%s%s [%s]%s [green]+%d [red]-%d[%s]`+"\n",
+			idx,
+			rowColor,
+			wtf.SelectionIndicator(selected),
+			fileStatuses[file.Status],
+			rowColor,
+			path,
+			file.LinesInserted,
+			file.LinesDeleted,
+			wtf.DefaultRowColor(),
+		)
+	}
+
+	page.view.Highlight(strconv.Itoa(page.selected)).ScrollToHighlight()
+	page.view.SetText(str)
+}
+
+func (page *filesPage) selectFile(step int) {
+	if len(page.files) == 0 {
+		return
+	}
+
+	count := len(page.files)
+
+	switch {
+	case page.selected < 0 && step < 0:
+		page.selected = count - 1
+	case page.selected < 0:
+		page.selected = 0
+	default:
+		page.selected = ((page.selected+step)%count + count) % count
+	}
+
+	page.render(nil)
+}
+
+// setStatus replaces the text below the files, which is the help until the change
+// is voted on
+func (page *filesPage) setStatus(text string, color tcell.Color) {
+	page.frame.Clear()
+	page.frame.AddText(tview.Escape(text), false, tview.AlignCenter, color)
+}
+
+// vote opens the form for voting on the change, and reports the vote once it's
+// been posted
+func (page *filesPage) vote() {
+	onVoted := func(vote string) {
+		page.setStatus(fmt.Sprintf("Voted Code-Review %s on %d", vote, page.change.Number), tcell.ColorGreen)
+		go wtf.RefreshWidget(page.widget)
+	}
+
+	if err := page.widget.showVoteForm(page.project, page.change, page.view, onVoted); err != nil {
+		page.setStatus(err.Error(), tcell.ColorRed)
+	}
+}
+
+// show loads the change's files afresh, and displays them
+func (page *filesPage) show() {
+	files, err := page.project.changeFiles(page.change)
+	page.files = files
+	page.render(err)
+
+	page.widget.pages.AddPage(filesPageName, page.frame, true, true)
+	page.widget.app.SetFocus(page.view)
+}
+
+// fileURL returns the URL of the diff of the file in the change's current patch
+// set. If the patch set isn't known, it's the change's URL
+func fileURL(change *glb.ChangeInfo, path string) string {
+	patchSet := change.Revisions[change.CurrentRevision].Number
+	if patchSet == 0 {
+		return reviewURL(change)
+	}
+
+	return fmt.Sprintf("%s/%d/%s", reviewURL(change), patchSet, path)
+}
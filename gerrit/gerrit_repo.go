@@ -1,15 +1,28 @@
 package gerrit
 
 import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
 	glb "github.com/andygrunwald/go-gerrit"
 	"github.com/senorprogrammer/wtf/wtf"
 )
 
+// errUnknownMergeable is returned by queries Gerrit rejects because it doesn't know
+// the MERGEABLE option
+var errUnknownMergeable = errors.New("MERGEABLE is not a valid option")
+
 type GerritProject struct {
 	gerrit *glb.Client
 	Path   string
 
 	Changes         *[]glb.ChangeInfo
+	Mergeable       map[int]bool
 	ReviewCount     int
 	IncomingReviews []glb.ChangeInfo
 	OutgoingReviews []glb.ChangeInfo
@@ -88,15 +101,123 @@ func (project *GerritProject) myIncomingReviews(changes *[]glb.ChangeInfo, usern
 }
 
 func (project *GerritProject) loadChanges() (*[]glb.ChangeInfo, error) {
-	opt := &glb.QueryChangeOptions{}
-	opt.Query = []string{"(projects:" + project.Path + "+ is:open + owner:self) " + " OR " +
-		"(projects:" + project.Path + " + is:open + ((reviewer:self + -owner:self + -star:ignore) + OR + assignee:self))"}
-	opt.AdditionalFields = []string{"DETAILED_LABELS", "DETAILED_ACCOUNTS"}
-	changes, _, err := project.gerrit.Changes.QueryChanges(opt)
+	query := "(projects:" + project.Path + "+ is:open + owner:self) " + " OR " +
+		"(projects:" + project.Path + " + is:open + ((reviewer:self + -owner:self + -star:ignore) + OR + assignee:self))"
+	fields := []string{"CURRENT_REVISION", "DETAILED_ACCOUNTS", "DETAILED_LABELS", "MERGEABLE"}
+
+	changes, err := project.queryChanges(query, fields)
+
+	// Gerrit versions before 3.0 don't know the MERGEABLE option, and return whether
+	// changes are mergeable without it
+	if err == errUnknownMergeable {
+		changes, err = project.queryChanges(query, fields[:len(fields)-1])
+	}
 
 	if err != nil {
+		project.Mergeable = map[int]bool{}
 		return nil, err
 	}
 
-	return changes, err
+	infos := []glb.ChangeInfo{}
+	mergeable := map[int]bool{}
+
+	for _, change := range changes {
+		infos = append(infos, change.ChangeInfo)
+
+		if change.Mergeable != nil {
+			mergeable[change.Number] = *change.Mergeable
+		}
+	}
+
+	project.Mergeable = mergeable
+
+	return &infos, nil
+}
+
+// queryChanges runs the query, returning the changes with the additional fields.
+// It returns errUnknownMergeable if Gerrit doesn't know the MERGEABLE field
+func (project *GerritProject) queryChanges(query string, fields []string) ([]queriedChange, error) {
+	changes := []queriedChange{}
+
+	resp, err := project.gerrit.Call("GET", changesPath(query, fields), nil, &changes)
+	if err != nil && resp != nil && resp.StatusCode == http.StatusBadRequest {
+		defer resp.Body.Close()
+
+		body, _ := ioutil.ReadAll(resp.Body)
+		if strings.Contains(string(body), "MERGEABLE") {
+			return nil, errUnknownMergeable
+		}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return changes, nil
+}
+
+// changesPath returns the path of the API call that queries changes. The query is
+// escaped the way go-gerrit escapes it, leaving + and : as they are, as Gerrit
+// reads + as a space
+func changesPath(query string, fields []string) string {
+	escaped := url.QueryEscape(query)
+	escaped = strings.Replace(escaped, "%2B", "+", -1)
+	escaped = strings.Replace(escaped, "%3A", ":", -1)
+
+	params := []string{"q=" + escaped}
+	for _, field := range fields {
+		params = append(params, "o="+field)
+	}
+
+	return "changes/?" + strings.Join(params, "&")
+}
+
+// changeFiles returns the files changed by the change's current patch set, sorted
+// by path. Gerrit's magic files, i.e. the commit message, are left out
+func (project *GerritProject) changeFiles(change *glb.ChangeInfo) ([]changeFile, error) {
+	files, _, err := project.gerrit.Changes.ListFiles(strconv.Itoa(change.Number), "current", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	changeFiles := []changeFile{}
+	for path, info := range files {
+		if strings.HasPrefix(path, "/") {
+			continue
+		}
+
+		changeFiles = append(changeFiles, changeFile{FileInfo: info, path: path})
+	}
+
+	sort.Slice(changeFiles, func(i, j int) bool {
+		return changeFiles[i].path < changeFiles[j].path
+	})
+
+	return changeFiles, nil
+}
+
+// review posts a Code-Review vote, with an optional message, on the change's
+// current patch set
+func (project *GerritProject) review(change *glb.ChangeInfo, vote, message string) error {
+	input := glb.ReviewInput{
+		Labels:  map[string]string{codeReviewLabel: vote},
+		Message: message,
+	}
+
+	_, _, err := project.gerrit.Changes.SetReview(strconv.Itoa(change.Number), "current", &input)
+	return err
+}
+
+// queriedChange is a change returned by a query. Gerrit leaves out whether the
+// change is mergeable if it doesn't know, which ChangeInfo can't tell apart from
+// it not being mergeable
+type queriedChange struct {
+	glb.ChangeInfo
+	Mergeable *bool `json:"mergeable,omitempty"`
+}
+
+// changeFile is a file changed by a patch set
+type changeFile struct {
+	glb.FileInfo
+	path string
 }
@@ -0,0 +1,118 @@
+package gerrit
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	glb "github.com/andygrunwald/go-gerrit"
+)
+
+// The labels whose votes are displayed for each change, with their abbreviations
+const (
+	codeReviewLabel = "Code-Review"
+	verifiedLabel   = "Verified"
+)
+
+var labelAbbreviations = map[string]string{
+	codeReviewLabel: "CR",
+	verifiedLabel:   "V",
+}
+
+// LabelVote sums up the votes on a label the way Gerrit does: a veto, the lowest
+// vote the label allows, beats an approval, the highest vote it allows, which
+// beats any other negative vote, which beats any other positive vote. It's 0 if
+// nobody has voted
+func LabelVote(label glb.LabelInfo) int {
+	lowest, highest := 0, 0
+	for _, approval := range label.All {
+		if approval.Value < lowest {
+			lowest = approval.Value
+		}
+		if approval.Value > highest {
+			highest = approval.Value
+		}
+	}
+
+	min, max := labelRange(label)
+
+	switch {
+	case lowest < 0 && lowest <= min:
+		return lowest
+	case highest > 0 && highest >= max:
+		return highest
+	case lowest < 0:
+		return lowest
+	}
+
+	return highest
+}
+
+// labelRange returns the lowest and the highest votes the label allows, i.e.:
+// -2 and +2 for Code-Review
+func labelRange(label glb.LabelInfo) (int, int) {
+	min, max := 0, 0
+	for _, value := range labelValues(label) {
+		if value < min {
+			min = value
+		}
+		if value > max {
+			max = value
+		}
+	}
+
+	return min, max
+}
+
+// labelValues returns the votes the label allows. Gerrit lists them as strings,
+// i.e.: "-1", " 0" and "+1"
+func labelValues(label glb.LabelInfo) []int {
+	values := []int{}
+	for str := range label.Values {
+		if value, err := strconv.Atoi(strings.TrimSpace(str)); err == nil {
+			values = append(values, value)
+		}
+	}
+
+	return values
+}
+
+// permittedVotes returns the votes the user may give on the label of the change,
+// from the lowest to the highest, i.e.: -1, 0, +1. It's empty if they can't vote
+// on it
+func permittedVotes(change *glb.ChangeInfo, label string) []string {
+	votes := []string{}
+	for _, vote := range change.PermittedLabels[label] {
+		votes = append(votes, strings.TrimSpace(vote))
+	}
+
+	sort.SliceStable(votes, func(i, j int) bool {
+		first, _ := strconv.Atoi(votes[i])
+		second, _ := strconv.Atoi(votes[j])
+		return first < second
+	})
+
+	return votes
+}
+
+// userVote returns the vote the user with the given username gave on the label,
+// which is 0 if they haven't voted
+func userVote(label glb.LabelInfo, username string) int {
+	for _, approval := range label.All {
+		if approval.Username == username {
+			return approval.Value
+		}
+	}
+
+	return 0
+}
+
+// formatVote formats a vote the way Gerrit does, with its sign, i.e.: +2
+func formatVote(vote int) string {
+	if vote == 0 {
+		return "0"
+	}
+
+	return fmt.Sprintf("%+d", vote)
+}
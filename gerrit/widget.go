@@ -17,11 +17,14 @@ const HelpText = `
   Keyboard commands for Gerrit:
 
     /: Show/hide this help window
+    f: Show the files of the selected review
     h: Show the previous project
     l: Show the next project
     j: Select the next review in the list
     k: Select the previous review in the list
     r: Refresh the data
+    v: Vote on the selected review's Code-Review, with an optional message
+    y: Copy the selected review's URL to the clipboard
 
     arrow left:  Show the previous project
     arrow right: Show the next project
@@ -29,6 +32,28 @@ const HelpText = `
 	arrow up:    Select the previous review in the list
 
 	return: Open the selected review in a browser
+
+  In a review's files:
+
+    j:      Select the next file
+    k:      Select the previous file
+    o:      Open the review in a browser
+    v:      Vote on the review's Code-Review
+    return: Open the selected file, or the review, in a browser
+    esc:    Close the files
+`
+
+const ConfigDefaults = `
+colors:
+  rows:
+    even: "white"
+    odd: "lightblue"
+domain: ""
+password: ""
+projects: []
+refreshInterval: 300
+username: ""
+verifyServerCertificate: true
 `
 
 type Widget struct {
@@ -39,6 +64,8 @@ type Widget struct {
 
 	GerritProjects []*GerritProject
 	Idx            int
+	app            *tview.Application
+	pages          *tview.Pages
 	selected       int
 }
 
@@ -51,12 +78,16 @@ func NewWidget(app *tview.Application, pages *tview.Pages) *Widget {
 		HelpfulWidget: wtf.NewHelpfulWidget(app, pages, HelpText),
 		TextWidget:    wtf.NewTextWidget(app, "Gerrit", "gerrit", true),
 
-		Idx: 0,
+		Idx:   0,
+		app:   app,
+		pages: pages,
 	}
 
 	widget.HelpfulWidget.SetView(widget.View)
 
+	widget.View.SetRegions(true)
 	widget.View.SetInputCapture(widget.keyboardIntercept)
+	widget.restoreProject()
 	widget.unselect()
 
 	return &widget
@@ -94,7 +125,7 @@ func (widget *Widget) Refresh() {
 	if err != nil {
 		widget.View.SetWrap(true)
 		widget.View.SetTitle(widget.Name)
-		widget.View.SetText(err.Error())
+		widget.SetText(err.Error())
 		return
 	}
 	widget.gerrit = gerrit
@@ -108,6 +139,33 @@ func (widget *Widget) Refresh() {
 	widget.display()
 }
 
+// ActionData returns the fields of the selected review for the module's actions
+func (widget *Widget) ActionData() wtf.ActionData {
+	change := widget.selectedReview()
+	if change == nil {
+		return nil
+	}
+
+	return wtf.ActionData{
+		"Branch":  change.Branch,
+		"Number":  change.Number,
+		"Project": change.Project,
+		"Subject": change.Subject,
+		"URL":     reviewURL(change),
+	}
+}
+
+// SelectItem selects the review at idx, when it's clicked on
+func (widget *Widget) SelectItem(idx int) {
+	widget.selected = idx
+	widget.display()
+}
+
+// OpenItem opens the selected review in a browser, when it's double-clicked
+func (widget *Widget) OpenItem() {
+	widget.openReview()
+}
+
 /* -------------------- Unexported Functions -------------------- */
 
 func (widget *Widget) nextProject() {
@@ -117,6 +175,7 @@ func (widget *Widget) nextProject() {
 		widget.Idx = 0
 	}
 
+	widget.saveProject()
 	widget.unselect()
 }
 
@@ -126,6 +185,7 @@ func (widget *Widget) prevProject() {
 		widget.Idx = len(widget.GerritProjects) - 1
 	}
 
+	widget.saveProject()
 	widget.unselect()
 }
 
@@ -150,16 +210,118 @@ func (widget *Widget) prevReview() {
 }
 
 func (widget *Widget) openReview() {
+	if change := widget.selectedReview(); change != nil {
+		wtf.OpenFile(reviewURL(change))
+	}
+}
+
+func (widget *Widget) copyReview() {
+	if change := widget.selectedReview(); change != nil {
+		widget.Copy(reviewURL(change))
+	}
+}
+
+// selectedReview returns the selected review, or nil if no review is selected
+func (widget *Widget) selectedReview() *glb.ChangeInfo {
 	sel := widget.selected
-	project := widget.GerritProjects[widget.Idx]
-	if sel >= 0 && sel < project.ReviewCount {
-		change := glb.ChangeInfo{}
-		if sel < len(project.IncomingReviews) {
-			change = project.IncomingReviews[sel]
-		} else {
-			change = project.OutgoingReviews[sel-len(project.IncomingReviews)]
+	project := widget.currentGerritProject()
+	if project == nil || sel < 0 || sel >= project.ReviewCount {
+		return nil
+	}
+
+	if sel < len(project.IncomingReviews) {
+		return &project.IncomingReviews[sel]
+	}
+
+	return &project.OutgoingReviews[sel-len(project.IncomingReviews)]
+}
+
+// showFiles lists the files changed by the selected review
+func (widget *Widget) showFiles() {
+	project := widget.currentGerritProject()
+	change := widget.selectedReview()
+	if project == nil || change == nil {
+		return
+	}
+
+	newFilesPage(widget, project, change).show()
+}
+
+// vote opens the form for voting on the selected review, and reloads the reviews
+// once the vote's been posted
+func (widget *Widget) vote() {
+	project := widget.currentGerritProject()
+	change := widget.selectedReview()
+	if project == nil || change == nil {
+		return
+	}
+
+	onVoted := func(vote string) {
+		widget.ShowNotice(fmt.Sprintf("[green]Voted Code-Review %s on %d", vote, change.Number))
+		go wtf.RefreshWidget(widget)
+	}
+
+	if err := widget.showVoteForm(project, change, widget.View, onVoted); err != nil {
+		widget.ShowNotice("[red]" + tview.Escape(err.Error()))
+	}
+}
+
+// showVoteForm opens a form for voting on the change's Code-Review label, with an
+// optional message. It returns an error if the user may not vote on it. Focus
+// returns to returnFocus when the form closes. The vote is posted in the
+// background, and onVoted is called with it, on the app's event loop, once it's
+// been posted
+func (widget *Widget) showVoteForm(project *GerritProject, change *glb.ChangeInfo, returnFocus tview.Primitive, onVoted func(string)) error {
+	votes := permittedVotes(change, codeReviewLabel)
+	if len(votes) == 0 {
+		return fmt.Errorf("you can't vote on %s of %d", codeReviewLabel, change.Number)
+	}
+
+	current := formatVote(userVote(change.Labels[codeReviewLabel], wtf.Config.UString("wtf.mods.gerrit.username")))
+
+	form := wtf.NewModalForm(widget.app, widget.pages, fmt.Sprintf("Review %d", change.Number), returnFocus).
+		AddDropDown("vote", codeReviewLabel+":", votes, current).
+		AddTextField("message", "Message:", "")
+
+	form.SetSubmitFunc(func(values wtf.FormValues) error {
+		if err := project.review(change, values["vote"], values["message"]); err != nil {
+			return err
+		}
+
+		widget.app.QueueUpdateDraw(func() {
+			onVoted(values["vote"])
+		})
+		return nil
+	})
+
+	form.SubmitInBackground()
+	form.Show()
+
+	return nil
+}
+
+func reviewURL(change *glb.ChangeInfo) string {
+	return fmt.Sprintf("%s/%s/%d", wtf.Config.UString("wtf.mods.gerrit.domain"), "#/c", change.Number)
+}
+
+// restoreProject displays the project that was displayed when the app last ran. The
+// projects themselves aren't loaded until the first refresh, so this works off the config
+func (widget *Widget) restoreProject() {
+	source := wtf.State.Get(widget.ConfigKey()).Source
+
+	for idx, name := range wtf.ToStrs(wtf.Config.UList("wtf.mods.gerrit.projects")) {
+		if name == source {
+			widget.Idx = idx
+			return
 		}
-		wtf.OpenFile(fmt.Sprintf("%s/%s/%d", wtf.Config.UString("wtf.mods.gerrit.domain"), "#/c", change.Number))
+	}
+}
+
+func (widget *Widget) saveProject() {
+	project := widget.currentGerritProject()
+
+	if project != nil {
+		wtf.State.SetSource(widget.ConfigKey(), project.Path)
 	}
 }
 
@@ -196,6 +358,9 @@ func (widget *Widget) keyboardIntercept(event *tcell.EventKey) *tcell.EventKey {
 	case "/":
 		widget.ShowHelp()
 		return nil
+	case "f":
+		widget.showFiles()
+		return nil
 	case "h":
 		widget.prevProject()
 		return nil
@@ -211,6 +376,12 @@ func (widget *Widget) keyboardIntercept(event *tcell.EventKey) *tcell.EventKey {
 	case "r":
 		widget.Refresh()
 		return nil
+	case "v":
+		widget.vote()
+		return nil
+	case "y":
+		widget.copyReview()
+		return nil
 	}
 
 	switch event.Key() {
@@ -0,0 +1,68 @@
+package gerrit_tests
+
+import (
+	"testing"
+
+	glb "github.com/andygrunwald/go-gerrit"
+	. "github.com/senorprogrammer/wtf/gerrit"
+	. "github.com/stretchr/testify/assert"
+)
+
+// codeReview returns a Code-Review label, which allows votes from -2 to +2, with
+// the given votes on it
+func codeReview(votes ...int) glb.LabelInfo {
+	label := glb.LabelInfo{
+		Values: map[string]string{
+			"-2": "Do not submit",
+			"-1": "I would prefer that you didn't submit this",
+			" 0": "No score",
+			"+1": "Looks good to me, but someone else must approve",
+			"+2": "Looks good to me, approved",
+		},
+	}
+
+	for _, vote := range votes {
+		label.All = append(label.All, glb.ApprovalInfo{Value: vote})
+	}
+
+	return label
+}
+
+/* -------------------- LabelVote() -------------------- */
+
+func TestLabelVote(t *testing.T) {
+	Equal(t, 0, LabelVote(codeReview()))
+	Equal(t, 0, LabelVote(codeReview(0, 0)))
+	Equal(t, 1, LabelVote(codeReview(1)))
+	Equal(t, -1, LabelVote(codeReview(-1)))
+	Equal(t, 2, LabelVote(codeReview(1, 2)))
+}
+
+func TestLabelVoteVetoBeatsApproval(t *testing.T) {
+	Equal(t, -2, LabelVote(codeReview(2, -2)))
+	Equal(t, -2, LabelVote(codeReview(-1, -2, 1)))
+}
+
+func TestLabelVoteApprovalBeatsNegativeVote(t *testing.T) {
+	Equal(t, 2, LabelVote(codeReview(-1, 2)))
+}
+
+func TestLabelVoteNegativeVoteBeatsPositiveVote(t *testing.T) {
+	Equal(t, -1, LabelVote(codeReview(1, -1)))
+	Equal(t, -1, LabelVote(codeReview(1, -1, 1)))
+}
+
+func TestLabelVoteRange(t *testing.T) {
+	// +2 only approves if it's the highest vote the label allows
+	label := glb.LabelInfo{
+		Values: map[string]string{"-3": "Veto", " 0": "No score", "+3": "Approved"},
+		All:    []glb.ApprovalInfo{{Value: 2}, {Value: -1}},
+	}
+	Equal(t, -1, LabelVote(label))
+
+	label.All = []glb.ApprovalInfo{{Value: 3}, {Value: -1}}
+	Equal(t, 3, LabelVote(label))
+
+	label.All = []glb.ApprovalInfo{{Value: 3}, {Value: -3}}
+	Equal(t, -3, LabelVote(label))
+}
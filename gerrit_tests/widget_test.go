@@ -0,0 +1,120 @@
+package gerrit_tests
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell"
+	"github.com/olebedev/config"
+	"github.com/rivo/tview"
+	. "github.com/senorprogrammer/wtf/gerrit"
+	"github.com/senorprogrammer/wtf/wtf"
+	. "github.com/stretchr/testify/assert"
+)
+
+const changesJSON = `)]}'
+[
+  {"_number": 1, "project": "wtf", "branch": "master", "subject": "Mergeable", "owner": {"username": "me"}, "mergeable": true},
+  {"_number": 2, "project": "wtf", "branch": "master", "subject": "Unknown", "owner": {"username": "me"}}
+]`
+
+const filesJSON = `)]}'
+{"/COMMIT_MSG": {}, "main.go": {"lines_inserted": 3}}`
+
+// newServer returns a Gerrit server with two changes, one of which doesn't say
+// whether it's mergeable. If knowsMergeable is false, it rejects queries for the
+// MERGEABLE field, as Gerrit did before 3.0
+func newServer(knowsMergeable bool) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/accounts/self"):
+			fmt.Fprint(w, ")]}'\n{}")
+		case strings.HasSuffix(r.URL.Path, "/files/"):
+			fmt.Fprint(w, filesJSON)
+		case strings.HasSuffix(r.URL.Path, "/changes/"):
+			if !knowsMergeable && strings.Contains(r.URL.RawQuery, "o=MERGEABLE") {
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprint(w, `"MERGEABLE" is not a valid value for "-o"`)
+				return
+			}
+
+			fmt.Fprint(w, changesJSON)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	return server
+}
+
+func newWidget(server *httptest.Server) (*Widget, *tview.Pages) {
+	wtf.Config, _ = config.ParseYaml(fmt.Sprintf(`
+wtf:
+  mods:
+    gerrit:
+      domain: "%s"
+      enabled: true
+      projects:
+        - wtf
+      username: me
+`, server.URL))
+
+	app := tview.NewApplication()
+	pages := tview.NewPages()
+
+	widget := NewWidget(app, pages)
+	wtf.CaptureFilterKeys(widget)
+	widget.Refresh()
+
+	return widget, pages
+}
+
+func key(r rune) *tcell.EventKey {
+	return tcell.NewEventKey(tcell.KeyRune, r, tcell.ModNone)
+}
+
+/* -------------------- Keys -------------------- */
+
+func TestFilesKeyShowsFiles(t *testing.T) {
+	server := newServer(true)
+	defer server.Close()
+
+	widget, pages := newWidget(server)
+	capture := widget.View.GetInputCapture()
+
+	Nil(t, capture(key('j')))
+	Nil(t, capture(key('f')))
+
+	True(t, pages.HasPage("changeFiles"))
+
+	// 'f' showed the files rather than opening the filter line, so typing
+	// isn't filtering
+	False(t, wtf.HandleFilterKey(widget, key('x')))
+}
+
+/* -------------------- Mergeability -------------------- */
+
+func TestMergeableOnlyWhenKnown(t *testing.T) {
+	server := newServer(true)
+	defer server.Close()
+
+	widget, _ := newWidget(server)
+	project := widget.GerritProjects[0]
+
+	Equal(t, 2, project.ReviewCount)
+	Equal(t, map[int]bool{1: true}, project.Mergeable)
+}
+
+func TestMergeableRetriedWithoutOption(t *testing.T) {
+	server := newServer(false)
+	defer server.Close()
+
+	widget, _ := newWidget(server)
+	project := widget.GerritProjects[0]
+
+	Equal(t, 2, project.ReviewCount)
+	Equal(t, map[int]bool{1: true}, project.Mergeable)
+}
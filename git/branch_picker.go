@@ -0,0 +1,257 @@
+package git
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gdamore/tcell"
+	"github.com/rivo/tview"
+	"github.com/senorprogrammer/wtf/wtf"
+)
+
+const pickerWidth = 80
+const pickerHeight = 20
+
+const pickerHelp = "up/down: select   return: checkout   esc: cancel"
+
+// branchPicker lists a repo's branches, filtered by the fuzzy search typed above
+// them, for checking one out. If the search doesn't name an existing branch, it
+// also offers to create a branch of that name
+type branchPicker struct {
+	branches   []gitBranch
+	confirming bool
+	matches    []branchMatch
+	repo       *GitRepo
+	selected   int
+	widget     *Widget
+
+	frame *tview.Frame
+	input *tview.InputField
+	list  *tview.TextView
+}
+
+// branchMatch is a row of the picker: a branch that matches the search, with the
+// positions of the matched characters, or the branch to create
+type branchMatch struct {
+	branch    gitBranch
+	create    bool
+	positions []int
+	score     int
+}
+
+func newBranchPicker(widget *Widget, repo *GitRepo) (*branchPicker, error) {
+	branches, err := repo.branches()
+	if err != nil {
+		return nil, err
+	}
+
+	picker := branchPicker{
+		branches: branches,
+		repo:     repo,
+		widget:   widget,
+	}
+
+	picker.input = tview.NewInputField().
+		SetLabel("Branch: ").
+		SetPlaceholder("type to search, or to name a new branch")
+	picker.input.SetChangedFunc(picker.search)
+	picker.input.SetInputCapture(picker.keyboardIntercept)
+
+	picker.list = tview.NewTextView()
+	picker.list.SetDynamicColors(true)
+	picker.list.SetRegions(true)
+	picker.list.SetWrap(false)
+
+	layout := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(picker.input, 2, 0, true).
+		AddItem(picker.list, 0, 1, false)
+
+	picker.frame = tview.NewFrame(layout)
+	picker.frame.SetBorder(true)
+	picker.frame.SetBorders(1, 0, 0, 1, 1, 1)
+	picker.frame.SetTitle(fmt.Sprintf(" Checkout a branch of %s ", tview.Escape(repoName(repo))))
+
+	picker.search("")
+
+	return &picker, nil
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// checkout checks out the selected branch, or creates it. If the working tree has
+// changes it first asks for confirmation, which is given by choosing the branch
+// again
+func (picker *branchPicker) checkout() {
+	if picker.selected >= len(picker.matches) {
+		return
+	}
+
+	if len(picker.repo.ChangedFiles) > 0 && !picker.confirming {
+		picker.confirming = true
+		picker.render("")
+		return
+	}
+
+	match := picker.matches[picker.selected]
+
+	args := checkoutArgs(match.branch, picker.branches)
+	if match.create {
+		args = []string{"-b", match.branch.name}
+	}
+
+	result, err := picker.repo.checkout(args...)
+	if err != nil {
+		picker.confirming = false
+		picker.render(err.Error())
+		return
+	}
+
+	picker.close()
+
+	if result != "" {
+		picker.widget.ShowNotice("[green]" + tview.Escape(result))
+	}
+
+	go wtf.RefreshWidget(picker.widget)
+}
+
+func (picker *branchPicker) close() {
+	picker.widget.pages.RemovePage("modal")
+	picker.widget.app.SetFocus(picker.widget.View)
+	picker.widget.display()
+}
+
+func (picker *branchPicker) keyboardIntercept(event *tcell.EventKey) *tcell.EventKey {
+	switch event.Key() {
+	case tcell.KeyDown:
+		picker.selectRow(1)
+		return nil
+	case tcell.KeyUp:
+		picker.selectRow(-1)
+		return nil
+	case tcell.KeyEnter:
+		picker.checkout()
+		return nil
+	case tcell.KeyEsc:
+		picker.close()
+		return nil
+	case tcell.KeyTab, tcell.KeyBacktab:
+		return nil
+	default:
+		return event
+	}
+}
+
+// render lists the matching branches, with the error, if any, or the warning
+// about the working tree's changes below them
+func (picker *branchPicker) render(errMsg string) {
+	picker.frame.Clear()
+
+	switch {
+	case errMsg != "":
+		picker.frame.AddText(tview.Escape(errMsg), false, tview.AlignCenter, tcell.ColorRed)
+	case picker.confirming:
+		warning := fmt.Sprintf(
+			"%d changed files in the working tree. Press return again to checkout anyway",
+			len(picker.repo.ChangedFiles),
+		)
+		picker.frame.AddText(warning, false, tview.AlignCenter, tcell.ColorYellow)
+	default:
+		picker.frame.AddText(pickerHelp, false, tview.AlignCenter, tcell.ColorGrey)
+	}
+
+	nameWidth := 0
+	for _, match := range picker.matches {
+		if len(match.branch.name) > nameWidth {
+			nameWidth = len(match.branch.name)
+		}
+	}
+
+	str := ""
+	for idx, match := range picker.matches {
+		selected := idx == picker.selected
+
+		rowColor := wtf.RowColor("git", idx)
+		if selected {
+			rowColor = wtf.DefaultFocussedRowColor()
+		}
+
+		str = str + fmt.Sprintf(`["%d"][""][%s]%s`, idx, rowColor, wtf.SelectionIndicator(selected))
+
+		if match.create {
+			str = str + fmt.Sprintf("+ Create branch %s", tview.Escape(match.branch.name))
+		} else {
+			current := "  "
+			if match.branch.current {
+				current = "* "
+			}
+
+			padding := strings.Repeat(" ", nameWidth-len(match.branch.name))
+			str = str + current + wtf.MarkFuzzyMatch(match.branch.name, match.positions) + padding
+
+			if !match.branch.committedAt.IsZero() {
+				str = str + "  " + relativeDate(match.branch.committedAt)
+			}
+		}
+
+		str = str + fmt.Sprintf("[%s]\n", wtf.DefaultRowColor())
+	}
+
+	if len(picker.matches) == 0 {
+		str = " [grey]no branches[white]"
+	}
+
+	picker.list.Highlight(strconv.Itoa(picker.selected)).ScrollToHighlight()
+	picker.list.SetText(str)
+}
+
+// search lists the branches that fuzzily match the query, the best matches first.
+// Branches that match equally well stay in order of their most recent commits
+func (picker *branchPicker) search(query string) {
+	query = strings.TrimSpace(query)
+
+	picker.matches = []branchMatch{}
+	exists := false
+
+	for _, branch := range picker.branches {
+		if score, positions, ok := wtf.FuzzyMatch(branch.name, query); ok {
+			picker.matches = append(picker.matches, branchMatch{branch: branch, positions: positions, score: score})
+		}
+
+		if branch.remote == "" && branch.name == query {
+			exists = true
+		}
+	}
+
+	sort.SliceStable(picker.matches, func(i, j int) bool {
+		return picker.matches[i].score > picker.matches[j].score
+	})
+
+	if query != "" && !exists {
+		picker.matches = append(picker.matches, branchMatch{branch: gitBranch{name: query}, create: true})
+	}
+
+	picker.selected = 0
+	picker.confirming = false
+	picker.render("")
+}
+
+// selectRow moves the selection up or down by the given number of rows
+func (picker *branchPicker) selectRow(step int) {
+	if len(picker.matches) == 0 {
+		return
+	}
+
+	count := len(picker.matches)
+	picker.selected = ((picker.selected+step)%count + count) % count
+	picker.confirming = false
+	picker.render("")
+}
+
+func (picker *branchPicker) show() {
+	picker.widget.pages.AddPage("modal", wtf.Centered(picker.frame, pickerWidth, pickerHeight), true, true)
+	picker.widget.app.SetFocus(picker.input)
+}
@@ -0,0 +1,102 @@
+package git
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/src-d/go-git.v4/plumbing"
+)
+
+// gitBranch is a local branch, i.e.: master, or a remote one, i.e.: origin/master
+type gitBranch struct {
+	committedAt time.Time
+	current     bool
+	name        string
+	remote      string
+}
+
+// localName returns the name of the local branch that tracks the branch, which
+// for a remote branch is its name without the remote, i.e.: master for
+// origin/master
+func (branch gitBranch) localName() string {
+	if branch.remote == "" {
+		return branch.name
+	}
+
+	return strings.TrimPrefix(branch.name, branch.remote+"/")
+}
+
+// branches returns the repo's local and remote branches, the most recently
+// committed to first
+func (repo *GitRepo) branches() ([]gitBranch, error) {
+	gitRepo, _, err := openRepository(repo.Repository)
+	if err != nil {
+		return nil, err
+	}
+
+	head, err := gitRepo.Reference(plumbing.HEAD, false)
+	if err != nil {
+		return nil, err
+	}
+
+	refs, err := gitRepo.References()
+	if err != nil {
+		return nil, err
+	}
+	defer refs.Close()
+
+	branches := []gitBranch{}
+
+	err = refs.ForEach(func(ref *plumbing.Reference) error {
+		// Remote HEADs, i.e.: origin/HEAD, point at one of the remote's branches
+		if ref.Type() != plumbing.HashReference {
+			return nil
+		}
+
+		branch := gitBranch{name: ref.Name().Short()}
+
+		switch {
+		case ref.Name().IsBranch():
+			branch.current = head.Type() == plumbing.SymbolicReference && head.Target() == ref.Name()
+		case ref.Name().IsRemote():
+			branch.remote = strings.SplitN(branch.name, "/", 2)[0]
+		default:
+			return nil
+		}
+
+		if commit, err := gitRepo.CommitObject(ref.Hash()); err == nil {
+			branch.committedAt = commit.Committer.When
+		}
+
+		branches = append(branches, branch)
+
+		return nil
+	})
+
+	sort.SliceStable(branches, func(i, j int) bool {
+		if !branches[i].committedAt.Equal(branches[j].committedAt) {
+			return branches[i].committedAt.After(branches[j].committedAt)
+		}
+		return branches[i].name < branches[j].name
+	})
+
+	return branches, err
+}
+
+// checkoutArgs returns the arguments to `git checkout` that check out the branch.
+// A remote branch is checked out as the local branch of the same name, which is
+// created to track it if there isn't one yet
+func checkoutArgs(branch gitBranch, branches []gitBranch) []string {
+	if branch.remote == "" {
+		return []string{branch.name}
+	}
+
+	for _, other := range branches {
+		if other.remote == "" && other.name == branch.localName() {
+			return []string{other.name}
+		}
+	}
+
+	return []string{"--track", branch.name}
+}
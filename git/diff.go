@@ -0,0 +1,336 @@
+package git
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/sergi/go-diff/diffmatchpatch"
+	gogit "gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/filemode"
+	fdiff "gopkg.in/src-d/go-git.v4/plumbing/format/diff"
+	"gopkg.in/src-d/go-git.v4/utils/diff"
+)
+
+// diffContextLines is the number of unchanged lines shown around each change
+const diffContextLines = 3
+
+// ChangedFile is a file listed in a repo's changed files. Staged and Unstaged
+// are its status letters for the index and the working tree, as
+// `git status --porcelain` displays them
+type ChangedFile struct {
+	Path     string
+	Staged   byte
+	Unstaged byte
+}
+
+// ParseChange parses a line of the repo's changed files, i.e.: "M  main.go"
+func ParseChange(line string) ChangedFile {
+	if len(line) < 4 {
+		return ChangedFile{Path: line}
+	}
+
+	return ChangedFile{
+		Path:     line[3:],
+		Staged:   line[0],
+		Unstaged: line[1],
+	}
+}
+
+// HasStagedChanges returns true if the file has changes in the index
+func (file ChangedFile) HasStagedChanges() bool {
+	return file.Staged != ' ' && file.Staged != '?'
+}
+
+// HasUnstagedChanges returns true if the file has changes in the working tree
+// that aren't in the index
+func (file ChangedFile) HasUnstagedChanges() bool {
+	return file.Unstaged != ' '
+}
+
+/* -------------------- Diffs -------------------- */
+
+// Diff returns the unified diff of the file's staged changes, between HEAD and
+// the index, or of its unstaged changes, between the index and the working tree.
+// It's empty if there are no such changes
+func (repo *GitRepo) Diff(path string, staged bool) (string, error) {
+	gitRepo, workTree, err := openRepository(repo.Repository)
+	if err != nil {
+		return "", err
+	}
+
+	if workTree == "" {
+		return "", fmt.Errorf("a bare repository has no changes")
+	}
+
+	indexed, err := indexVersion(gitRepo, path)
+	if err != nil {
+		return "", err
+	}
+
+	var from, to *fileVersion
+	if staged {
+		if from, err = headVersion(gitRepo, path); err != nil {
+			return "", err
+		}
+		to = indexed
+	} else {
+		from = indexed
+		if to, err = workTreeVersion(workTree, path); err != nil {
+			return "", err
+		}
+	}
+
+	if from == nil && to == nil {
+		return "", nil
+	}
+
+	// The encoder writes a header even when the versions are the same
+	if from != nil && to != nil && from.hash == to.hash && from.mode == to.mode {
+		return "", nil
+	}
+
+	var buf bytes.Buffer
+	err = fdiff.NewUnifiedEncoder(&buf, diffContextLines).Encode(newFilePatch(from, to))
+
+	return buf.String(), err
+}
+
+// stage adds the file's changes to the index, as `git add` does
+func (repo *GitRepo) stage(path string) error {
+	return repo.run("add", "--", path)
+}
+
+// unstage removes the file's changes from the index, leaving the working tree
+// alone, as `git reset` does
+func (repo *GitRepo) unstage(path string) error {
+	if _, err := repoHead(repo.Repository); err == plumbing.ErrReferenceNotFound {
+		// Before the first commit there's nothing to reset to
+		return repo.run("rm", "--cached", "--quiet", "--", path)
+	}
+
+	return repo.run("reset", "--quiet", "--", path)
+}
+
+func repoHead(repoPath string) (*plumbing.Reference, error) {
+	gitRepo, _, err := openRepository(repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return gitRepo.Head()
+}
+
+/* -------------------- File Versions -------------------- */
+
+// fileVersion is the content of a file in HEAD, the index or the working tree
+type fileVersion struct {
+	content []byte
+	hash    plumbing.Hash
+	mode    filemode.FileMode
+	path    string
+}
+
+func (file *fileVersion) Hash() plumbing.Hash {
+	return file.hash
+}
+
+func (file *fileVersion) Mode() filemode.FileMode {
+	return file.mode
+}
+
+func (file *fileVersion) Path() string {
+	return file.path
+}
+
+func headVersion(gitRepo *gogit.Repository, path string) (*fileVersion, error) {
+	head, err := gitRepo.Head()
+	if err == plumbing.ErrReferenceNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	commit, err := gitRepo.CommitObject(head.Hash())
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := commit.File(path)
+	if err != nil {
+		// The file isn't in HEAD
+		return nil, nil
+	}
+
+	return blobVersion(gitRepo, path, file.Hash, file.Mode)
+}
+
+func indexVersion(gitRepo *gogit.Repository, path string) (*fileVersion, error) {
+	idx, err := gitRepo.Storer.Index()
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := idx.Entry(path)
+	if err != nil {
+		// The file isn't in the index
+		return nil, nil
+	}
+
+	return blobVersion(gitRepo, path, entry.Hash, entry.Mode)
+}
+
+func workTreeVersion(workTree, path string) (*fileVersion, error) {
+	fullPath := filepath.Join(workTree, filepath.FromSlash(path))
+
+	info, err := os.Lstat(fullPath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	mode, err := filemode.NewFromOSFileMode(info.Mode())
+	if err != nil {
+		return nil, err
+	}
+
+	var content []byte
+	if mode == filemode.Symlink {
+		target, err := os.Readlink(fullPath)
+		if err != nil {
+			return nil, err
+		}
+		content = []byte(filepath.ToSlash(target))
+	} else if content, err = ioutil.ReadFile(fullPath); err != nil {
+		return nil, err
+	}
+
+	return &fileVersion{
+		content: content,
+		hash:    plumbing.ComputeHash(plumbing.BlobObject, content),
+		mode:    mode,
+		path:    path,
+	}, nil
+}
+
+func blobVersion(gitRepo *gogit.Repository, path string, hash plumbing.Hash, mode filemode.FileMode) (*fileVersion, error) {
+	version := &fileVersion{hash: hash, mode: mode, path: path}
+
+	if mode == filemode.Submodule {
+		// A submodule is a commit, not a blob
+		return version, nil
+	}
+
+	blob, err := gitRepo.BlobObject(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	reader, err := blob.Reader()
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	version.content, err = ioutil.ReadAll(reader)
+
+	return version, err
+}
+
+/* -------------------- Patch -------------------- */
+
+// filePatch is the patch that turns one version of a file into another, in the
+// form go-git's unified diff encoder takes
+type filePatch struct {
+	from, to *fileVersion
+	chunks   []fdiff.Chunk
+	binary   bool
+}
+
+func newFilePatch(from, to *fileVersion) *filePatch {
+	patch := &filePatch{from: from, to: to}
+
+	var fromContent, toContent []byte
+	if from != nil {
+		fromContent = from.content
+	}
+	if to != nil {
+		toContent = to.content
+	}
+
+	if isBinary(fromContent) || isBinary(toContent) {
+		patch.binary = true
+		return patch
+	}
+
+	ops := map[diffmatchpatch.Operation]fdiff.Operation{
+		diffmatchpatch.DiffEqual:  fdiff.Equal,
+		diffmatchpatch.DiffInsert: fdiff.Add,
+		diffmatchpatch.DiffDelete: fdiff.Delete,
+	}
+
+	for _, change := range diff.Do(string(fromContent), string(toContent)) {
+		patch.chunks = append(patch.chunks, &chunk{content: change.Text, op: ops[change.Type]})
+	}
+
+	return patch
+}
+
+func (patch *filePatch) FilePatches() []fdiff.FilePatch {
+	return []fdiff.FilePatch{patch}
+}
+
+func (patch *filePatch) Message() string {
+	return ""
+}
+
+func (patch *filePatch) IsBinary() bool {
+	return patch.binary
+}
+
+func (patch *filePatch) Chunks() []fdiff.Chunk {
+	return patch.chunks
+}
+
+// Files returns the versions of the file. A nil version is returned as a nil
+// interface, which is how the encoder knows the file was added or deleted
+func (patch *filePatch) Files() (fdiff.File, fdiff.File) {
+	var from, to fdiff.File
+	if patch.from != nil {
+		from = patch.from
+	}
+	if patch.to != nil {
+		to = patch.to
+	}
+
+	return from, to
+}
+
+type chunk struct {
+	content string
+	op      fdiff.Operation
+}
+
+func (c *chunk) Content() string {
+	return c.content
+}
+
+func (c *chunk) Type() fdiff.Operation {
+	return c.op
+}
+
+// isBinary guesses whether the content is binary as git does, by looking for a
+// NUL byte near its start
+func isBinary(content []byte) bool {
+	if len(content) > 8000 {
+		content = content[:8000]
+	}
+
+	return bytes.IndexByte(content, 0) >= 0
+}
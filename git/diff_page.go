@@ -0,0 +1,139 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell"
+	"github.com/rivo/tview"
+	"github.com/senorprogrammer/wtf/wtf"
+)
+
+// diffPageName is the name of the page the diff is displayed on
+const diffPageName = "diff"
+
+const diffPageHelp = "s: stage   u: unstage   tab: staged/unstaged   j/k: scroll   esc: close"
+
+// diffPage displays the diff of one of a repo's changed files, either its staged
+// or its unstaged changes, on a page of its own
+type diffPage struct {
+	path   string
+	repo   *GitRepo
+	staged bool
+	widget *Widget
+
+	frame *tview.Frame
+	view  *tview.TextView
+}
+
+func newDiffPage(widget *Widget, repo *GitRepo, file ChangedFile) *diffPage {
+	page := diffPage{
+		path:   file.Path,
+		repo:   repo,
+		widget: widget,
+
+		// Show the unstaged changes first, unless there aren't any
+		staged: file.HasStagedChanges() && !file.HasUnstagedChanges(),
+	}
+
+	page.view = tview.NewTextView()
+	page.view.SetDynamicColors(true)
+	page.view.SetScrollable(true)
+	page.view.SetWrap(false)
+	page.view.SetInputCapture(page.keyboardIntercept)
+
+	page.frame = tview.NewFrame(page.view)
+	page.frame.SetBorder(true)
+	page.frame.SetBorders(0, 0, 0, 1, 1, 1)
+
+	return &page
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+func (page *diffPage) close() {
+	page.widget.pages.RemovePage(diffPageName)
+	page.widget.app.SetFocus(page.widget.View)
+	page.widget.display()
+}
+
+func (page *diffPage) keyboardIntercept(event *tcell.EventKey) *tcell.EventKey {
+	switch string(event.Rune()) {
+	case "q":
+		page.close()
+		return nil
+	case "s":
+		page.update(page.repo.stage, true)
+		return nil
+	case "u":
+		page.update(page.repo.unstage, false)
+		return nil
+	}
+
+	switch event.Key() {
+	case tcell.KeyEsc:
+		page.close()
+		return nil
+	case tcell.KeyTab:
+		page.staged = !page.staged
+		page.render("")
+		return nil
+	default:
+		return event
+	}
+}
+
+// render displays the diff, with the notice, if any, below it
+func (page *diffPage) render(notice string) {
+	changes := "Unstaged Changes"
+	if page.staged {
+		changes = "Staged Changes"
+	}
+
+	page.frame.SetTitle(fmt.Sprintf(" %s - %s ", tview.Escape(page.path), changes))
+	page.frame.Clear()
+	page.frame.AddText(diffPageHelp, false, tview.AlignCenter, tcell.ColorGrey)
+
+	if notice != "" {
+		page.frame.AddText(notice, false, tview.AlignCenter, tcell.ColorRed)
+	}
+
+	if strings.HasSuffix(page.path, "/") {
+		page.view.SetText(" [grey]This directory is untracked. Stage it to add its files.[white]")
+		return
+	}
+
+	diff, err := page.repo.Diff(page.path, page.staged)
+	switch {
+	case err != nil:
+		page.view.SetText(fmt.Sprintf(" [red]%s[white]", tview.Escape(err.Error())))
+	case diff == "":
+		page.view.SetText(fmt.Sprintf(" [grey]No %s[white]", strings.ToLower(changes)))
+	default:
+		style := wtf.Config.UString("wtf.mods.git.diffStyle", "vim")
+		page.view.SetText(wtf.HighlightSyntax(diff, "diff", style))
+	}
+
+	page.view.ScrollToBeginning()
+}
+
+func (page *diffPage) show() {
+	page.render("")
+
+	page.widget.pages.AddPage(diffPageName, page.frame, true, true)
+	page.widget.app.SetFocus(page.view)
+}
+
+// update stages or unstages the file, then shows the changes it now has in the
+// index, after staging, or in the working tree, after unstaging
+func (page *diffPage) update(action func(string) error, staged bool) {
+	if err := action(page.path); err != nil {
+		page.render(err.Error())
+		return
+	}
+
+	page.staged = staged
+	page.render("")
+
+	go wtf.RefreshWidget(page.widget)
+}
@@ -0,0 +1,128 @@
+package git
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/senorprogrammer/wtf/wtf"
+)
+
+// repoPaths returns the configured repositories, with ~ expanded, followed by those
+// discovered under the configured roots, without duplicates
+func (widget *Widget) repoPaths() []string {
+	paths := []string{}
+
+	seen := map[string]bool{}
+	for _, path := range wtf.ToStrs(wtf.Config.UList("wtf.mods.git.repositories")) {
+		if expanded, err := wtf.ExpandHomeDir(path); err == nil {
+			path = expanded
+		}
+
+		if !seen[cleanPath(path)] {
+			seen[cleanPath(path)] = true
+			paths = append(paths, path)
+		}
+	}
+
+	for _, path := range widget.discoveredRepos() {
+		if !seen[cleanPath(path)] {
+			seen[cleanPath(path)] = true
+			paths = append(paths, path)
+		}
+	}
+
+	return paths
+}
+
+// discoveredRepos returns the repositories under the configured roots. Walking a
+// large root takes much longer than reading the repositories, so the roots are
+// only scanned again once 'rescanInterval' seconds have passed since the last
+// scan, which picks up repositories cloned into them without a restart
+func (widget *Widget) discoveredRepos() []string {
+	widget.discoveryMutex.Lock()
+	defer widget.discoveryMutex.Unlock()
+
+	interval := time.Duration(wtf.Config.UInt("wtf.mods.git.rescanInterval", 60)) * time.Second
+	if widget.discovered != nil && time.Since(widget.discoveredAt) < interval {
+		return widget.discovered
+	}
+
+	roots := wtf.ToStrs(wtf.Config.UList("wtf.mods.git.roots"))
+	maxDepth := wtf.Config.UInt("wtf.mods.git.maxDepth", 3)
+
+	discovered := []string{}
+	for _, root := range roots {
+		discovered = append(discovered, DiscoverRepos(root, maxDepth)...)
+	}
+
+	widget.discovered = discovered
+	widget.discoveredAt = time.Now()
+
+	return discovered
+}
+
+// DiscoverRepos returns the repositories in the root directory, and in the
+// directories below it down to maxDepth levels. Repositories aren't searched for
+// more repositories, and neither are hidden directories or symlinks
+func DiscoverRepos(root string, maxDepth int) []string {
+	root, err := wtf.ExpandHomeDir(root)
+	if err != nil {
+		return []string{}
+	}
+
+	repos := []string{}
+
+	var scan func(dir string, depth int)
+	scan = func(dir string, depth int) {
+		if isRepo(dir) {
+			repos = append(repos, dir)
+			return
+		}
+
+		if depth >= maxDepth {
+			return
+		}
+
+		infos, err := ioutil.ReadDir(dir)
+		if err != nil {
+			return
+		}
+
+		for _, info := range infos {
+			if info.IsDir() && !strings.HasPrefix(info.Name(), ".") {
+				scan(filepath.Join(dir, info.Name()), depth+1)
+			}
+		}
+	}
+
+	scan(filepath.Clean(root), 0)
+
+	return repos
+}
+
+// isRepo returns true if the directory is a working tree, which has a .git
+// directory or file, or a bare repository
+func isRepo(dir string) bool {
+	if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+		return true
+	}
+
+	for _, name := range []string{"HEAD", "objects", "refs"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+func cleanPath(path string) string {
+	if expanded, err := wtf.ExpandHomeDir(path); err == nil {
+		path = expanded
+	}
+
+	return filepath.Clean(path)
+}
@@ -2,16 +2,24 @@ package git
 
 import (
 	"fmt"
+	"path/filepath"
+	"sort"
 	"strings"
 	"unicode/utf8"
 
+	"github.com/rivo/tview"
 	"github.com/senorprogrammer/wtf/wtf"
 )
 
 func (widget *Widget) display() {
+	if widget.overview {
+		widget.displayOverview()
+		return
+	}
+
 	repoData := widget.currentData()
 	if repoData == nil {
-		widget.View.SetText(" Git repo data is unavailable ")
+		widget.SetText(" Git repo data is unavailable ")
 		return
 	}
 
@@ -19,37 +27,110 @@ func (widget *Widget) display() {
 	widget.View.SetTitle(widget.ContextualTitle(title))
 
 	str := wtf.SigilStr(len(widget.Data), widget.Idx, widget.View) + "\n"
+
+	if repoData.Err != nil {
+		str = str + fmt.Sprintf(" [red]%s[white]\n", tview.Escape(repoData.Err.Error()))
+		widget.SetText(str)
+		return
+	}
+
 	str = str + " [red]Branch[white]\n"
-	str = str + fmt.Sprintf(" %s", repoData.Branch)
+	str = str + fmt.Sprintf(" %s", tview.Escape(repoData.Branch))
+	str = str + widget.formatTracking(repoData)
 	str = str + "\n"
+	str = str + widget.formatRepoState(repoData)
 	str = str + widget.formatChanges(repoData.ChangedFiles)
 	str = str + "\n"
-	str = str + widget.formatCommits(repoData.Commits)
+	str = str + widget.formatCommits(repoData.Commits, repoData.CommitFilter)
+
+	widget.SetText(str)
+	widget.Publish(wtf.EventRepoSelected, widget.ActionData())
+}
+
+// formatTracking describes where the branch stands against its upstream. Being
+// behind is flagged in yellow, and having diverged in red
+func (widget *Widget) formatTracking(repo *GitRepo) string {
+	if repo.Upstream == "" {
+		return ""
+	}
+
+	str := fmt.Sprintf(" [grey]%s %s[white] ", wtf.Glyph("→", "->"), tview.Escape(repo.Upstream))
+
+	switch {
+	case repo.Ahead > 0 && repo.Behind > 0:
+		return str + fmt.Sprintf("[red]diverged, %d ahead, %d behind[white]", repo.Ahead, repo.Behind)
+	case repo.Behind > 0:
+		return str + fmt.Sprintf("[yellow]%d behind[white]", repo.Behind)
+	case repo.Ahead > 0:
+		return str + fmt.Sprintf("[green]%d ahead[white]", repo.Ahead)
+	}
 
-	widget.View.SetText(str)
+	return str + "[grey]up to date[white]"
+}
+
+// formatRepoState lists the stashes and when the repo was last fetched from
+func (widget *Widget) formatRepoState(repo *GitRepo) string {
+	details := []string{}
+
+	if repo.Stashes == 1 {
+		details = append(details, "1 stash")
+	} else if repo.Stashes > 1 {
+		details = append(details, fmt.Sprintf("%d stashes", repo.Stashes))
+	}
+
+	if repo.Upstream != "" {
+		if repo.FetchedAt.IsZero() {
+			details = append(details, "never fetched")
+		} else {
+			details = append(details, "fetched "+relativeDate(repo.FetchedAt))
+		}
+	}
+
+	str := ""
+	if len(details) > 0 {
+		str = fmt.Sprintf(" [grey]%s[white]\n", strings.Join(details, ", "))
+	}
+
+	if err := widget.fetchError(repo.Path); err != nil {
+		str = str + fmt.Sprintf(" [red]Fetch failed: %s[white]\n", tview.Escape(err.Error()))
+	}
+
+	return str
 }
 
 func (widget *Widget) formatChanges(data []string) string {
 	str := ""
 	str = str + " [red]Changed Files[white]\n"
 
-	if len(data) == 1 {
+	if len(data) == 0 {
 		str = str + " [grey]none[white]\n"
 	} else {
-		for _, line := range data {
-			str = str + widget.formatChange(line)
+		for idx, line := range data {
+			str = str + fmt.Sprintf(`["%d"][""]`, idx) + widget.formatChange(line, idx)
 		}
 	}
 
 	return str
 }
 
-func (widget *Widget) formatChange(line string) string {
+func (widget *Widget) formatChange(line string, idx int) string {
 	if len(line) == 0 {
 		return ""
 	}
 
-	line = strings.TrimSpace(line)
+	// The selected file is the one the diff page opens, so it's only marked while
+	// the widget has focus
+	if widget.View.HasFocus() && idx == widget.selectedFile {
+		return fmt.Sprintf(
+			" [%s]%s%s[%s]\n",
+			wtf.DefaultFocussedRowColor(),
+			wtf.SelectionIndicator(true),
+			tview.Escape(strings.TrimSpace(line)),
+			wtf.DefaultRowColor(),
+		)
+	}
+
+	line = tview.Escape(strings.TrimSpace(line))
 	firstChar, _ := utf8.DecodeRuneInString(line)
 
 	// Revisit this and kill the ugly duplication
@@ -64,12 +145,22 @@ func (widget *Widget) formatChange(line string) string {
 		line = strings.Replace(line, "R", "[purple]R[white]", 1)
 	}
 
-	return fmt.Sprintf(" %s\n", strings.Replace(line, "\"", "", -1))
+	return fmt.Sprintf(" %s%s\n", wtf.SelectionIndicator(false), line)
 }
 
-func (widget *Widget) formatCommits(data []string) string {
+// formatCommits lists the repo's commits under a heading that names the filter
+// they were loaded with, which may since have changed
+func (widget *Widget) formatCommits(data []string, commitFilter string) string {
 	str := ""
-	str = str + " [red]Recent Commits[white]\n"
+	if commitFilter == "" {
+		str = str + " [red]Recent Commits[white]\n"
+	} else {
+		str = str + fmt.Sprintf(" [red]Commits Mentioning %s[white]\n", tview.Escape(commitFilter))
+	}
+
+	if len(data) == 0 {
+		return str + " [grey]none[white]\n"
+	}
 
 	for _, line := range data {
 		str = str + widget.formatCommit(line)
@@ -79,5 +170,128 @@ func (widget *Widget) formatCommits(data []string) string {
 }
 
 func (widget *Widget) formatCommit(line string) string {
-	return fmt.Sprintf(" %s\n", strings.Replace(line, "\"", "", -1))
+	return fmt.Sprintf(" %s\n", line)
+}
+
+// displayOverview lists every repository on a line of its own, with its branch,
+// the number of changed files and how far it is ahead of and behind its upstream.
+// The repos that need attention are listed first: those that couldn't be read,
+// then those with changes, then those that are behind their upstream
+func (widget *Widget) displayOverview() {
+	title := fmt.Sprintf("%s - [green]%d repositories[white]", widget.Name, len(widget.Data))
+	widget.View.SetTitle(widget.ContextualTitle(title))
+
+	if len(widget.Data) == 0 {
+		widget.SetText(" [grey]no repositories[white]")
+		return
+	}
+
+	nameWidth, branchWidth := 0, 0
+	for _, repo := range widget.Data {
+		if len(repoName(repo)) > nameWidth {
+			nameWidth = len(repoName(repo))
+		}
+		if len(repo.Branch) > branchWidth {
+			branchWidth = len(repo.Branch)
+		}
+	}
+
+	str := ""
+	for row, idx := range widget.overviewOrder() {
+		repo := widget.Data[idx]
+		selected := widget.View.HasFocus() && idx == widget.Idx
+
+		rowColor := wtf.RowColor("git", row)
+		if selected {
+			rowColor = wtf.DefaultFocussedRowColor()
+		}
+
+		str = str + fmt.Sprintf(
+			`["%d"][""][%s] %s%-*s  %-*s[white]  %s`+"\n",
+			row,
+			rowColor,
+			wtf.SelectionIndicator(selected),
+			nameWidth,
+			tview.Escape(repoName(repo)),
+			branchWidth,
+			tview.Escape(repo.Branch),
+			overviewStatus(repo),
+		)
+	}
+
+	widget.SetText(str)
+	widget.Publish(wtf.EventRepoSelected, widget.ActionData())
+}
+
+// overviewOrder returns the indexes into widget.Data of the repos in the order
+// the overview lists them
+func (widget *Widget) overviewOrder() []int {
+	return OverviewOrder(widget.Data)
+}
+
+// OverviewOrder returns the indexes of the repos in the order the overview lists
+// them: the ones that need attention most first, and then by name
+func OverviewOrder(repos []*GitRepo) []int {
+	order := []int{}
+	for idx := range repos {
+		order = append(order, idx)
+	}
+
+	sort.SliceStable(order, func(i, j int) bool {
+		a, b := repos[order[i]], repos[order[j]]
+		if attentionRank(a) != attentionRank(b) {
+			return attentionRank(a) < attentionRank(b)
+		}
+		return repoName(a) < repoName(b)
+	})
+
+	return order
+}
+
+// attentionRank ranks the repos by how much they need attention, from 0, for a
+// repo that couldn't be read, to 3, for one that's clean and up to date
+func attentionRank(repo *GitRepo) int {
+	switch {
+	case repo.Err != nil:
+		return 0
+	case len(repo.ChangedFiles) > 0:
+		return 1
+	case repo.Behind > 0:
+		return 2
+	}
+
+	return 3
+}
+
+// overviewStatus summarises the repo's changes and tracking for its overview line
+func overviewStatus(repo *GitRepo) string {
+	if repo.Err != nil {
+		return "[red]" + tview.Escape(repo.Err.Error()) + "[white]"
+	}
+
+	details := []string{}
+
+	if count := len(repo.ChangedFiles); count > 0 {
+		details = append(details, fmt.Sprintf("[yellow]%d changed[white]", count))
+	}
+
+	switch {
+	case repo.Ahead > 0 && repo.Behind > 0:
+		details = append(details, fmt.Sprintf("[red]%s%d %s%d[white]", wtf.Glyph("↑", "+"), repo.Ahead, wtf.Glyph("↓", "-"), repo.Behind))
+	case repo.Behind > 0:
+		details = append(details, fmt.Sprintf("[yellow]%s%d[white]", wtf.Glyph("↓", "-"), repo.Behind))
+	case repo.Ahead > 0:
+		details = append(details, fmt.Sprintf("[green]%s%d[white]", wtf.Glyph("↑", "+"), repo.Ahead))
+	}
+
+	if len(details) == 0 {
+		return "[grey]clean[white]"
+	}
+
+	return strings.Join(details, " ")
+}
+
+// repoName is the name the overview lists the repo under: its directory's name
+func repoName(repo *GitRepo) string {
+	return filepath.Base(repo.Repository)
 }
@@ -2,97 +2,310 @@ package git
 
 import (
 	"fmt"
-	"os/exec"
+	"os"
+	"path/filepath"
+	"regexp"
 	"strings"
+	"time"
 
 	"github.com/senorprogrammer/wtf/wtf"
+	gogit "gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/plumbing/storer"
 )
 
+// remotePattern matches the owner and name at the end of a remote's URL, i.e.:
+// git@github.com:senorprogrammer/wtf.git or https://github.com/senorprogrammer/wtf
+var remotePattern = regexp.MustCompile(`[:/]([^:/]+)/([^:/]+?)(\.git)?/?$`)
+
 type GitRepo struct {
 	Branch       string
 	ChangedFiles []string
+	CommitFilter string
 	Commits      []string
+	Remote       string
 	Repository   string
 	Path         string
+
+	// Upstream is the branch the checked out branch tracks, i.e.: origin/master,
+	// and Ahead and Behind are the number of commits each has that the other
+	// doesn't. Upstream is empty if the branch doesn't track another
+	Upstream string
+	Ahead    int
+	Behind   int
+
+	// FetchedAt is when the repo was last fetched from, and is zero if it never was
+	FetchedAt time.Time
+	Stashes   int
+
+	// Err is set if the repo couldn't be read
+	Err error
 }
 
-func NewGitRepo(repoPath string) *GitRepo {
-	repo := GitRepo{Path: repoPath}
+// NewGitRepo loads the repo at repoPath, which is a working tree, including those
+// of submodules and linked worktrees, or a bare repo. If commitFilter isn't empty,
+// only the commits whose messages mention it are loaded
+func NewGitRepo(repoPath string, commitFilter string) *GitRepo {
+	repo := GitRepo{
+		CommitFilter: commitFilter,
+		Path:         repoPath,
+		Repository:   repoPath,
+	}
 
-	repo.Branch = repo.branch()
-	repo.ChangedFiles = repo.changedFiles()
-	repo.Commits = repo.commits()
-	repo.Repository = strings.TrimSpace(repo.repository())
+	repo.Err = repo.load()
 
 	return &repo
 }
 
+/* -------------------- Exported Functions -------------------- */
+
+// OwnerAndName returns the owner and name of the repo on its origin remote. If it
+// has no origin, the owner is empty and the name is the repo's directory name
+func (repo *GitRepo) OwnerAndName() (string, string) {
+	if match := remotePattern.FindStringSubmatch(repo.Remote); match != nil {
+		return match[1], match[2]
+	}
+
+	return "", filepath.Base(repo.Repository)
+}
+
 /* -------------------- Unexported Functions -------------------- */
 
-func (repo *GitRepo) branch() string {
-	arg := []string{repo.gitDir(), repo.workTree(), "rev-parse", "--abbrev-ref", "HEAD"}
+func (repo *GitRepo) load() error {
+	absPath, err := filepath.Abs(cleanPath(repo.Path))
+	if err != nil {
+		return err
+	}
 
-	cmd := exec.Command("git", arg...)
-	str := wtf.ExecuteCommand(cmd)
+	gitRepo, workTree, err := openRepository(absPath)
+	if err != nil {
+		return err
+	}
 
-	return str
-}
+	if workTree != "" {
+		repo.Repository = workTree
+	} else {
+		repo.Repository = absPath
+	}
+
+	repo.Branch, err = branch(gitRepo)
+	if err != nil {
+		return err
+	}
+
+	repo.ChangedFiles, err = status(gitRepo, absPath, workTree)
+	if err != nil {
+		return err
+	}
 
-func (repo *GitRepo) changedFiles() []string {
-	arg := []string{repo.gitDir(), repo.workTree(), "status", "--porcelain"}
+	repo.Commits, err = repo.commits(gitRepo)
+	if err != nil {
+		return err
+	}
 
-	cmd := exec.Command("git", arg...)
-	str := wtf.ExecuteCommand(cmd)
+	repo.Remote = remote(gitRepo)
 
-	data := strings.Split(str, "\n")
+	gitDir, _, err := findGitDir(absPath)
+	if err != nil {
+		return err
+	}
 
-	return data
+	commonDir := commonGitDir(gitDir)
+	repo.FetchedAt = fetchedAt(gitDir, commonDir)
+	repo.Stashes = stashCount(commonDir)
+
+	return repo.track(gitRepo)
 }
 
-func (repo *GitRepo) commits() []string {
-	numStr := fmt.Sprintf("-n %d", wtf.Config.UInt("wtf.mods.git.commitCount", 10))
+// track sets the upstream branch and how far the checked out branch is ahead of
+// and behind it
+func (repo *GitRepo) track(gitRepo *gogit.Repository) error {
+	upstreamRef, err := upstream(gitRepo)
+	if err != nil || upstreamRef == nil {
+		return err
+	}
 
-	dateFormat := wtf.Config.UString("wtf.mods.git.dateFormat", "%b %d, %Y")
-	dateStr := fmt.Sprintf("--date=format:\"%s\"", dateFormat)
+	head, err := gitRepo.Head()
+	if err == plumbing.ErrReferenceNotFound {
+		// A branch without any commits yet
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	repo.Upstream = upstreamRef.Name().Short()
+	repo.Ahead, repo.Behind, err = aheadBehind(gitRepo, head.Hash(), upstreamRef.Hash())
 
+	return err
+}
+
+func (repo *GitRepo) commits(gitRepo *gogit.Repository) ([]string, error) {
+	commitCount := wtf.Config.UInt("wtf.mods.git.commitCount", 10)
 	commitFormat := wtf.Config.UString("wtf.mods.git.commitFormat", "[forestgreen]%h [white]%s [grey]%an on %cd[white]")
-	commitStr := fmt.Sprintf("--pretty=format:\"%s\"", commitFormat)
+	dateFormat := wtf.Config.UString("wtf.mods.git.dateFormat", "%b %d, %Y")
+
+	head, err := gitRepo.Head()
+	if err == plumbing.ErrReferenceNotFound {
+		// A repo without any commits yet
+		return []string{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var filter *regexp.Regexp
+	if repo.CommitFilter != "" {
+		// Match whole keys, so that PROJ-1 doesn't match PROJ-12
+		filter = regexp.MustCompile("(?i)" + regexp.QuoteMeta(repo.CommitFilter) + "([^0-9]|$)")
+	}
+
+	iter, err := gitRepo.Log(&gogit.LogOptions{From: head.Hash(), Order: gogit.LogOrderCommitterTime})
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+
+	commits := []string{}
+
+	err = iter.ForEach(func(commit *object.Commit) error {
+		if len(commits) >= commitCount {
+			return storer.ErrStop
+		}
 
-	arg := []string{repo.gitDir(), repo.workTree(), "log", dateStr, numStr, commitStr}
+		if filter == nil || filter.MatchString(commit.Message) {
+			commits = append(commits, formatCommit(commit, commitFormat, dateFormat))
+		}
 
-	cmd := exec.Command("git", arg...)
-	str := wtf.ExecuteCommand(cmd)
+		return nil
+	})
 
-	data := strings.Split(str, "\n")
+	return commits, err
+}
+
+// pull runs `git pull`, which needs the user's credentials and hooks, and so is
+// left to git itself
+func (repo *GitRepo) pull() error {
+	return runRemoteGit(repo.Repository, "pull")
+}
+
+// checkout runs `git checkout` with the arguments, and returns what git said it
+// did, i.e.: Switched to branch 'master'
+func (repo *GitRepo) checkout(arg ...string) (string, error) {
+	return gitOutput(repo.Repository, append([]string{"checkout"}, arg...)...)
+}
+
+// run runs the git command in the repo's working tree
+func (repo *GitRepo) run(arg ...string) error {
+	return runGit(repo.Repository, arg...)
+}
+
+// fetch runs `git fetch` in the repo at repoPath, which updates the upstream
+// branch that the ahead and behind counts are relative to
+func fetch(repoPath string) error {
+	return runRemoteGit(repoPath, "fetch", "--quiet")
+}
+
+// runRemoteGit runs a git command that talks to the repo's remotes. It has longer
+// than other commands, set by 'fetchTimeout', and fails rather than prompt for
+// credentials: a prompt would stop the command, which runs in the background
+func runRemoteGit(dir string, arg ...string) error {
+	cmd := wtf.NewCommand("git", arg...)
+	cmd.Dir = dir
+	cmd.Env = []string{"GIT_TERMINAL_PROMPT=0"}
+	if os.Getenv("GIT_SSH") == "" || os.Getenv("GIT_SSH_COMMAND") != "" {
+		cmd.Env = append(cmd.Env, "GIT_SSH_COMMAND="+sshCommand(dir))
+	}
+	cmd.Timeout = time.Duration(wtf.Config.UInt("wtf.mods.git.fetchTimeout", 60)) * time.Second
+
+	result := cmd.Run()
+	if result.Err != nil {
+		if stderr := strings.TrimSpace(result.Stderr); stderr != "" {
+			return fmt.Errorf("%s", firstLine(stderr))
+		}
+		return result.Err
+	}
 
-	return data
+	return nil
 }
 
-func (repo *GitRepo) repository() string {
-	arg := []string{repo.gitDir(), repo.workTree(), "rev-parse", "--show-toplevel"}
-	cmd := exec.Command("git", arg...)
-	str := wtf.ExecuteCommand(cmd)
+// sshCommand returns the ssh command the repo is configured to use, made to fail
+// rather than prompt for a passphrase or to accept a host key
+func sshCommand(dir string) string {
+	command := os.Getenv("GIT_SSH_COMMAND")
+	if command == "" {
+		command, _ = gitOutput(dir, "config", "core.sshCommand")
+	}
+	if command == "" {
+		command = "ssh"
+	}
 
-	return str
+	return command + " -o BatchMode=yes"
 }
-func (repo *GitRepo) pull() string {
-	arg := []string{repo.gitDir(), repo.workTree(), "pull"}
-	cmd := exec.Command("git", arg...)
-	str := wtf.ExecuteCommand(cmd)
-	return str
+
+// runGit runs the git command in the given directory. If it fails, the error is
+// the first line git wrote to stderr, which is the one that says why
+func runGit(dir string, arg ...string) error {
+	_, err := gitOutput(dir, arg...)
+	return err
 }
 
-func (repo *GitRepo) checkout(branch string) string {
-	arg := []string{repo.gitDir(), repo.workTree(), "checkout", branch}
-	cmd := exec.Command("git", arg...)
-	str := wtf.ExecuteCommand(cmd)
-	return str
+// gitOutput runs the git command in the given directory, like runGit, and returns
+// the first line of its output. git reports what it did on stderr, so that's
+// preferred over stdout
+func gitOutput(dir string, arg ...string) (string, error) {
+	cmd := wtf.NewCommand("git", arg...)
+	cmd.Dir = dir
+
+	result := cmd.Run()
+	stderr := strings.TrimSpace(result.Stderr)
+
+	if result.Err != nil {
+		if stderr != "" {
+			return "", fmt.Errorf("%s", firstLine(stderr))
+		}
+		return "", result.Err
+	}
+
+	if stderr != "" {
+		return firstLine(stderr), nil
+	}
+
+	return firstLine(strings.TrimSpace(result.Stdout)), nil
 }
 
-func (repo *GitRepo) gitDir() string {
-	return fmt.Sprintf("--git-dir=%s/.git", repo.Path)
+func firstLine(text string) string {
+	return strings.SplitN(text, "\n", 2)[0]
 }
 
-func (repo *GitRepo) workTree() string {
-	return fmt.Sprintf("--work-tree=%s", repo.Path)
+// branch returns the name of the checked out branch or, if HEAD is detached, the
+// commit it's detached at
+func branch(gitRepo *gogit.Repository) (string, error) {
+	head, err := gitRepo.Reference(plumbing.HEAD, false)
+	if err != nil {
+		return "", err
+	}
+
+	if head.Type() == plumbing.SymbolicReference {
+		return head.Target().Short(), nil
+	}
+
+	return fmt.Sprintf("HEAD detached at %s", head.Hash().String()[:7]), nil
+}
+
+// remote returns the URL of the repo's origin remote. A repo without an origin
+// isn't an error, it just has no remote
+func remote(gitRepo *gogit.Repository) string {
+	config, err := gitRepo.Config()
+	if err != nil {
+		return ""
+	}
+
+	origin, ok := config.Remotes["origin"]
+	if !ok || len(origin.URLs) == 0 {
+		return ""
+	}
+
+	return origin.URLs[0]
 }
@@ -0,0 +1,159 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rivo/tview"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+)
+
+// formatCommit renders the commit with the module's commitFormat, which uses the
+// placeholders of `git log --pretty=format:`: %h and %H for the abbreviated and
+// full hash, %s for the subject, %an, %ae, %ad and %ar for the author's name,
+// email, date and relative date, %cn, %ce, %cd and %cr for the committer's, %n
+// for a new line and %% for a literal %. Dates are formatted with dateFormat,
+// which uses strftime's directives. Any other placeholder is displayed as it is
+func formatCommit(commit *object.Commit, commitFormat, dateFormat string) string {
+	subject := strings.TrimSpace(strings.SplitN(commit.Message, "\n", 2)[0])
+	hash := commit.Hash.String()
+
+	fields := map[string]string{
+		"h":  hash[:7],
+		"H":  hash,
+		"s":  subject,
+		"an": commit.Author.Name,
+		"ae": commit.Author.Email,
+		"ad": strftime(commit.Author.When, dateFormat),
+		"ar": relativeDate(commit.Author.When),
+		"cn": commit.Committer.Name,
+		"ce": commit.Committer.Email,
+		"cd": strftime(commit.Committer.When, dateFormat),
+		"cr": relativeDate(commit.Committer.When),
+	}
+
+	var str strings.Builder
+
+	for i := 0; i < len(commitFormat); i++ {
+		if commitFormat[i] != '%' || i+1 == len(commitFormat) {
+			str.WriteByte(commitFormat[i])
+			continue
+		}
+
+		switch next := commitFormat[i+1]; next {
+		case '%':
+			str.WriteByte('%')
+			i++
+			continue
+		case 'n':
+			str.WriteByte('\n')
+			i++
+			continue
+		case 'h', 'H', 's':
+			str.WriteString(tview.Escape(fields[string(next)]))
+			i++
+			continue
+		}
+
+		if i+2 < len(commitFormat) {
+			if value, ok := fields[commitFormat[i+1:i+3]]; ok {
+				str.WriteString(tview.Escape(value))
+				i += 2
+				continue
+			}
+		}
+
+		str.WriteByte('%')
+	}
+
+	return str.String()
+}
+
+// strftime formats the time with strftime's directives, i.e.: "%b %d, %Y". Any
+// directive that isn't supported is displayed as it is
+func strftime(when time.Time, format string) string {
+	layouts := map[byte]string{
+		'a': "Mon",
+		'A': "Monday",
+		'b': "Jan",
+		'B': "January",
+		'c': "Mon Jan _2 15:04:05 2006",
+		'd': "02",
+		'D': "01/02/06",
+		'e': "_2",
+		'F': "2006-01-02",
+		'h': "Jan",
+		'H': "15",
+		'I': "03",
+		'm': "01",
+		'M': "04",
+		'p': "PM",
+		'R': "15:04",
+		'S': "05",
+		'T': "15:04:05",
+		'y': "06",
+		'Y': "2006",
+		'z': "-0700",
+		'Z': "MST",
+	}
+
+	var str strings.Builder
+
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' || i+1 == len(format) {
+			str.WriteByte(format[i])
+			continue
+		}
+
+		i++
+
+		switch directive := format[i]; directive {
+		case '%':
+			str.WriteByte('%')
+		case 'j':
+			str.WriteString(when.Format("002"))
+		default:
+			if layout, ok := layouts[directive]; ok {
+				str.WriteString(when.Format(layout))
+			} else {
+				str.WriteByte('%')
+				str.WriteByte(directive)
+			}
+		}
+	}
+
+	return str.String()
+}
+
+// relativeDate describes how long ago the time was, as `git log` does for %ar
+// and %cr, i.e.: "3 hours ago"
+func relativeDate(when time.Time) string {
+	since := time.Since(when)
+
+	unit := func(count int, name string) string {
+		if count == 1 {
+			return "1 " + name + " ago"
+		}
+		return fmt.Sprintf("%d %ss ago", count, name)
+	}
+
+	switch {
+	case since < 0:
+		return "in the future"
+	case since < 90*time.Second:
+		return unit(int(since.Seconds()), "second")
+	case since < 90*time.Minute:
+		return unit(int(since.Minutes()+0.5), "minute")
+	case since < 36*time.Hour:
+		return unit(int(since.Hours()+0.5), "hour")
+	case since < 14*24*time.Hour:
+		return unit(int(since.Hours()/24+0.5), "day")
+	case since < 10*7*24*time.Hour:
+		return unit(int(since.Hours()/24/7+0.5), "week")
+	case since < 365*24*time.Hour:
+		return unit(int(since.Hours()/24/30+0.5), "month")
+	}
+
+	return unit(int(since.Hours()/24/365), "year")
+}
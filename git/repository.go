@@ -0,0 +1,224 @@
+package git
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/src-d/go-billy.v4"
+	"gopkg.in/src-d/go-billy.v4/helper/chroot"
+	"gopkg.in/src-d/go-billy.v4/osfs"
+	gogit "gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing/cache"
+	"gopkg.in/src-d/go-git.v4/storage/filesystem"
+)
+
+// openRepository opens the repository at repoPath, which is either a working
+// tree or a bare repository. Working trees whose .git is a file, as submodules'
+// and linked worktrees' are, are followed to their git directory. Returns the
+// repository and the path of its working tree, which is empty for bare repos
+func openRepository(repoPath string) (*gogit.Repository, string, error) {
+	repoPath, err := filepath.Abs(repoPath)
+	if err != nil {
+		return nil, "", err
+	}
+
+	gitDir, workTree, err := findGitDir(repoPath)
+	if err != nil {
+		return nil, "", err
+	}
+
+	storage := filesystem.NewStorage(dotGitFilesystem(gitDir), cache.NewObjectLRUDefault())
+
+	var workTreeFs billy.Filesystem
+	if workTree != "" {
+		workTreeFs = osfs.New(workTree)
+	}
+
+	repo, err := gogit.Open(storage, workTreeFs)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %v", repoPath, err)
+	}
+
+	return repo, workTree, nil
+}
+
+// findGitDir returns the git directory and the working tree of the repository
+// at repoPath
+func findGitDir(repoPath string) (string, string, error) {
+	dotGit := filepath.Join(repoPath, ".git")
+
+	info, err := os.Stat(dotGit)
+	switch {
+	case err == nil && info.IsDir():
+		return dotGit, repoPath, nil
+	case err == nil:
+		gitDir, err := readGitFile(dotGit)
+		return gitDir, repoPath, err
+	case !os.IsNotExist(err):
+		return "", "", err
+	}
+
+	// A bare repository is its own git directory
+	if _, err := os.Stat(filepath.Join(repoPath, "HEAD")); err == nil {
+		return repoPath, "", nil
+	}
+
+	return "", "", fmt.Errorf("%s is not a git repository", repoPath)
+}
+
+// readGitFile returns the git directory a .git file points to, i.e.:
+// gitdir: ../.git/modules/vendor
+func readGitFile(path string) (string, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	line := strings.TrimSpace(strings.SplitN(string(content), "\n", 2)[0])
+	if !strings.HasPrefix(line, "gitdir:") {
+		return "", fmt.Errorf("%s doesn't point to a git directory", path)
+	}
+
+	gitDir := strings.TrimSpace(strings.TrimPrefix(line, "gitdir:"))
+	if !filepath.IsAbs(gitDir) {
+		gitDir = filepath.Join(filepath.Dir(path), gitDir)
+	}
+
+	return gitDir, nil
+}
+
+// dotGitFilesystem returns the filesystem the repository's data is read from. A
+// linked worktree's git directory only holds its HEAD and index, and everything
+// else is read from the main repository's git directory
+func dotGitFilesystem(gitDir string) billy.Filesystem {
+	commonDir := commonGitDir(gitDir)
+	if commonDir == gitDir {
+		return osfs.New(gitDir)
+	}
+
+	return &worktreeFilesystem{
+		common: osfs.New(commonDir),
+		own:    osfs.New(gitDir),
+	}
+}
+
+// commonGitDir returns the git directory that holds the objects, refs and config.
+// A linked worktree's git directory names it in 'commondir'. Every other git
+// directory is its own
+func commonGitDir(gitDir string) string {
+	content, err := ioutil.ReadFile(filepath.Join(gitDir, "commondir"))
+	if err != nil {
+		return gitDir
+	}
+
+	commonDir := strings.TrimSpace(string(content))
+	if !filepath.IsAbs(commonDir) {
+		commonDir = filepath.Join(gitDir, commonDir)
+	}
+
+	return filepath.Clean(commonDir)
+}
+
+/* -------------------- Worktree Filesystem -------------------- */
+
+// worktreeFilesystem is a linked worktree's git directory. Its own HEAD, index
+// and logs are read from the worktree's directory, and the objects, refs and
+// config it shares with the main repository from the common directory
+type worktreeFilesystem struct {
+	common billy.Filesystem
+	own    billy.Filesystem
+}
+
+// sharedPaths are the top-level entries of a git directory that are read from
+// the common directory
+var sharedPaths = map[string]bool{
+	"branches":    true,
+	"config":      true,
+	"hooks":       true,
+	"info":        true,
+	"logs":        true,
+	"objects":     true,
+	"packed-refs": true,
+	"refs":        true,
+	"remotes":     true,
+	"shallow":     true,
+	"worktrees":   true,
+}
+
+func (fs *worktreeFilesystem) fsFor(path string) billy.Filesystem {
+	clean := filepath.ToSlash(filepath.Clean(path))
+
+	switch clean {
+	case "logs/HEAD", "refs/bisect", "refs/rewritten", "refs/worktree":
+		return fs.own
+	}
+
+	if sharedPaths[strings.Split(clean, "/")[0]] {
+		return fs.common
+	}
+
+	return fs.own
+}
+
+func (fs *worktreeFilesystem) Create(filename string) (billy.File, error) {
+	return fs.fsFor(filename).Create(filename)
+}
+
+func (fs *worktreeFilesystem) Open(filename string) (billy.File, error) {
+	return fs.fsFor(filename).Open(filename)
+}
+
+func (fs *worktreeFilesystem) OpenFile(filename string, flag int, perm os.FileMode) (billy.File, error) {
+	return fs.fsFor(filename).OpenFile(filename, flag, perm)
+}
+
+func (fs *worktreeFilesystem) Stat(filename string) (os.FileInfo, error) {
+	return fs.fsFor(filename).Stat(filename)
+}
+
+func (fs *worktreeFilesystem) Rename(oldpath, newpath string) error {
+	return fs.fsFor(newpath).Rename(oldpath, newpath)
+}
+
+func (fs *worktreeFilesystem) Remove(filename string) error {
+	return fs.fsFor(filename).Remove(filename)
+}
+
+func (fs *worktreeFilesystem) Join(elem ...string) string {
+	return fs.own.Join(elem...)
+}
+
+func (fs *worktreeFilesystem) TempFile(dir, prefix string) (billy.File, error) {
+	return fs.fsFor(dir).TempFile(dir, prefix)
+}
+
+func (fs *worktreeFilesystem) ReadDir(path string) ([]os.FileInfo, error) {
+	return fs.fsFor(path).ReadDir(path)
+}
+
+func (fs *worktreeFilesystem) MkdirAll(filename string, perm os.FileMode) error {
+	return fs.fsFor(filename).MkdirAll(filename, perm)
+}
+
+func (fs *worktreeFilesystem) Lstat(filename string) (os.FileInfo, error) {
+	return fs.fsFor(filename).Lstat(filename)
+}
+
+func (fs *worktreeFilesystem) Symlink(target, link string) error {
+	return fs.fsFor(link).Symlink(target, link)
+}
+
+func (fs *worktreeFilesystem) Readlink(link string) (string, error) {
+	return fs.fsFor(link).Readlink(link)
+}
+
+func (fs *worktreeFilesystem) Chroot(path string) (billy.Filesystem, error) {
+	return chroot.New(fs, path), nil
+}
+
+func (fs *worktreeFilesystem) Root() string {
+	return fs.own.Root()
+}
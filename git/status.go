@@ -0,0 +1,354 @@
+package git
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"gopkg.in/src-d/go-billy.v4/osfs"
+	gogit "gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/filemode"
+	"gopkg.in/src-d/go-git.v4/plumbing/format/gitignore"
+	"gopkg.in/src-d/go-git.v4/plumbing/format/index"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+)
+
+// treeEntry is a file in a commit's tree
+type treeEntry struct {
+	hash plumbing.Hash
+	mode filemode.FileMode
+}
+
+// headTree is the flattened tree of a repository's HEAD commit
+type headTree struct {
+	commit  plumbing.Hash
+	entries map[string]treeEntry
+}
+
+// headTrees caches each repository's flattened HEAD tree, keyed on its path, so
+// that the tree is only walked again when HEAD moves
+var (
+	headTrees     = map[string]*headTree{}
+	headTreeMutex = &sync.Mutex{}
+)
+
+// status returns the repository's changes in the form `git status --porcelain`
+// displays them: two status letters, for the index and the working tree, then
+// the path. i.e.: "M  main.go", " D README.md", "?? notes/"
+//
+// Files whose size and modification time match the index aren't read, so that
+// repositories can be polled cheaply
+func status(repo *gogit.Repository, repoPath, workTree string) ([]string, error) {
+	if workTree == "" {
+		// A bare repo has nothing to change
+		return []string{}, nil
+	}
+
+	idx, err := repo.Storer.Index()
+	if err != nil {
+		return nil, err
+	}
+
+	head, err := flattenHead(repo, repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := map[string][]byte{}
+	change := func(path string, staged, unstaged byte) {
+		if _, ok := changes[path]; !ok {
+			changes[path] = []byte{' ', ' '}
+		}
+		if staged != 0 {
+			changes[path][0] = staged
+		}
+		if unstaged != 0 {
+			changes[path][1] = unstaged
+		}
+	}
+
+	gitDir, _, err := findGitDir(repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	indexed := map[string]bool{}
+	indexTime := indexModTime(gitDir)
+
+	for _, entry := range idx.Entries {
+		indexed[entry.Name] = true
+
+		// The index decoder reads a merged entry's stage as 0, not index.Merged,
+		// so any other stage is one side of a conflict
+		if entry.Stage != 0 {
+			change(entry.Name, 'U', 'U')
+			continue
+		}
+
+		if committed, ok := head.entries[entry.Name]; !ok {
+			change(entry.Name, 'A', 0)
+		} else if committed.hash != entry.Hash || committed.mode != entry.Mode {
+			change(entry.Name, 'M', 0)
+		}
+
+		if !entry.SkipWorktree {
+			if modified := worktreeChange(workTree, entry, indexTime); modified != 0 {
+				change(entry.Name, 0, modified)
+			}
+		}
+	}
+
+	for path := range head.entries {
+		if !indexed[path] {
+			change(path, 'D', 0)
+		}
+	}
+
+	lines := []string{}
+	for path, codes := range changes {
+		lines = append(lines, string(codes)+" "+path)
+	}
+
+	// A file removed from the index but not from the working tree is listed
+	// twice, as deleted and as untracked
+	for _, path := range untracked(gitDir, workTree, indexed) {
+		lines = append(lines, "?? "+path)
+	}
+
+	sort.SliceStable(lines, func(i, j int) bool {
+		return lines[i][3:] < lines[j][3:]
+	})
+
+	return lines, nil
+}
+
+// flattenHead returns the repository's HEAD tree, from the cache if HEAD hasn't
+// moved. A repository without any commits has an empty tree
+func flattenHead(repo *gogit.Repository, repoPath string) (*headTree, error) {
+	ref, err := repo.Head()
+	if err == plumbing.ErrReferenceNotFound {
+		return &headTree{entries: map[string]treeEntry{}}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	headTreeMutex.Lock()
+	cached, ok := headTrees[repoPath]
+	headTreeMutex.Unlock()
+
+	if ok && cached.commit == ref.Hash() {
+		return cached, nil
+	}
+
+	commit, err := repo.CommitObject(ref.Hash())
+	if err != nil {
+		return nil, err
+	}
+
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, err
+	}
+
+	flattened := &headTree{commit: ref.Hash(), entries: map[string]treeEntry{}}
+
+	walker := object.NewTreeWalker(tree, true, nil)
+	defer walker.Close()
+
+	for {
+		name, entry, err := walker.Next()
+		if err != nil {
+			break
+		}
+
+		if entry.Mode != filemode.Dir {
+			flattened.entries[name] = treeEntry{hash: entry.Hash, mode: entry.Mode}
+		}
+	}
+
+	headTreeMutex.Lock()
+	headTrees[repoPath] = flattened
+	headTreeMutex.Unlock()
+
+	return flattened, nil
+}
+
+// indexModTime returns when the index was last written. Files modified at the
+// same time or later may have changed without their size or time changing, so
+// they're read to be sure
+func indexModTime(gitDir string) int64 {
+	info, err := os.Stat(filepath.Join(gitDir, "index"))
+	if err != nil {
+		return 0
+	}
+
+	return info.ModTime().UnixNano()
+}
+
+// worktreeChange returns 'D' if the entry's file was deleted from the working
+// tree, 'M' if it was modified, and 0 if it's unchanged
+func worktreeChange(workTree string, entry *index.Entry, indexTime int64) byte {
+	if entry.Mode == filemode.Submodule {
+		return 0
+	}
+
+	path := filepath.Join(workTree, filepath.FromSlash(entry.Name))
+
+	info, err := os.Lstat(path)
+	if err != nil {
+		return 'D'
+	}
+
+	mode, err := filemode.NewFromOSFileMode(info.Mode())
+	if err != nil || mode != entry.Mode {
+		return 'M'
+	}
+
+	modTime := info.ModTime()
+	if uint32(info.Size()) == entry.Size && modTime.Equal(entry.ModifiedAt) && modTime.UnixNano() < indexTime {
+		return 0
+	}
+
+	var content []byte
+	if mode == filemode.Symlink {
+		target, err := os.Readlink(path)
+		if err != nil {
+			return 'M'
+		}
+		content = []byte(filepath.ToSlash(target))
+	} else {
+		content, err = ioutil.ReadFile(path)
+		if err != nil {
+			return 'M'
+		}
+	}
+
+	if plumbing.ComputeHash(plumbing.BlobObject, content) != entry.Hash {
+		return 'M'
+	}
+
+	return 0
+}
+
+// untracked returns the files in the working tree that aren't in the index and
+// aren't ignored. Directories that contain nothing but untracked files are
+// returned as a whole, with a trailing slash, as git does
+func untracked(gitDir, workTree string, indexed map[string]bool) []string {
+	// The directories that contain tracked files
+	trackedDirs := map[string]bool{}
+	for path := range indexed {
+		for dir := filepath.ToSlash(filepath.Dir(path)); dir != "."; dir = filepath.ToSlash(filepath.Dir(dir)) {
+			trackedDirs[dir] = true
+		}
+	}
+
+	patterns := ignorePatterns(gitDir)
+
+	found := []string{}
+
+	var walk func(dir string, parts []string, patterns []gitignore.Pattern)
+	walk = func(dir string, parts []string, patterns []gitignore.Pattern) {
+		patterns = append(patterns, readIgnoreFile(workTree, parts)...)
+		matcher := gitignore.NewMatcher(patterns)
+
+		infos, err := ioutil.ReadDir(filepath.Join(workTree, dir))
+		if err != nil {
+			return
+		}
+
+		for _, info := range infos {
+			if info.Name() == ".git" {
+				continue
+			}
+
+			path := strings.Join(append(parts, info.Name()), "/")
+			childParts := append(append([]string{}, parts...), info.Name())
+
+			if indexed[path] || matcher.Match(childParts, info.IsDir()) {
+				continue
+			}
+
+			switch {
+			case !info.IsDir():
+				found = append(found, path)
+			case trackedDirs[path]:
+				walk(filepath.Join(dir, info.Name()), childParts, patterns)
+			case hasUntrackedFiles(workTree, childParts, patterns):
+				found = append(found, path+"/")
+			}
+		}
+	}
+
+	walk("", []string{}, patterns)
+
+	return found
+}
+
+// hasUntrackedFiles returns true if the directory, which has no tracked files,
+// contains any file that isn't ignored
+func hasUntrackedFiles(workTree string, parts []string, patterns []gitignore.Pattern) bool {
+	patterns = append(patterns, readIgnoreFile(workTree, parts)...)
+	matcher := gitignore.NewMatcher(patterns)
+
+	infos, err := ioutil.ReadDir(filepath.Join(workTree, filepath.Join(parts...)))
+	if err != nil {
+		return false
+	}
+
+	for _, info := range infos {
+		childParts := append(append([]string{}, parts...), info.Name())
+
+		if info.Name() == ".git" || matcher.Match(childParts, info.IsDir()) {
+			continue
+		}
+
+		if !info.IsDir() || hasUntrackedFiles(workTree, childParts, patterns) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ignorePatterns returns the patterns that apply to the whole working tree: the
+// system and user excludes files and the repository's info/exclude
+func ignorePatterns(gitDir string) []gitignore.Pattern {
+	patterns, _ := gitignore.LoadSystemPatterns(osfs.New("/"))
+
+	global, _ := gitignore.LoadGlobalPatterns(osfs.New("/"))
+	patterns = append(patterns, global...)
+
+	return append(patterns, parseIgnoreFile(filepath.Join(gitDir, "info", "exclude"), nil)...)
+}
+
+// readIgnoreFile returns the patterns in the .gitignore of the directory whose
+// path in the working tree is parts
+func readIgnoreFile(workTree string, parts []string) []gitignore.Pattern {
+	path := filepath.Join(workTree, filepath.Join(parts...), ".gitignore")
+	return parseIgnoreFile(path, parts)
+}
+
+func parseIgnoreFile(path string, domain []string) []gitignore.Pattern {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+
+	patterns := []gitignore.Pattern{}
+	for _, line := range bytes.Split(content, []byte("\n")) {
+		line = bytes.TrimRight(line, "\r")
+		if len(bytes.TrimSpace(line)) == 0 || line[0] == '#' {
+			continue
+		}
+
+		patterns = append(patterns, gitignore.ParsePattern(string(line), domain))
+	}
+
+	return patterns
+}
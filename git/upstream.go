@@ -0,0 +1,183 @@
+package git
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	gogit "gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+)
+
+// Flags painted onto commits while counting how far apart two commits are
+const (
+	reachableFromLocal = 1 << iota
+	reachableFromUpstream
+)
+
+// aheadBehindCounts caches the ahead/behind counts of each pair of local and
+// upstream commits, which only change when either commit moves
+var (
+	aheadBehindCounts = map[[2]plumbing.Hash][2]int{}
+	aheadBehindMutex  = &sync.Mutex{}
+)
+
+// upstream returns the branch the checked out branch tracks, as set by
+// `git branch --set-upstream-to`. Returns nil if HEAD is detached, the branch
+// doesn't track another, or the branch it tracks doesn't exist
+func upstream(gitRepo *gogit.Repository) (*plumbing.Reference, error) {
+	head, err := gitRepo.Reference(plumbing.HEAD, false)
+	if err != nil || head.Type() != plumbing.SymbolicReference {
+		return nil, err
+	}
+
+	config, err := gitRepo.Config()
+	if err != nil {
+		return nil, err
+	}
+
+	tracking, ok := config.Branches[head.Target().Short()]
+	if !ok || tracking.Remote == "" || tracking.Merge == "" {
+		return nil, nil
+	}
+
+	name := tracking.Merge
+	if tracking.Remote != "." {
+		name = plumbing.NewRemoteReferenceName(tracking.Remote, tracking.Merge.Short())
+	}
+
+	ref, err := gitRepo.Reference(name, true)
+	if err == plumbing.ErrReferenceNotFound {
+		return nil, nil
+	}
+
+	return ref, err
+}
+
+// aheadBehind returns the number of commits the local commit has that the
+// upstream one doesn't, and the number the upstream one has that the local one
+// doesn't, as `git rev-list --left-right --count local...upstream` does
+func aheadBehind(gitRepo *gogit.Repository, local, upstream plumbing.Hash) (int, int, error) {
+	key := [2]plumbing.Hash{local, upstream}
+
+	aheadBehindMutex.Lock()
+	counts, ok := aheadBehindCounts[key]
+	aheadBehindMutex.Unlock()
+
+	if ok {
+		return counts[0], counts[1], nil
+	}
+
+	localCommit, err := gitRepo.CommitObject(local)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	upstreamCommit, err := gitRepo.CommitObject(upstream)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	flags := map[plumbing.Hash]int{}
+	walked := map[plumbing.Hash]bool{}
+	queued := map[plumbing.Hash]bool{}
+	queue := []*object.Commit{}
+
+	push := func(commit *object.Commit, flag int) {
+		if flags[commit.Hash]&flag == flag {
+			return
+		}
+
+		flags[commit.Hash] |= flag
+
+		if !queued[commit.Hash] {
+			queued[commit.Hash] = true
+			queue = append(queue, commit)
+		}
+	}
+
+	// needsWalk returns true while there are commits left to walk whose flags
+	// their ancestors don't have yet. A commit that gains a flag after it was
+	// walked, because its date is out of order, is walked again to pass it on
+	needsWalk := func() bool {
+		for _, commit := range queue {
+			if flags[commit.Hash] != reachableFromLocal|reachableFromUpstream || walked[commit.Hash] {
+				return true
+			}
+		}
+		return false
+	}
+
+	push(localCommit, reachableFromLocal)
+	push(upstreamCommit, reachableFromUpstream)
+
+	// Walk back from both commits, newest first, carrying each commit's flags to
+	// its parents, until the rest of the history is reachable from both
+	for needsWalk() {
+		sort.Slice(queue, func(i, j int) bool {
+			return queue[i].Committer.When.After(queue[j].Committer.When)
+		})
+
+		commit := queue[0]
+		queue = queue[1:]
+		queued[commit.Hash] = false
+		walked[commit.Hash] = true
+
+		err := commit.Parents().ForEach(func(parent *object.Commit) error {
+			push(parent, flags[commit.Hash])
+			return nil
+		})
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+
+	ahead, behind := 0, 0
+	for _, flag := range flags {
+		switch flag {
+		case reachableFromLocal:
+			ahead++
+		case reachableFromUpstream:
+			behind++
+		}
+	}
+
+	aheadBehindMutex.Lock()
+	aheadBehindCounts[key] = [2]int{ahead, behind}
+	aheadBehindMutex.Unlock()
+
+	return ahead, behind, nil
+}
+
+// stashCount returns the number of entries in the repo's stash, which git keeps
+// in the stash ref's reflog
+func stashCount(commonDir string) int {
+	content, err := ioutil.ReadFile(filepath.Join(commonDir, "logs", "refs", "stash"))
+	if err != nil {
+		return 0
+	}
+
+	content = bytes.TrimSpace(content)
+	if len(content) == 0 {
+		return 0
+	}
+
+	return bytes.Count(content, []byte("\n")) + 1
+}
+
+// fetchedAt returns when the repo was last fetched from, which is when git last
+// wrote FETCH_HEAD. Returns the zero time if it has never been fetched
+func fetchedAt(gitDir, commonDir string) time.Time {
+	for _, dir := range []string{gitDir, commonDir} {
+		if info, err := os.Stat(filepath.Join(dir, "FETCH_HEAD")); err == nil {
+			return info.ModTime()
+		}
+	}
+
+	return time.Time{}
+}
@@ -1,6 +1,10 @@
 package git
 
 import (
+	"strings"
+	"sync"
+	"time"
+
 	"github.com/gdamore/tcell"
 	"github.com/rivo/tview"
 	"github.com/senorprogrammer/wtf/wtf"
@@ -10,27 +14,86 @@ const HelpText = `
   Keyboard commands for Git:
 
     /: Show/hide this help window
-    c: Checkout to branch
+    c: Checkout a branch
     h: Previous git repository
     l: Next git repository
     p: Pull current git repository
+    v: Toggle the overview of all repositories
 
     arrow left:  Previous git repository
     arrow right: Next git repository
+
+  In a repository:
+
+    j: Select the next changed file
+    k: Select the previous changed file
+
+    arrow down: Select the next changed file
+    arrow up:   Select the previous changed file
+
+    return: Show the selected file's diff
+
+  In the diff:
+
+    s:   Stage the file
+    u:   Unstage the file
+    tab: Switch between the staged and unstaged changes
+    esc: Close the diff
+
+  In the overview:
+
+    j: Select the next repository
+    k: Select the previous repository
+
+    arrow down: Select the next repository
+    arrow up:   Select the previous repository
+
+    return: Show the selected repository
 `
 
-const offscreen = -1000
-const modalWidth = 80
-const modalHeight = 7
+const ConfigDefaults = `
+commitCount: 10
+commitFormat: "[forestgreen]%h [white]%s [grey]%an on %cd[white]"
+dateFormat: "%b %d, %Y"
+diffStyle: "vim"
+fetchInterval: 0
+fetchTimeout: 60
+follow: ""
+maxDepth: 3
+overview: false
+refreshInterval: 8
+repositories: []
+rescanInterval: 60
+roots: []
+`
 
 type Widget struct {
 	wtf.HelpfulWidget
 	wtf.MultiSourceWidget
 	wtf.TextWidget
 
-	app   *tview.Application
-	Data  []*GitRepo
-	pages *tview.Pages
+	app          *tview.Application
+	ch           chan struct{}
+	Data         []*GitRepo
+	overview     bool
+	pages        *tview.Pages
+	selectedFile int
+
+	// commitFilter is set from the followed widget's goroutine and read by
+	// refreshes, so it's guarded by filterMutex
+	commitFilter string
+	filterMutex  *sync.Mutex
+
+	// fetchErrors holds the error of each repo's last background fetch, keyed on
+	// the repo's path
+	fetchErrors map[string]error
+	fetchMutex  *sync.Mutex
+
+	// discovered holds the repos found under the roots when they were last
+	// scanned, at discoveredAt
+	discovered     []string
+	discoveredAt   time.Time
+	discoveryMutex *sync.Mutex
 }
 
 func NewWidget(app *tview.Application, pages *tview.Pages) *Widget {
@@ -39,8 +102,14 @@ func NewWidget(app *tview.Application, pages *tview.Pages) *Widget {
 		MultiSourceWidget: wtf.NewMultiSourceWidget("git", "repository", "repositories"),
 		TextWidget:        wtf.NewTextWidget(app, "Git", "git", true),
 
-		app:   app,
-		pages: pages,
+		app:            app,
+		ch:             make(chan struct{}),
+		discoveryMutex: &sync.Mutex{},
+		fetchErrors:    map[string]error{},
+		fetchMutex:     &sync.Mutex{},
+		filterMutex:    &sync.Mutex{},
+		overview:       wtf.Config.UBool("wtf.mods.git.overview", false),
+		pages:          pages,
 	}
 
 	widget.LoadSources()
@@ -49,98 +118,126 @@ func NewWidget(app *tview.Application, pages *tview.Pages) *Widget {
 	widget.HelpfulWidget.SetView(widget.View)
 	widget.View.SetInputCapture(widget.keyboardIntercept)
 
+	widget.Follow(widget.follow)
+
+	go fetchLoop(&widget)
+
 	return &widget
 }
 
 /* -------------------- Exported Functions -------------------- */
 
+// Checkout opens the picker of the displayed repository's branches
 func (widget *Widget) Checkout() {
-	form := widget.modalForm("Branch to checkout:", "")
+	repo := widget.currentData()
+	if repo == nil || repo.Err != nil {
+		return
+	}
 
-	checkoutFctn := func() {
-		text := form.GetFormItem(0).(*tview.InputField).GetText()
-		repoToCheckout := widget.Data[widget.Idx]
-		repoToCheckout.checkout(text)
-		widget.pages.RemovePage("modal")
-		widget.app.SetFocus(widget.View)
-		widget.display()
-		widget.Refresh()
+	picker, err := newBranchPicker(widget, repo)
+	if err != nil {
+		widget.ShowNotice("[red]" + tview.Escape(err.Error()))
+		return
 	}
 
-	widget.addButtons(form, checkoutFctn)
-	widget.modalFocus(form)
+	picker.show()
+}
+
+// Disable stops the background fetches along with the refreshes
+func (widget *Widget) Disable() {
+	close(widget.ch)
+	widget.TextWidget.Disable()
 }
 
 func (widget *Widget) Pull() {
 	repoToPull := widget.Data[widget.Idx]
-	repoToPull.pull()
+	if err := repoToPull.pull(); err != nil {
+		widget.ShowNotice("[red]" + tview.Escape(err.Error()))
+	}
 	widget.Refresh()
-
 }
 
-func (widget *Widget) Refresh() {
-	repoPaths := wtf.ToStrs(wtf.Config.UList("wtf.mods.git.repositories"))
-
-	widget.UpdateRefreshedAt()
-	widget.Data = widget.gitRepos(repoPaths)
-	widget.display()
-}
+// ActionData returns the fields of the displayed repository, for the module's
+// actions and for the widgets that follow this one
+func (widget *Widget) ActionData() wtf.ActionData {
+	repo := widget.currentData()
+	if repo == nil {
+		return nil
+	}
 
-/* -------------------- Unexported Functions -------------------- */
+	owner, name := repo.OwnerAndName()
 
-func (widget *Widget) addCheckoutButton(form *tview.Form, fctn func()) {
-	form.AddButton("Checkout", fctn)
-}
-
-func (widget *Widget) addButtons(form *tview.Form, checkoutFctn func()) {
-	widget.addCheckoutButton(form, checkoutFctn)
-	widget.addCancelButton(form)
+	return wtf.ActionData{
+		"Branch": strings.TrimSpace(repo.Branch),
+		"Owner":  owner,
+		"Path":   repo.Path,
+		"Repo":   name,
+	}
 }
 
-func (widget *Widget) addCancelButton(form *tview.Form) {
-	cancelFn := func() {
-		widget.pages.RemovePage("modal")
-		widget.app.SetFocus(widget.View)
-		widget.display()
+// OpenItem shows the repository selected in the overview or the diff of the
+// selected changed file, when it's double-clicked
+func (widget *Widget) OpenItem() {
+	if widget.overview {
+		widget.toggleOverview()
+		return
 	}
 
-	form.AddButton("Cancel", cancelFn)
-	form.SetCancelFunc(cancelFn)
+	widget.showDiff()
 }
 
-func (widget *Widget) modalFocus(form *tview.Form) {
-	frame := widget.modalFrame(form)
-	widget.pages.AddPage("modal", frame, false, true)
-	widget.app.SetFocus(frame)
-}
+func (widget *Widget) Refresh() {
+	paths := widget.repoPaths()
+	repos := widget.gitRepos(paths)
+
+	// Keep the same repo displayed when repos are discovered or go away. On the
+	// first refresh, that's the one displayed when the app last ran, which may
+	// be one of the discovered repos
+	current := widget.CurrentSource()
+	if widget.Data == nil && wtf.State.Get("git").Source != "" {
+		current = wtf.State.Get("git").Source
+	}
 
-func (widget *Widget) modalForm(lbl, text string) *tview.Form {
-	form := tview.NewForm().
-		SetButtonsAlign(tview.AlignCenter).
-		SetButtonTextColor(tview.Styles.PrimaryTextColor)
+	widget.UpdateRefreshedAt()
+	widget.Sources = paths
+	widget.Data = repos
 
-	form.AddInputField(lbl, text, 60, nil, nil)
+	for idx, path := range paths {
+		if cleanPath(path) == cleanPath(current) {
+			widget.Idx = idx
+		}
+	}
+	if widget.Idx >= len(paths) {
+		widget.Idx = 0
+	}
 
-	return form
+	widget.display()
 }
 
-func (widget *Widget) modalFrame(form *tview.Form) *tview.Frame {
-	frame := tview.NewFrame(form).SetBorders(0, 0, 0, 0, 0, 0)
-	frame.SetRect(offscreen, offscreen, modalWidth, modalHeight)
-	frame.SetBorder(true)
-	frame.SetBorders(1, 1, 0, 0, 1, 1)
+// SelectItem selects the repository in the overview's row idx, or the changed
+// file idx, when it's clicked on. A file idx of -1 selects no file
+func (widget *Widget) SelectItem(idx int) {
+	if !widget.overview {
+		if repo := widget.currentData(); repo != nil && idx >= -1 && idx < len(repo.ChangedFiles) {
+			widget.selectedFile = idx
+			widget.display()
+		}
+		return
+	}
 
-	drawFunc := func(screen tcell.Screen, x, y, width, height int) (int, int, int, int) {
-		w, h := screen.Size()
-		frame.SetRect((w/2)-(width/2), (h/2)-(height/2), width, height)
-		return x, y, width, height
+	order := widget.overviewOrder()
+	if idx < 0 || idx >= len(order) {
+		return
 	}
 
-	frame.SetDrawFunc(drawFunc)
+	widget.Idx = order[idx]
 
-	return frame
+	wtf.State.SetSource("git", widget.CurrentSource())
+	widget.display()
 }
 
+/* -------------------- Unexported Functions -------------------- */
+
 func (widget *Widget) currentData() *GitRepo {
 	if len(widget.Data) == 0 {
 		return nil
@@ -153,18 +250,121 @@ func (widget *Widget) currentData() *GitRepo {
 	return widget.Data[widget.Idx]
 }
 
+// follow filters the commits to the ones that mention the issue selected in a
+// followed widget, i.e. Jira
+func (widget *Widget) follow(event wtf.Event) {
+	if event.Type != wtf.EventIssueSelected {
+		return
+	}
+
+	key, _ := event.Data["Key"].(string)
+
+	widget.filterMutex.Lock()
+	changed := key != widget.commitFilter
+	widget.commitFilter = key
+	widget.filterMutex.Unlock()
+
+	if changed {
+		go wtf.QueueRefresh(widget)
+	}
+}
+
+// fetchAll fetches every repo, in parallel, and then refreshes the widget so that
+// the new ahead and behind counts are displayed
+func (widget *Widget) fetchAll() {
+	var wg sync.WaitGroup
+	for _, repoPath := range widget.repoPaths() {
+		wg.Add(1)
+
+		go func(repoPath string) {
+			defer wg.Done()
+			err := fetch(repoPath)
+
+			widget.fetchMutex.Lock()
+			widget.fetchErrors[repoPath] = err
+			widget.fetchMutex.Unlock()
+		}(repoPath)
+	}
+	wg.Wait()
+
+	wtf.RefreshWidget(widget)
+}
+
+// fetchError returns the error of the repo's last background fetch, if it failed
+func (widget *Widget) fetchError(repoPath string) error {
+	widget.fetchMutex.Lock()
+	defer widget.fetchMutex.Unlock()
+
+	return widget.fetchErrors[repoPath]
+}
+
 func (widget *Widget) gitRepos(repoPaths []string) []*GitRepo {
-	repos := []*GitRepo{}
+	repos := make([]*GitRepo, len(repoPaths))
+
+	widget.filterMutex.Lock()
+	commitFilter := widget.commitFilter
+	widget.filterMutex.Unlock()
+
+	// Load the repos in parallel, so that one large repo doesn't hold up the rest
+	var wg sync.WaitGroup
+	for idx, repoPath := range repoPaths {
+		wg.Add(1)
 
-	for _, repoPath := range repoPaths {
-		repo := NewGitRepo(repoPath)
-		repos = append(repos, repo)
+		go func(idx int, repoPath string) {
+			defer wg.Done()
+			repos[idx] = NewGitRepo(repoPath, commitFilter)
+		}(idx, repoPath)
 	}
+	wg.Wait()
 
 	return repos
 }
 
 func (widget *Widget) keyboardIntercept(event *tcell.EventKey) *tcell.EventKey {
+	if widget.overview {
+		switch string(event.Rune()) {
+		case "j":
+			widget.selectRow(1)
+			return nil
+		case "k":
+			widget.selectRow(-1)
+			return nil
+		}
+
+		switch event.Key() {
+		case tcell.KeyDown:
+			widget.selectRow(1)
+			return nil
+		case tcell.KeyUp:
+			widget.selectRow(-1)
+			return nil
+		case tcell.KeyEnter:
+			widget.toggleOverview()
+			return nil
+		}
+	} else {
+		switch string(event.Rune()) {
+		case "j":
+			widget.selectFile(1)
+			return nil
+		case "k":
+			widget.selectFile(-1)
+			return nil
+		}
+
+		switch event.Key() {
+		case tcell.KeyDown:
+			widget.selectFile(1)
+			return nil
+		case tcell.KeyUp:
+			widget.selectFile(-1)
+			return nil
+		case tcell.KeyEnter:
+			widget.showDiff()
+			return nil
+		}
+	}
+
 	switch string(event.Rune()) {
 	case "/":
 		widget.ShowHelp()
@@ -181,6 +381,9 @@ func (widget *Widget) keyboardIntercept(event *tcell.EventKey) *tcell.EventKey {
 	case "c":
 		widget.Checkout()
 		return nil
+	case "v":
+		widget.toggleOverview()
+		return nil
 	}
 
 	switch event.Key() {
@@ -194,3 +397,79 @@ func (widget *Widget) keyboardIntercept(event *tcell.EventKey) *tcell.EventKey {
 		return event
 	}
 }
+
+// selectFile moves the selection down or up the changed files by the given number
+// of files
+func (widget *Widget) selectFile(step int) {
+	repo := widget.currentData()
+	if repo == nil || len(repo.ChangedFiles) == 0 {
+		return
+	}
+
+	count := len(repo.ChangedFiles)
+	widget.selectedFile = ((widget.selectedFile+step)%count + count) % count
+	widget.display()
+}
+
+// showDiff opens the diff of the selected changed file on a page of its own
+func (widget *Widget) showDiff() {
+	repo := widget.currentData()
+	if repo == nil || widget.selectedFile < 0 || widget.selectedFile >= len(repo.ChangedFiles) {
+		return
+	}
+
+	file := ParseChange(repo.ChangedFiles[widget.selectedFile])
+	newDiffPage(widget, repo, file).show()
+}
+
+// selectRow moves the overview's selection up or down by the given number of rows
+func (widget *Widget) selectRow(step int) {
+	order := widget.overviewOrder()
+	if len(order) == 0 {
+		return
+	}
+
+	row := 0
+	for idx, dataIdx := range order {
+		if dataIdx == widget.Idx {
+			row = idx
+		}
+	}
+
+	row = (row + step + len(order)) % len(order)
+	widget.Idx = order[row]
+
+	wtf.State.SetSource("git", widget.CurrentSource())
+	widget.display()
+}
+
+// toggleOverview switches between the overview of all the repositories and the
+// details of the selected one
+func (widget *Widget) toggleOverview() {
+	widget.overview = !widget.overview
+	widget.display()
+}
+
+// fetchLoop fetches the repos every 'fetchInterval' seconds, which is usually much
+// longer than the refresh interval, until the widget is disabled. A fetchInterval
+// of 0 turns background fetching off
+func fetchLoop(widget *Widget) {
+	interval := wtf.Config.UInt("wtf.mods.git.fetchInterval", 0)
+	if interval <= 0 {
+		return
+	}
+
+	tick := time.NewTicker(time.Duration(interval) * time.Second)
+	defer tick.Stop()
+
+	for {
+		select {
+		case <-tick.C:
+			if !wtf.SchedulersPaused() {
+				widget.fetchAll()
+			}
+		case <-widget.ch:
+			return
+		}
+	}
+}
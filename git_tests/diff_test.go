@@ -0,0 +1,147 @@
+package git_tests
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/senorprogrammer/wtf/git"
+	. "github.com/stretchr/testify/assert"
+	gogit "gopkg.in/src-d/go-git.v4"
+)
+
+/* -------------------- ParseChange() -------------------- */
+
+func TestParseChange(t *testing.T) {
+	Equal(t, ChangedFile{Path: "main.go", Staged: ' ', Unstaged: 'M'}, ParseChange(" M main.go"))
+	Equal(t, ChangedFile{Path: "git/diff.go", Staged: 'A', Unstaged: ' '}, ParseChange("A  git/diff.go"))
+	Equal(t, ChangedFile{Path: "notes/", Staged: '?', Unstaged: '?'}, ParseChange("?? notes/"))
+	Equal(t, ChangedFile{Path: "read me.md", Staged: ' ', Unstaged: 'D'}, ParseChange(" D read me.md"))
+	Equal(t, ChangedFile{Path: "M "}, ParseChange("M "))
+}
+
+func TestChangedFileHasStagedChanges(t *testing.T) {
+	Equal(t, false, ParseChange(" M main.go").HasStagedChanges())
+	Equal(t, true, ParseChange("A  main.go").HasStagedChanges())
+	Equal(t, true, ParseChange("MM main.go").HasStagedChanges())
+	Equal(t, false, ParseChange("?? main.go").HasStagedChanges())
+}
+
+func TestChangedFileHasUnstagedChanges(t *testing.T) {
+	Equal(t, true, ParseChange(" M main.go").HasUnstagedChanges())
+	Equal(t, false, ParseChange("A  main.go").HasUnstagedChanges())
+	Equal(t, true, ParseChange("MM main.go").HasUnstagedChanges())
+	Equal(t, true, ParseChange("?? main.go").HasUnstagedChanges())
+}
+
+/* -------------------- Diff() -------------------- */
+
+func diff(t *testing.T, dir, path string, staged bool) string {
+	diff, err := NewGitRepo(dir, "").Diff(path, staged)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return diff
+}
+
+func TestDiffUnchanged(t *testing.T) {
+	dir, cleanup := makeRepo(t)
+	defer cleanup()
+
+	Equal(t, "", diff(t, dir, "a.txt", false))
+	Equal(t, "", diff(t, dir, "a.txt", true))
+	Equal(t, "", diff(t, dir, "missing.txt", false))
+}
+
+func TestDiffModified(t *testing.T) {
+	dir, cleanup := makeRepo(t)
+	defer cleanup()
+
+	writeFile(t, dir, "a.txt", "changed\n")
+
+	unstaged := diff(t, dir, "a.txt", false)
+	Contains(t, unstaged, "--- a/a.txt\n+++ b/a.txt\n")
+	Contains(t, unstaged, "@@ -1 +1 @@\n-a\n+changed\n")
+
+	Equal(t, "", diff(t, dir, "a.txt", true))
+}
+
+func TestDiffStaged(t *testing.T) {
+	dir, cleanup := makeRepo(t)
+	defer cleanup()
+
+	gitRepo, _ := gogit.PlainOpen(dir)
+	worktree, _ := gitRepo.Worktree()
+
+	writeFile(t, dir, "a.txt", "changed\n")
+	worktree.Add("a.txt")
+
+	Contains(t, diff(t, dir, "a.txt", true), "@@ -1 +1 @@\n-a\n+changed\n")
+	Equal(t, "", diff(t, dir, "a.txt", false))
+}
+
+func TestDiffUntracked(t *testing.T) {
+	dir, cleanup := makeRepo(t)
+	defer cleanup()
+
+	writeFile(t, dir, "c.txt", "c\n")
+
+	unstaged := diff(t, dir, "c.txt", false)
+	Contains(t, unstaged, "new file mode 100644\n")
+	Contains(t, unstaged, "--- /dev/null\n+++ b/c.txt\n")
+	Contains(t, unstaged, "+c\n")
+
+	Equal(t, "", diff(t, dir, "c.txt", true))
+}
+
+func TestDiffAdded(t *testing.T) {
+	dir, cleanup := makeRepo(t)
+	defer cleanup()
+
+	gitRepo, _ := gogit.PlainOpen(dir)
+	worktree, _ := gitRepo.Worktree()
+
+	writeFile(t, dir, "c.txt", "c\n")
+	worktree.Add("c.txt")
+
+	staged := diff(t, dir, "c.txt", true)
+	Contains(t, staged, "new file mode 100644\n")
+	Contains(t, staged, "+c\n")
+}
+
+func TestDiffDeleted(t *testing.T) {
+	dir, cleanup := makeRepo(t)
+	defer cleanup()
+
+	os.Remove(filepath.Join(dir, "b.txt"))
+
+	unstaged := diff(t, dir, "b.txt", false)
+	Contains(t, unstaged, "deleted file mode 100644\n")
+	Contains(t, unstaged, "--- a/b.txt\n+++ /dev/null\n")
+	Contains(t, unstaged, "-b\n")
+}
+
+func TestDiffBinary(t *testing.T) {
+	dir, cleanup := makeRepo(t)
+	defer cleanup()
+
+	writeFile(t, dir, "a.txt", "a\x00b\n")
+
+	Contains(t, diff(t, dir, "a.txt", false), "Binary files a/a.txt and b/a.txt differ\n")
+}
+
+func TestDiffBare(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wtf-git")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	initRepo(t, dir, true)
+
+	_, err = NewGitRepo(dir, "").Diff("a.txt", false)
+
+	EqualError(t, err, "a bare repository has no changes")
+}
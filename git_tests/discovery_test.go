@@ -0,0 +1,81 @@
+package git_tests
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/senorprogrammer/wtf/git"
+	. "github.com/stretchr/testify/assert"
+	gogit "gopkg.in/src-d/go-git.v4"
+)
+
+/* -------------------- DiscoverRepos() -------------------- */
+
+func initRepo(t *testing.T, dir string, bare bool) string {
+	if _, err := gogit.PlainInit(dir, bare); err != nil {
+		t.Fatal(err)
+	}
+
+	return dir
+}
+
+func TestDiscoverRepos(t *testing.T) {
+	root, err := ioutil.TempDir("", "wtf-git")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+
+	first := initRepo(t, filepath.Join(root, "first"), false)
+	bare := initRepo(t, filepath.Join(root, "mirrors", "bare.git"), true)
+	third := initRepo(t, filepath.Join(root, "work", "team", "third"), false)
+	deep := initRepo(t, filepath.Join(root, "work", "team", "old", "deep"), false)
+
+	// Repos in hidden directories, and inside other repos, aren't discovered
+	initRepo(t, filepath.Join(root, ".cache", "hidden"), false)
+	initRepo(t, filepath.Join(first, "vendor", "nested"), false)
+	writeFile(t, root, "notes/todo.txt", "todo\n")
+
+	// Directories are searched in the order of their names
+	Equal(t, []string{first, bare, third}, DiscoverRepos(root, 3))
+	Equal(t, []string{first, bare, deep, third}, DiscoverRepos(root, 4))
+	Equal(t, []string{first}, DiscoverRepos(root, 1))
+	Equal(t, []string{}, DiscoverRepos(root, 0))
+}
+
+func TestDiscoverReposRootIsRepo(t *testing.T) {
+	dir, cleanup := makeRepo(t)
+	defer cleanup()
+
+	initRepo(t, filepath.Join(dir, "nested"), false)
+
+	Equal(t, []string{dir}, DiscoverRepos(dir, 3))
+}
+
+func TestDiscoverReposMissingRoot(t *testing.T) {
+	Equal(t, []string{}, DiscoverRepos("/does/not/exist", 3))
+}
+
+/* -------------------- OverviewOrder() -------------------- */
+
+func TestOverviewOrder(t *testing.T) {
+	repos := []*GitRepo{
+		{Repository: "/src/clean"},
+		{Repository: "/src/behind", Behind: 2},
+		{Repository: "/src/dirty", ChangedFiles: []string{" M a.txt"}},
+		{Repository: "/src/broken", Err: errors.New("not a git repository")},
+		{Repository: "/src/another", ChangedFiles: []string{"?? b.txt"}, Behind: 1},
+		{Repository: "/src/ahead", Ahead: 3},
+	}
+
+	// Unreadable repos come first, then the ones with changes, then the ones that
+	// are behind, and then the rest, each by name
+	Equal(t, []int{3, 4, 2, 1, 5, 0}, OverviewOrder(repos))
+}
+
+func TestOverviewOrderEmpty(t *testing.T) {
+	Equal(t, []int{}, OverviewOrder([]*GitRepo{}))
+}
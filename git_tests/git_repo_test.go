@@ -0,0 +1,339 @@
+package git_tests
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/olebedev/config"
+	. "github.com/senorprogrammer/wtf/git"
+	"github.com/senorprogrammer/wtf/wtf"
+	. "github.com/stretchr/testify/assert"
+	gogit "gopkg.in/src-d/go-git.v4"
+	gitconfig "gopkg.in/src-d/go-git.v4/config"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+)
+
+const testConfig = `
+wtf:
+  mods:
+    git:
+      commitFormat: "%h %s (%an, %cd)"
+      dateFormat: "%Y-%m-%d"
+`
+
+// makeRepo creates a repo with one commit of a.txt and b.txt
+func makeRepo(t *testing.T) (string, func()) {
+	wtf.Config, _ = config.ParseYaml(testConfig)
+
+	dir, err := ioutil.TempDir("", "wtf-git")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	repo, err := gogit.PlainInit(dir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	writeFile(t, dir, "a.txt", "a\n")
+	writeFile(t, dir, "b.txt", "b\n")
+
+	commit(t, repo, "PROJ-1 Add the files", "a.txt", "b.txt")
+
+	return dir, func() { os.RemoveAll(dir) }
+}
+
+func commit(t *testing.T, repo *gogit.Repository, message string, paths ...string) {
+	worktree, _ := repo.Worktree()
+	for _, path := range paths {
+		worktree.Add(path)
+	}
+
+	_, err := worktree.Commit(message, &gogit.CommitOptions{
+		Author: &object.Signature{
+			Name:  "Chris",
+			Email: "chris@example.com",
+			When:  time.Date(2018, 9, 1, 12, 0, 0, 0, time.UTC),
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func writeFile(t *testing.T, dir, name, content string) {
+	path := filepath.Join(dir, name)
+	os.MkdirAll(filepath.Dir(path), 0755)
+
+	if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+/* -------------------- NewGitRepo() -------------------- */
+
+func TestNewGitRepo(t *testing.T) {
+	dir, cleanup := makeRepo(t)
+	defer cleanup()
+
+	repo := NewGitRepo(dir, "")
+
+	Nil(t, repo.Err)
+	Equal(t, "master", repo.Branch)
+	Equal(t, []string{}, repo.ChangedFiles)
+	Equal(t, 1, len(repo.Commits))
+	Regexp(t, `^[0-9a-f]{7} PROJ-1 Add the files \(Chris, 2018-09-01\)$`, repo.Commits[0])
+}
+
+func TestNewGitRepoChangedFiles(t *testing.T) {
+	dir, cleanup := makeRepo(t)
+	defer cleanup()
+
+	gitRepo, _ := gogit.PlainOpen(dir)
+	worktree, _ := gitRepo.Worktree()
+
+	writeFile(t, dir, "a.txt", "changed\n")
+	os.Remove(filepath.Join(dir, "b.txt"))
+	writeFile(t, dir, "c.txt", "c\n")
+	worktree.Add("c.txt")
+	writeFile(t, dir, "notes/todo.txt", "todo\n")
+	writeFile(t, dir, ".gitignore", "*.log\n")
+	writeFile(t, dir, "debug.log", "ignored\n")
+
+	repo := NewGitRepo(dir, "")
+
+	Nil(t, repo.Err)
+	Equal(t, []string{"?? .gitignore", " M a.txt", " D b.txt", "A  c.txt", "?? notes/"}, repo.ChangedFiles)
+}
+
+func TestNewGitRepoCommitFilter(t *testing.T) {
+	dir, cleanup := makeRepo(t)
+	defer cleanup()
+
+	gitRepo, _ := gogit.PlainOpen(dir)
+	writeFile(t, dir, "a.txt", "changed\n")
+	commit(t, gitRepo, "PROJ-12 Change a", "a.txt")
+
+	Equal(t, 2, len(NewGitRepo(dir, "").Commits))
+
+	commits := NewGitRepo(dir, "proj-1").Commits
+	Equal(t, 1, len(commits))
+	Contains(t, commits[0], "PROJ-1 Add the files")
+}
+
+func TestNewGitRepoGitFile(t *testing.T) {
+	dir, cleanup := makeRepo(t)
+	defer cleanup()
+
+	// A submodule's .git is a file that points to its git directory
+	module := filepath.Join(dir, "module")
+	writeFile(t, module, ".git", "gitdir: ../.git\n")
+	writeFile(t, module, "a.txt", "a\n")
+	writeFile(t, module, "b.txt", "b\n")
+
+	repo := NewGitRepo(module, "")
+
+	Nil(t, repo.Err)
+	Equal(t, module, repo.Repository)
+	Equal(t, "master", repo.Branch)
+	Equal(t, []string{}, repo.ChangedFiles)
+}
+
+func TestNewGitRepoLinkedWorktree(t *testing.T) {
+	dir, cleanup := makeRepo(t)
+	defer cleanup()
+
+	gitRepo, _ := gogit.PlainOpen(dir)
+	head, _ := gitRepo.Head()
+	gitRepo.Storer.SetReference(plumbing.NewHashReference("refs/heads/feature", head.Hash()))
+
+	// Lay out a linked worktree as 'git worktree add' does: its .git file points
+	// to its own git directory, whose commondir is the main repository's
+	linked, err := ioutil.TempDir("", "wtf-git")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(linked)
+
+	gitDir := filepath.Join(dir, ".git", "worktrees", "feature")
+	index, _ := ioutil.ReadFile(filepath.Join(dir, ".git", "index"))
+
+	writeFile(t, gitDir, "HEAD", "ref: refs/heads/feature\n")
+	writeFile(t, gitDir, "commondir", "../..\n")
+	writeFile(t, gitDir, "gitdir", filepath.Join(linked, ".git")+"\n")
+	writeFile(t, gitDir, "index", string(index))
+	writeFile(t, linked, ".git", "gitdir: "+gitDir+"\n")
+	writeFile(t, linked, "a.txt", "changed\n")
+	writeFile(t, linked, "b.txt", "b\n")
+
+	repo := NewGitRepo(linked, "")
+
+	Nil(t, repo.Err)
+	Equal(t, linked, repo.Repository)
+	Equal(t, "feature", repo.Branch)
+	Equal(t, []string{" M a.txt"}, repo.ChangedFiles)
+	Equal(t, 1, len(repo.Commits))
+
+	// The main working tree keeps its own HEAD and index
+	repo = NewGitRepo(dir, "")
+
+	Nil(t, repo.Err)
+	Equal(t, "master", repo.Branch)
+	Equal(t, []string{}, repo.ChangedFiles)
+}
+
+func TestNewGitRepoBare(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wtf-git")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	gogit.PlainInit(dir, true)
+
+	repo := NewGitRepo(dir, "")
+
+	Nil(t, repo.Err)
+	Equal(t, "master", repo.Branch)
+	Equal(t, []string{}, repo.ChangedFiles)
+	Equal(t, []string{}, repo.Commits)
+}
+
+func TestNewGitRepoNotARepo(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wtf-git")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	repo := NewGitRepo(dir, "")
+
+	NotNil(t, repo.Err)
+	Contains(t, repo.Err.Error(), "not a git repository")
+}
+
+func TestNewGitRepoTracking(t *testing.T) {
+	dir, cleanup := makeRepo(t)
+	defer cleanup()
+
+	gitRepo, _ := gogit.PlainOpen(dir)
+	base, _ := gitRepo.Head()
+
+	cfg, _ := gitRepo.Config()
+	cfg.Branches["master"] = &gitconfig.Branch{Name: "master", Remote: "origin", Merge: "refs/heads/master"}
+	gitRepo.Storer.SetConfig(cfg)
+
+	setUpstream := func(hash plumbing.Hash) {
+		gitRepo.Storer.SetReference(plumbing.NewHashReference("refs/remotes/origin/master", hash))
+	}
+
+	// Up to date
+	setUpstream(base.Hash())
+	repo := NewGitRepo(dir, "")
+	Nil(t, repo.Err)
+	Equal(t, "origin/master", repo.Upstream)
+	Equal(t, 0, repo.Ahead)
+	Equal(t, 0, repo.Behind)
+
+	// Ahead by one
+	writeFile(t, dir, "a.txt", "local\n")
+	commit(t, gitRepo, "Local change", "a.txt")
+
+	repo = NewGitRepo(dir, "")
+	Equal(t, 1, repo.Ahead)
+	Equal(t, 0, repo.Behind)
+
+	// Diverged: two upstream commits on top of the base
+	worktree, _ := gitRepo.Worktree()
+	worktree.Checkout(&gogit.CheckoutOptions{Hash: base.Hash(), Branch: "refs/heads/other", Create: true})
+	writeFile(t, dir, "b.txt", "upstream\n")
+	commit(t, gitRepo, "Upstream change", "b.txt")
+	writeFile(t, dir, "b.txt", "upstream again\n")
+	commit(t, gitRepo, "Another upstream change", "b.txt")
+	other, _ := gitRepo.Head()
+	setUpstream(other.Hash())
+	worktree.Checkout(&gogit.CheckoutOptions{Branch: "refs/heads/master"})
+
+	repo = NewGitRepo(dir, "")
+	Equal(t, "master", repo.Branch)
+	Equal(t, 1, repo.Ahead)
+	Equal(t, 2, repo.Behind)
+}
+
+func TestNewGitRepoStashes(t *testing.T) {
+	dir, cleanup := makeRepo(t)
+	defer cleanup()
+
+	Equal(t, 0, NewGitRepo(dir, "").Stashes)
+
+	writeFile(t, dir, ".git/logs/refs/stash", "0 1 Chris\tWIP on master\n1 2 Chris\tWIP on master\n")
+
+	Equal(t, 2, NewGitRepo(dir, "").Stashes)
+}
+
+/* -------------------- OwnerAndName() -------------------- */
+
+// withOrigin loads the repo at dir after setting its origin remote to url
+func withOrigin(t *testing.T, dir, url string) *GitRepo {
+	gitRepo, _ := gogit.PlainOpen(dir)
+	gitRepo.DeleteRemote("origin")
+
+	if _, err := gitRepo.CreateRemote(&gitconfig.RemoteConfig{Name: "origin", URLs: []string{url}}); err != nil {
+		t.Fatal(err)
+	}
+
+	return NewGitRepo(dir, "")
+}
+
+func TestOwnerAndNameSSH(t *testing.T) {
+	dir, cleanup := makeRepo(t)
+	defer cleanup()
+
+	repo := withOrigin(t, dir, "git@github.com:senorprogrammer/wtf.git")
+	Equal(t, "git@github.com:senorprogrammer/wtf.git", repo.Remote)
+
+	owner, name := repo.OwnerAndName()
+	Equal(t, "senorprogrammer", owner)
+	Equal(t, "wtf", name)
+
+	owner, name = withOrigin(t, dir, "ssh://git@gitlab.example.com:2222/team/project.git").OwnerAndName()
+	Equal(t, "team", owner)
+	Equal(t, "project", name)
+}
+
+func TestOwnerAndNameHTTPS(t *testing.T) {
+	dir, cleanup := makeRepo(t)
+	defer cleanup()
+
+	owner, name := withOrigin(t, dir, "https://github.com/senorprogrammer/wtf.git").OwnerAndName()
+	Equal(t, "senorprogrammer", owner)
+	Equal(t, "wtf", name)
+
+	owner, name = withOrigin(t, dir, "https://github.com/senorprogrammer/wtf").OwnerAndName()
+	Equal(t, "senorprogrammer", owner)
+	Equal(t, "wtf", name)
+
+	owner, name = withOrigin(t, dir, "https://github.com/senorprogrammer/wtf/").OwnerAndName()
+	Equal(t, "senorprogrammer", owner)
+	Equal(t, "wtf", name)
+
+	owner, name = withOrigin(t, dir, "https://github.com/senorprogrammer/wtf.github.io.git").OwnerAndName()
+	Equal(t, "senorprogrammer", owner)
+	Equal(t, "wtf.github.io", name)
+}
+
+func TestOwnerAndNameNoOrigin(t *testing.T) {
+	dir, cleanup := makeRepo(t)
+	defer cleanup()
+
+	repo := NewGitRepo(dir, "")
+	Equal(t, "", repo.Remote)
+
+	owner, name := repo.OwnerAndName()
+	Equal(t, "", owner)
+	Equal(t, filepath.Base(dir), name)
+}
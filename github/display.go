@@ -2,58 +2,191 @@ package github
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/google/go-github/github"
+	"github.com/rivo/tview"
 	"github.com/senorprogrammer/wtf/wtf"
 )
 
+// statsSection is the name the stats section is collapsed under in the state file
+const statsSection = "stats"
+
 func (widget *Widget) display() {
+	if widget.inboxMode {
+		widget.displayInbox()
+		return
+	}
+
 	repo := widget.currentGithubRepo()
 	if repo == nil {
-		widget.View.SetText(" GitHub repo data is unavailable ")
+		widget.SetText(" GitHub repo data is unavailable ")
 		return
 	}
 
 	widget.View.SetTitle(widget.ContextualTitle(fmt.Sprintf("%s - %s", widget.Name, widget.title(repo))))
 
 	str := wtf.SigilStr(len(widget.GithubRepos), widget.Idx, widget.View) + "\n"
-	str = str + " [red]Stats[white]\n"
-	str = str + widget.displayStats(repo)
+	if wtf.State.IsCollapsed(widget.ConfigKey(), statsSection) {
+		str = str + " [red]Stats[white] [grey](s to show)[white]\n"
+	} else {
+		str = str + " [red]Stats[white]\n"
+		str = str + widget.displayStats(repo)
+	}
+
+	// Configured sections replace the review requests and the user's pull requests
+	if hasConfiguredSections() {
+		str = str + widget.displaySections(repo.Sections, widget.repoSectionItems(), 0)
+		widget.SetText(str)
+		return
+	}
+
 	str = str + "\n"
 	str = str + " [red]Open Review Requests[white]\n"
-	str = str + widget.displayMyReviewRequests(repo, wtf.Config.UString("wtf.mods.github.username"))
+	str = str + widget.displayMyReviewRequests()
 	str = str + "\n"
 	str = str + " [red]My Pull Requests[white]\n"
-	str = str + widget.displayMyPullRequests(repo, wtf.Config.UString("wtf.mods.github.username"))
+	str = str + widget.displayMyPullRequests()
 
-	widget.View.SetText(str)
+	widget.SetText(str)
 }
 
-func (widget *Widget) displayMyPullRequests(repo *GithubRepo, username string) string {
-	prs := repo.myPullRequests(username)
+func (widget *Widget) displayMyPullRequests() string {
+	prs := widget.myPullRequests
 
 	if len(prs) == 0 {
 		return " [grey]none[white]\n"
 	}
 
 	str := ""
-	for _, pr := range prs {
-		str = str + fmt.Sprintf(" %s[green]%4d[white] %s\n", mergeString(pr), *pr.Number, *pr.Title)
+	for idx, pr := range prs {
+		str = str + widget.displayPullRequest(pr, idx+len(widget.reviewRequests))
 	}
 
 	return str
 }
 
-func (widget *Widget) displayMyReviewRequests(repo *GithubRepo, username string) string {
-	prs := repo.myReviewRequests(username)
+func (widget *Widget) displayMyReviewRequests() string {
+	prs := widget.reviewRequests
 
 	if len(prs) == 0 {
 		return " [grey]none[white]\n"
 	}
 
 	str := ""
-	for _, pr := range prs {
-		str = str + fmt.Sprintf(" [green]%4d[white] %s\n", *pr.Number, *pr.Title)
+	for idx, pr := range prs {
+		str = str + widget.displayPullRequest(pr, idx)
+	}
+
+	return str
+}
+
+// displayPullRequest formats the pull request's line: its number and title, how
+// long ago it was opened and, if enableStatus is set, whether it can be merged,
+// the state of its checks and the decision of its reviewers
+func (widget *Widget) displayPullRequest(pr *github.PullRequest, row int) string {
+	status := ""
+	review := ""
+
+	if repo := widget.currentGithubRepo(); repo != nil && showStatus() {
+		status = mergeString(pr) + checkString(repo.status(pr))
+		review = reviewString(repo.status(pr))
+	}
+
+	return fmt.Sprintf(
+		`["%d"][""] [%s]%s%s[green]%4d[%s] %s%s [grey]%s[%s]`+"\n",
+		row,
+		widget.rowColor(row),
+		widget.selectionIndicator(row),
+		status,
+		pr.GetNumber(),
+		widget.rowColor(row),
+		tview.Escape(pr.GetTitle()),
+		review,
+		wtf.ShortDuration(time.Since(pr.GetCreatedAt())),
+		wtf.DefaultRowColor(),
+	)
+}
+
+// displayInbox lists the unread notifications, then the results of each section's
+// search
+func (widget *Widget) displayInbox() {
+	inbox := widget.Inbox
+
+	widget.View.SetTitle(widget.ContextualTitle(fmt.Sprintf("%s - [green]Inbox[white]", widget.Name)))
+
+	items := widget.inboxItems()
+	row := 0
+
+	str := " [red]Notifications[white]\n"
+	if inbox.Err != nil {
+		str = str + fmt.Sprintf(" [red]%s[white]\n", tview.Escape(inbox.Err.Error()))
+	} else {
+		str = str + widget.displayInboxItems(items[row:row+len(inbox.Notifications)], row)
+		row = row + len(inbox.Notifications)
+	}
+
+	str = str + widget.displaySections(inbox.Sections, items[row:], row)
+
+	widget.SetText(str)
+}
+
+// displaySections lists each section's search results, or the error it failed
+// with. items are the sections' results, which are numbered from firstRow
+func (widget *Widget) displaySections(sections []*SearchSection, items []inboxItem, firstRow int) string {
+	str := ""
+	idx := 0
+
+	for _, section := range sections {
+		str = str + "\n"
+		str = str + fmt.Sprintf(" [red]%s[white]\n", tview.Escape(section.Title))
+
+		if section.Err != nil {
+			str = str + fmt.Sprintf(" [red]%s[white]\n", tview.Escape(section.Err.Error()))
+			continue
+		}
+
+		str = str + widget.displayInboxItems(items[idx:idx+len(section.Issues)], firstRow+idx)
+		idx = idx + len(section.Issues)
+	}
+
+	return str
+}
+
+// displayInboxItems lists the items, which are numbered from firstRow
+func (widget *Widget) displayInboxItems(items []inboxItem, firstRow int) string {
+	if len(items) == 0 {
+		return " [grey]none[white]\n"
+	}
+
+	str := ""
+	for idx, item := range items {
+		row := firstRow + idx
+		owner, name := item.repo()
+
+		// Issues are numbered after their repo, and notifications say why they
+		// were sent after their title
+		number, reason := "", ""
+		if item.issue != nil {
+			number = fmt.Sprintf("#%d", item.issue.GetNumber())
+		}
+		if item.notification != nil {
+			reason = fmt.Sprintf(" [grey](%s)", strings.Replace(item.notification.GetReason(), "_", " ", -1))
+		}
+
+		str = str + fmt.Sprintf(
+			`["%d"][""] [%s]%s[green]%s%s[%s] %s%s[%s]`+"\n",
+			row,
+			widget.rowColor(row),
+			widget.selectionIndicator(row),
+			tview.Escape(owner+"/"+name),
+			number,
+			widget.rowColor(row),
+			tview.Escape(item.title()),
+			reason,
+			wtf.DefaultRowColor(),
+		)
 	}
 
 	return str
@@ -70,6 +203,18 @@ func (widget *Widget) displayStats(repo *GithubRepo) string {
 	return str
 }
 
+func (widget *Widget) rowColor(index int) string {
+	if widget.View.HasFocus() && (index == widget.selected) {
+		return wtf.DefaultFocussedRowColor()
+	}
+
+	return wtf.DefaultRowColor()
+}
+
+func (widget *Widget) selectionIndicator(index int) string {
+	return wtf.SelectionIndicator(widget.View.HasFocus() && (index == widget.selected))
+}
+
 func (widget *Widget) title(repo *GithubRepo) string {
 	return fmt.Sprintf("[green]%s - %s[white]", repo.Owner, repo.Name)
 }
@@ -85,11 +230,72 @@ var mergeIcons = map[string]string{
 	"blocked":  "[red]✖[white] ",
 }
 
+var asciiMergeIcons = map[string]string{
+	"dirty":    "[red][CONFLICT[][white] ",
+	"clean":    "[green][OK[][white] ",
+	"unstable": "[red][FAIL[][white] ",
+	"blocked":  "[red][BLOCKED[][white] ",
+}
+
+var checkIcons = map[string]string{
+	checkFailing: "[red]✖[white] ",
+	checkPassing: "[green]✔[white] ",
+	checkPending: "[yellow]●[white] ",
+}
+
+var asciiCheckIcons = map[string]string{
+	checkFailing: "[red][CI FAIL[][white] ",
+	checkPassing: "[green][CI OK[][white] ",
+	checkPending: "[yellow][CI PENDING[][white] ",
+}
+
+// checkIcon marks the state of a check, or of all of a pull request's checks
+func checkIcon(state string) string {
+	icons := checkIcons
+	if wtf.Accessible() {
+		icons = asciiCheckIcons
+	}
+
+	return icons[state]
+}
+
+// checkString marks the combined state of the pull request's checks. Without any
+// checks, or before they're loaded, it's empty
+func checkString(status *PRStatus) string {
+	if status == nil {
+		return ""
+	}
+
+	return checkIcon(status.State())
+}
+
+// reviewString describes the reviewers' decision on the pull request
+func reviewString(status *PRStatus) string {
+	if status == nil {
+		return ""
+	}
+
+	switch status.ReviewDecision() {
+	case reviewApproved:
+		return " [green]approved[white]"
+	case reviewChangesRequested:
+		return " [red]changes requested[white]"
+	}
+
+	return ""
+}
+
 func mergeString(pr *github.PullRequest) string {
 	if !showStatus() {
 		return ""
 	}
-	if str, ok := mergeIcons[pr.GetMergeableState()]; ok {
+
+	icons := mergeIcons
+	if wtf.Accessible() {
+		icons = asciiMergeIcons
+	}
+
+	if str, ok := icons[pr.GetMergeableState()]; ok {
 		return str
 	}
 	return "? "
@@ -2,8 +2,8 @@ package github
 
 import (
 	"context"
-	"net/http"
 	"os"
+	"strings"
 
 	ghb "github.com/google/go-github/github"
 	"github.com/senorprogrammer/wtf/wtf"
@@ -19,6 +19,13 @@ type GithubRepo struct {
 	Owner        string
 	PullRequests []*ghb.PullRequest
 	RemoteRepo   *ghb.Repository
+
+	// Sections are the configured sections, with the results in this repo only
+	Sections []*SearchSection
+
+	// statuses holds the checks and reviews of the pull requests the user is
+	// involved in, keyed on their numbers
+	statuses map[int]*PRStatus
 }
 
 func NewGithubRepo(name, owner string) *GithubRepo {
@@ -32,6 +39,16 @@ func NewGithubRepo(name, owner string) *GithubRepo {
 	return &repo
 }
 
+// APIHost returns the host of the GitHub API this repo is loaded from
+func (repo *GithubRepo) APIHost() string {
+	return wtf.HostFor(repo.baseURL, "api.github.com")
+}
+
+// FullName returns the repo's name in owner/name form
+func (repo *GithubRepo) FullName() string {
+	return repo.Owner + "/" + repo.Name
+}
+
 func (repo *GithubRepo) Open() {
 	wtf.OpenFile(*repo.RemoteRepo.HTMLURL)
 }
@@ -42,6 +59,20 @@ func (repo *GithubRepo) Refresh() {
 	repo.RemoteRepo, _ = repo.loadRemoteRepository()
 }
 
+// RefreshStatuses reloads the checks and reviews of the pull requests username
+// created or has been asked to review
+func (repo *GithubRepo) RefreshStatuses(username string) {
+	prs := []*ghb.PullRequest{}
+
+	for _, pr := range repo.PullRequests {
+		if pr.GetUser().GetLogin() == username || isRequestedReviewer(pr, username) {
+			prs = append(prs, pr)
+		}
+	}
+
+	repo.statuses = repo.loadStatuses(prs)
+}
+
 /* -------------------- Counts -------------------- */
 
 func (repo *GithubRepo) IssueCount() int {
@@ -66,49 +97,75 @@ func (repo *GithubRepo) StarCount() int {
 
 /* -------------------- Unexported Functions -------------------- */
 
-func (repo *GithubRepo) isGitHubEnterprise() bool {
-	if len(repo.baseURL) > 0 {
-		if len(repo.uploadURL) == 0 {
-			repo.uploadURL = repo.baseURL
+// refreshRepoSections runs each of the configured sections' searches once for all
+// the repos, and gives each repo the results that are in it. Searches are limited
+// to the repos, unless their query names a repo with 'repo:'. GitHub only allows
+// 30 searches a minute, so they aren't run for each repo
+func refreshRepoSections(repos []*GithubRepo) {
+	if len(repos) == 0 {
+		return
+	}
+
+	sections := ConfiguredSections(wtf.Config.UInt("wtf.mods.github.inboxCount", 10))
+
+	// Each search lists enough results for each repo to have its count of them
+	searches := []*SearchSection{}
+	for _, section := range sections {
+		query := SearchSection{Count: section.Count * len(repos), Query: section.Query}
+		if query.Count > 100 {
+			query.Count = 100
 		}
-		return true
+
+		if !strings.Contains(query.Query, "repo:") {
+			for _, repo := range repos {
+				query.Query = query.Query + " repo:" + repo.FullName()
+			}
+		}
+
+		searches = append(searches, &query)
 	}
-	return false
-}
 
-func (repo *GithubRepo) oauthClient() *http.Client {
-	tokenService := oauth2.StaticTokenSource(
-		&oauth2.Token{AccessToken: repo.apiKey},
-	)
+	if github, err := repos[0].githubClient(); err == nil {
+		search(github, searches)
+	} else {
+		for _, query := range searches {
+			query.Err = err
+		}
+	}
 
-	return oauth2.NewClient(context.Background(), tokenService)
+	for _, repo := range repos {
+		repo.Sections = repoSections(sections, searches, repo.FullName())
+	}
 }
 
-func (repo *GithubRepo) githubClient() (*ghb.Client, error) {
-	oauthClient := repo.oauthClient()
+// repoSections returns a copy of each section with the results of its search that
+// are in the repo, up to the section's count
+func repoSections(sections, searches []*SearchSection, fullName string) []*SearchSection {
+	repoSections := []*SearchSection{}
 
-	if repo.isGitHubEnterprise() {
-		return ghb.NewEnterpriseClient(repo.baseURL, repo.uploadURL, oauthClient)
+	for idx, section := range sections {
+		repoSection := *section
+		repoSection.Err = searches[idx].Err
+		repoSection.Issues = []ghb.Issue{}
+
+		for _, issue := range searches[idx].Issues {
+			if len(repoSection.Issues) < repoSection.Count && strings.EqualFold(issueRepo(&issue), fullName) {
+				repoSection.Issues = append(repoSection.Issues, issue)
+			}
+		}
+
+		repoSections = append(repoSections, &repoSection)
 	}
 
-	return ghb.NewClient(oauthClient), nil
+	return repoSections
 }
 
-func (repo *GithubRepo) loadAPICredentials() {
-	repo.apiKey = wtf.Config.UString(
-		"wtf.mods.github.apiKey",
-		os.Getenv("WTF_GITHUB_TOKEN"),
-	)
-
-	repo.baseURL = wtf.Config.UString(
-		"wtf.mods.github.baseURL",
-		os.Getenv("WTF_GITHUB_BASE_URL"),
-	)
+func (repo *GithubRepo) githubClient() (*ghb.Client, error) {
+	return newGithubClient(repo.apiKey, repo.baseURL, repo.uploadURL)
+}
 
-	repo.uploadURL = wtf.Config.UString(
-		"wtf.mods.github.uploadURL",
-		os.Getenv("WTF_GITHUB_UPLOAD_URL"),
-	)
+func (repo *GithubRepo) loadAPICredentials() {
+	repo.apiKey, repo.baseURL, repo.uploadURL = apiCredentials()
 }
 
 // myPullRequests returns a list of pull requests created by username on this repo
@@ -158,16 +215,46 @@ func (repo *GithubRepo) myReviewRequests(username string) []*ghb.PullRequest {
 	prs := []*ghb.PullRequest{}
 
 	for _, pr := range repo.PullRequests {
-		for _, reviewer := range pr.RequestedReviewers {
-			if *reviewer.Login == username {
-				prs = append(prs, pr)
-			}
+		if isRequestedReviewer(pr, username) {
+			prs = append(prs, pr)
 		}
 	}
 
+	if showStatus() {
+		prs = repo.individualPRs(prs)
+	}
+
 	return prs
 }
 
+// pullRequest returns the open pull request with the number, or nil if there
+// isn't one
+func (repo *GithubRepo) pullRequest(number int) *ghb.PullRequest {
+	for _, pr := range repo.PullRequests {
+		if pr.GetNumber() == number {
+			return pr
+		}
+	}
+
+	return nil
+}
+
+// status returns the checks and reviews of the pull request, or nil if they
+// haven't been loaded
+func (repo *GithubRepo) status(pr *ghb.PullRequest) *PRStatus {
+	return repo.statuses[pr.GetNumber()]
+}
+
+func isRequestedReviewer(pr *ghb.PullRequest, username string) bool {
+	for _, reviewer := range pr.RequestedReviewers {
+		if reviewer.GetLogin() == username {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (repo *GithubRepo) loadPullRequests() ([]*ghb.PullRequest, error) {
 	github, err := repo.githubClient()
 
@@ -201,3 +288,45 @@ func (repo *GithubRepo) loadRemoteRepository() (*ghb.Repository, error) {
 
 	return repository, nil
 }
+
+/* -------------------- API Client -------------------- */
+
+// apiCredentials returns the API key and the URLs of the GitHub API, which are
+// only set for GitHub Enterprise
+func apiCredentials() (apiKey, baseURL, uploadURL string) {
+	apiKey = wtf.Config.UString(
+		"wtf.mods.github.apiKey",
+		os.Getenv("WTF_GITHUB_TOKEN"),
+	)
+
+	baseURL = wtf.Config.UString(
+		"wtf.mods.github.baseURL",
+		os.Getenv("WTF_GITHUB_BASE_URL"),
+	)
+
+	uploadURL = wtf.Config.UString(
+		"wtf.mods.github.uploadURL",
+		os.Getenv("WTF_GITHUB_UPLOAD_URL"),
+	)
+
+	return apiKey, baseURL, uploadURL
+}
+
+func newGithubClient(apiKey, baseURL, uploadURL string) (*ghb.Client, error) {
+	tokenService := oauth2.StaticTokenSource(
+		&oauth2.Token{AccessToken: apiKey},
+	)
+
+	// Route requests through a client that tracks GitHub's rate limit headers
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, wtf.NewHTTPClient("github", nil))
+	oauthClient := oauth2.NewClient(ctx, tokenService)
+
+	if len(baseURL) > 0 {
+		if len(uploadURL) == 0 {
+			uploadURL = baseURL
+		}
+		return ghb.NewEnterpriseClient(baseURL, uploadURL, oauthClient)
+	}
+
+	return ghb.NewClient(oauthClient), nil
+}
@@ -0,0 +1,290 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	ghb "github.com/google/go-github/github"
+	"github.com/senorprogrammer/wtf/wtf"
+)
+
+// apiPathPattern matches the path of an issue's or pull request's API URL, i.e.:
+// /repos/senorprogrammer/wtf/pulls/12 or, for GitHub Enterprise,
+// /api/v3/repos/senorprogrammer/wtf/issues/12
+var apiPathPattern = regexp.MustCompile(`^(/api/v3)?/repos/([^/]+/[^/]+)/(issues|pulls|commits|releases)/([^/]+)$`)
+
+// Inbox is the user's work across all repositories: their unread notifications,
+// followed by the results of the searches configured under 'sections'. By default
+// those are the open pull requests that ask for the user's review, the open issues
+// and pull requests that mention them and the open issues assigned to them
+type Inbox struct {
+	apiKey    string
+	baseURL   string
+	uploadURL string
+
+	Notifications []*ghb.Notification
+	Sections      []*SearchSection
+
+	// Err is set if the notifications couldn't be loaded. The sections' searches
+	// each have their own
+	Err error
+}
+
+// SearchSection is a section of the inbox, which lists the issues and pull
+// requests that a GitHub search query finds, i.e.: is:open label:p1 repo:org/x
+type SearchSection struct {
+	Count  int
+	Issues []ghb.Issue
+	Query  string
+	Title  string
+
+	// Err is set if the search failed, i.e. because the query is invalid
+	Err error
+}
+
+func NewInbox() *Inbox {
+	inbox := Inbox{}
+	inbox.apiKey, inbox.baseURL, inbox.uploadURL = apiCredentials()
+
+	return &inbox
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// APIHost returns the host of the GitHub API the inbox is loaded from
+func (inbox *Inbox) APIHost() string {
+	return wtf.HostFor(inbox.baseURL, "api.github.com")
+}
+
+// MarkRead marks the notification as read on GitHub. It doesn't change the inbox,
+// so it can be called off the app's event loop: Remove takes the notification out
+func (inbox *Inbox) MarkRead(notification *ghb.Notification) error {
+	github, err := inbox.githubClient()
+	if err != nil {
+		return err
+	}
+
+	_, err = github.Activity.MarkThreadRead(context.Background(), notification.GetID())
+	return err
+}
+
+// Remove takes the notification out of the inbox
+func (inbox *Inbox) Remove(notification *ghb.Notification) {
+	for idx, other := range inbox.Notifications {
+		if other == notification {
+			inbox.Notifications = append(inbox.Notifications[:idx], inbox.Notifications[idx+1:]...)
+			break
+		}
+	}
+}
+
+// Refresh reloads the notifications and runs each section's search again.
+// username is the user's GitHub login, which the default sections search for. If
+// it's empty they search for the user the API key belongs to
+func (inbox *Inbox) Refresh(username string) {
+	inbox.Err = inbox.load(username)
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+func (inbox *Inbox) githubClient() (*ghb.Client, error) {
+	return newGithubClient(inbox.apiKey, inbox.baseURL, inbox.uploadURL)
+}
+
+func (inbox *Inbox) load(username string) error {
+	github, err := inbox.githubClient()
+	if err != nil {
+		return err
+	}
+
+	count := wtf.Config.UInt("wtf.mods.github.inboxCount", 10)
+
+	// The sections are searched before they replace the displayed ones, so that
+	// they're never displayed half-loaded
+	sections := searchSections(username, count)
+	search(github, sections)
+
+	inbox.Sections = sections
+
+	notifications, _, err := github.Activity.ListNotifications(
+		context.Background(),
+		&ghb.NotificationListOptions{ListOptions: ghb.ListOptions{PerPage: count}},
+	)
+	if err != nil {
+		inbox.Notifications = nil
+		return err
+	}
+	inbox.Notifications = notifications
+
+	return nil
+}
+
+// ConfiguredSections returns the sections configured under 'sections'. Each is
+// configured as a map with the section's 'title', its 'query' and, optionally,
+// the 'count' of results to list, which defaults to count. A section without a
+// query is skipped
+func ConfiguredSections(count int) []*SearchSection {
+	sections := []*SearchSection{}
+
+	for _, entry := range wtf.Config.UList("wtf.mods.github.sections") {
+		settings, ok := entry.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		section := SearchSection{Count: count}
+		section.Title, _ = settings["title"].(string)
+		section.Query, _ = settings["query"].(string)
+		if sectionCount, ok := settings["count"].(int); ok && sectionCount > 0 {
+			section.Count = sectionCount
+		}
+
+		if section.Query == "" {
+			continue
+		}
+		if section.Title == "" {
+			section.Title = section.Query
+		}
+
+		sections = append(sections, &section)
+	}
+
+	return sections
+}
+
+// hasConfiguredSections returns true if any sections are configured, in which case
+// they're displayed for each repository as well as in the inbox
+func hasConfiguredSections() bool {
+	return len(ConfiguredSections(1)) > 0
+}
+
+// searchSections returns the sections of the inbox: the configured sections, or
+// the default sections if there aren't any
+func searchSections(username string, count int) []*SearchSection {
+	if sections := ConfiguredSections(count); len(sections) > 0 {
+		return sections
+	}
+
+	if username == "" {
+		username = "@me"
+	}
+
+	return []*SearchSection{
+		{Count: count, Title: "Review Requests", Query: "is:open is:pr review-requested:" + username},
+		{Count: count, Title: "Mentions", Query: "is:open mentions:" + username},
+		{Count: count, Title: "Assigned Issues", Query: "is:open is:issue assignee:" + username},
+	}
+}
+
+// search runs each section's search, and sets its results or the error it failed
+// with
+func search(github *ghb.Client, sections []*SearchSection) {
+	for _, section := range sections {
+		opts := &ghb.SearchOptions{
+			Sort:        "updated",
+			Order:       "desc",
+			ListOptions: ghb.ListOptions{PerPage: section.Count},
+		}
+
+		result, _, err := github.Search.Issues(context.Background(), section.Query, opts)
+		if err != nil {
+			section.Err = err
+			continue
+		}
+
+		section.Issues = result.Issues
+	}
+}
+
+// issueRepo returns the owner/name of the issue's repository
+func issueRepo(issue *ghb.Issue) string {
+	parts := strings.Split(strings.TrimSuffix(issue.GetRepositoryURL(), "/"), "/")
+	if len(parts) < 2 {
+		return ""
+	}
+
+	return strings.Join(parts[len(parts)-2:], "/")
+}
+
+// NotificationURL returns the web page of the notification's subject. The API
+// only gives the subject's API URL, so the web page's URL is worked out from it.
+// If it can't be, the repository's page is returned instead
+func NotificationURL(notification *ghb.Notification) string {
+	repoURL := notification.GetRepository().GetHTMLURL()
+
+	apiURL, err := url.Parse(notification.GetSubject().GetURL())
+	if err != nil || repoURL == "" {
+		return repoURL
+	}
+
+	match := apiPathPattern.FindStringSubmatch(apiURL.Path)
+	if match == nil {
+		return repoURL
+	}
+
+	kind := match[3]
+	switch kind {
+	case "pulls":
+		kind = "pull"
+	case "commits":
+		kind = "commit"
+	case "releases":
+		// A release's API URL has its ID, but its page is found by its tag
+		return repoURL + "/releases"
+	}
+
+	return fmt.Sprintf("%s/%s/%s", repoURL, kind, match[4])
+}
+
+/* -------------------- Inbox Items -------------------- */
+
+// inboxItem is a row of the inbox: a notification, or an issue or pull request
+// found by one of the searches
+type inboxItem struct {
+	issue        *ghb.Issue
+	notification *ghb.Notification
+}
+
+// repo returns the owner and name of the item's repository
+func (item inboxItem) repo() (string, string) {
+	fullName := ""
+
+	switch {
+	case item.notification != nil:
+		fullName = item.notification.GetRepository().GetFullName()
+	case item.issue != nil:
+		fullName = issueRepo(item.issue)
+	}
+
+	parts := strings.SplitN(fullName, "/", 2)
+	if len(parts) < 2 {
+		return "", fullName
+	}
+
+	return parts[0], parts[1]
+}
+
+func (item inboxItem) title() string {
+	switch {
+	case item.notification != nil:
+		return item.notification.GetSubject().GetTitle()
+	case item.issue != nil:
+		return item.issue.GetTitle()
+	}
+
+	return ""
+}
+
+func (item inboxItem) url() string {
+	switch {
+	case item.notification != nil:
+		return NotificationURL(item.notification)
+	case item.issue != nil:
+		return item.issue.GetHTMLURL()
+	}
+
+	return ""
+}
@@ -0,0 +1,210 @@
+package github
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/gdamore/tcell"
+	ghb "github.com/google/go-github/github"
+	"github.com/rivo/tview"
+	"github.com/senorprogrammer/wtf/wtf"
+)
+
+// prPageName is the name of the page a pull request's details are displayed on
+const prPageName = "pullRequest"
+
+const prPageHelp = "j/k: select a check   return: open the check   o: open the pull request   esc: close"
+
+// prPage displays a pull request's reviews and each of its checks on a page of its
+// own. The checks can be selected, and opened in a browser
+type prPage struct {
+	pr       *ghb.PullRequest
+	repo     *GithubRepo
+	selected int
+	status   *PRStatus
+	widget   *Widget
+
+	frame *tview.Frame
+	view  *tview.TextView
+}
+
+func newPRPage(widget *Widget, repo *GithubRepo, pr *ghb.PullRequest) *prPage {
+	page := prPage{
+		pr:       pr,
+		repo:     repo,
+		selected: -1,
+		widget:   widget,
+	}
+
+	page.view = tview.NewTextView()
+	page.view.SetDynamicColors(true)
+	page.view.SetRegions(true)
+	page.view.SetScrollable(true)
+	page.view.SetInputCapture(page.keyboardIntercept)
+
+	page.frame = tview.NewFrame(page.view)
+	page.frame.SetBorder(true)
+	page.frame.SetBorders(0, 0, 0, 1, 1, 1)
+	page.frame.SetTitle(fmt.Sprintf(" %s #%d ", tview.Escape(repo.FullName()), pr.GetNumber()))
+	page.frame.AddText(prPageHelp, false, tview.AlignCenter, tcell.ColorGrey)
+
+	return &page
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+func (page *prPage) close() {
+	page.widget.pages.RemovePage(prPageName)
+	page.widget.app.SetFocus(page.widget.View)
+	page.widget.display()
+}
+
+func (page *prPage) keyboardIntercept(event *tcell.EventKey) *tcell.EventKey {
+	switch string(event.Rune()) {
+	case "j":
+		page.selectCheck(1)
+		return nil
+	case "k":
+		page.selectCheck(-1)
+		return nil
+	case "o":
+		wtf.OpenFile(page.pr.GetHTMLURL())
+		return nil
+	case "q":
+		page.close()
+		return nil
+	}
+
+	switch event.Key() {
+	case tcell.KeyDown:
+		page.selectCheck(1)
+		return nil
+	case tcell.KeyEnter:
+		page.open()
+		return nil
+	case tcell.KeyEsc:
+		page.close()
+		return nil
+	case tcell.KeyUp:
+		page.selectCheck(-1)
+		return nil
+	default:
+		return event
+	}
+}
+
+// open opens the selected check's page or, if no check is selected or it has no
+// page, the pull request's
+func (page *prPage) open() {
+	if page.status != nil && page.selected >= 0 && page.selected < len(page.status.Checks) {
+		if url := page.status.Checks[page.selected].URL; url != "" {
+			wtf.OpenFile(url)
+			return
+		}
+	}
+
+	wtf.OpenFile(page.pr.GetHTMLURL())
+}
+
+func (page *prPage) render(err error) {
+	pr := page.pr
+
+	str := fmt.Sprintf(" [green]#%d[white] %s\n", pr.GetNumber(), tview.Escape(pr.GetTitle()))
+	str = str + fmt.Sprintf(
+		" [grey]%s wants to merge %s into %s, opened %s ago[white]\n",
+		tview.Escape(pr.GetUser().GetLogin()),
+		tview.Escape(pr.GetHead().GetRef()),
+		tview.Escape(pr.GetBase().GetRef()),
+		wtf.ShortDuration(time.Since(pr.GetCreatedAt())),
+	)
+
+	if err != nil {
+		str = str + fmt.Sprintf("\n [red]%s[white]\n", tview.Escape(err.Error()))
+		page.view.SetText(str)
+		return
+	}
+
+	if page.status == nil {
+		str = str + "\n [grey]Loading reviews and checks...[white]\n"
+		page.view.SetText(str)
+		return
+	}
+
+	str = str + "\n [red]Reviews[white]\n"
+	if len(page.status.Reviews) == 0 {
+		str = str + " [grey]none[white]\n"
+	}
+	for _, login := range page.status.Reviewers() {
+		color := "green"
+		if page.status.Reviews[login] == reviewChangesRequested {
+			color = "red"
+		}
+
+		str = str + fmt.Sprintf(" %s [%s]%s[white]\n", tview.Escape(login), color, page.status.Reviews[login])
+	}
+
+	str = str + "\n [red]Checks[white]\n"
+	if len(page.status.Checks) == 0 {
+		str = str + " [grey]none[white]\n"
+	}
+	for idx, check := range page.status.Checks {
+		selected := idx == page.selected
+
+		rowColor := wtf.DefaultRowColor()
+		if selected {
+			rowColor = wtf.DefaultFocussedRowColor()
+		}
+
+		str = str + fmt.Sprintf(
+			`["%d"][""] [%s]%s%s%s [grey]%s[%s]`+"\n",
+			idx,
+			rowColor,
+			wtf.SelectionIndicator(selected),
+			checkIcon(check.State),
+			tview.Escape(check.Name),
+			tview.Escape(check.Description),
+			wtf.DefaultRowColor(),
+		)
+	}
+
+	page.view.Highlight(strconv.Itoa(page.selected)).ScrollToHighlight()
+	page.view.SetText(str)
+}
+
+func (page *prPage) selectCheck(step int) {
+	if page.status == nil || len(page.status.Checks) == 0 {
+		return
+	}
+
+	count := len(page.status.Checks)
+
+	switch {
+	case page.selected < 0 && step < 0:
+		page.selected = count - 1
+	case page.selected < 0:
+		page.selected = 0
+	default:
+		page.selected = ((page.selected+step)%count + count) % count
+	}
+
+	page.render(nil)
+}
+
+// show displays the page, and loads the pull request's checks and reviews afresh
+// in the background, displaying them once they've loaded
+func (page *prPage) show() {
+	page.render(nil)
+
+	page.widget.pages.AddPage(prPageName, page.frame, true, true)
+	page.widget.app.SetFocus(page.view)
+
+	go func() {
+		status, err := page.repo.loadStatus(page.pr)
+
+		page.widget.app.QueueUpdateDraw(func() {
+			page.status = status
+			page.render(err)
+		})
+	}()
+}
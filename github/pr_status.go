@@ -0,0 +1,273 @@
+package github
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	ghb "github.com/google/go-github/github"
+)
+
+// The states of a pull request's checks, and of each check
+const (
+	checkFailing = "failing"
+	checkPassing = "passing"
+	checkPending = "pending"
+)
+
+// The review decisions on a pull request
+const (
+	reviewApproved         = "approved"
+	reviewChangesRequested = "changes requested"
+)
+
+// Check is one of the checks run on a pull request's head commit: a commit status,
+// set by i.e. Travis CI, or a check run, set by i.e. GitHub Actions
+type Check struct {
+	Description string
+	Name        string
+	State       string
+	URL         string
+}
+
+// PRStatus is the state of a pull request's checks and its reviews. Reviews holds
+// the decision of each reviewer who approved or requested changes, keyed on
+// their logins
+type PRStatus struct {
+	Checks  []Check
+	Reviews map[string]string
+}
+
+// State combines the states of the checks: failing if any check failed, pending
+// if any hasn't finished, and passing if they all passed. It's empty if there are
+// no checks
+func (status *PRStatus) State() string {
+	if len(status.Checks) == 0 {
+		return ""
+	}
+
+	state := checkPassing
+	for _, check := range status.Checks {
+		switch check.State {
+		case checkFailing:
+			return checkFailing
+		case checkPending:
+			state = checkPending
+		}
+	}
+
+	return state
+}
+
+// ReviewDecision returns changes requested if any reviewer requested changes in
+// their latest review, approved if any approved it, and otherwise nothing
+func (status *PRStatus) ReviewDecision() string {
+	decision := ""
+
+	for _, state := range status.Reviews {
+		switch state {
+		case reviewChangesRequested:
+			return reviewChangesRequested
+		case reviewApproved:
+			decision = reviewApproved
+		}
+	}
+
+	return decision
+}
+
+// Reviewers returns the logins of the reviewers who approved or requested changes,
+// sorted
+func (status *PRStatus) Reviewers() []string {
+	logins := []string{}
+	for login := range status.Reviews {
+		logins = append(logins, login)
+	}
+	sort.Strings(logins)
+
+	return logins
+}
+
+/* -------------------- Loading -------------------- */
+
+// loadStatuses loads the status of each of the pull requests, in parallel, keyed
+// on their numbers. Pull requests whose status can't be loaded are left out
+func (repo *GithubRepo) loadStatuses(prs []*ghb.PullRequest) map[int]*PRStatus {
+	statuses := map[int]*PRStatus{}
+
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+
+	for _, pr := range prs {
+		wg.Add(1)
+
+		go func(pr *ghb.PullRequest) {
+			defer wg.Done()
+
+			status, err := repo.loadStatus(pr)
+			if err != nil {
+				return
+			}
+
+			mutex.Lock()
+			statuses[pr.GetNumber()] = status
+			mutex.Unlock()
+		}(pr)
+	}
+	wg.Wait()
+
+	return statuses
+}
+
+// loadStatus loads the commit statuses and check runs of the pull request's head
+// commit, and its reviews
+func (repo *GithubRepo) loadStatus(pr *ghb.PullRequest) (*PRStatus, error) {
+	github, err := repo.githubClient()
+	if err != nil {
+		return nil, err
+	}
+
+	sha := pr.GetHead().GetSHA()
+	status := &PRStatus{Checks: []Check{}, Reviews: map[string]string{}}
+
+	statuses, err := repo.loadCommitStatuses(github, sha)
+	if err != nil {
+		return nil, err
+	}
+	status.Checks = append(status.Checks, statuses...)
+
+	// GitHub Enterprise versions without check runs return an error, which leaves
+	// just the commit statuses
+	if runs, err := repo.loadCheckRuns(github, sha); err == nil {
+		status.Checks = append(status.Checks, runs...)
+	}
+
+	sort.SliceStable(status.Checks, func(i, j int) bool {
+		return strings.ToLower(status.Checks[i].Name) < strings.ToLower(status.Checks[j].Name)
+	})
+
+	reviews, err := repo.loadReviews(github, pr.GetNumber())
+	if err != nil {
+		return nil, err
+	}
+
+	// Reviews are listed oldest first, so each reviewer's latest one wins
+	for _, review := range reviews {
+		login := review.GetUser().GetLogin()
+
+		switch review.GetState() {
+		case "APPROVED":
+			status.Reviews[login] = reviewApproved
+		case "CHANGES_REQUESTED":
+			status.Reviews[login] = reviewChangesRequested
+		case "DISMISSED":
+			delete(status.Reviews, login)
+		}
+	}
+
+	return status, nil
+}
+
+// loadCommitStatuses loads the latest status of each context set on the commit,
+// a page at a time
+func (repo *GithubRepo) loadCommitStatuses(github *ghb.Client, sha string) ([]Check, error) {
+	checks := []Check{}
+	opts := &ghb.ListOptions{PerPage: 100}
+
+	for {
+		combined, resp, err := github.Repositories.GetCombinedStatus(context.Background(), repo.Owner, repo.Name, sha, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, repoStatus := range combined.Statuses {
+			checks = append(checks, Check{
+				Description: repoStatus.GetDescription(),
+				Name:        repoStatus.GetContext(),
+				State:       StatusState(repoStatus.GetState()),
+				URL:         repoStatus.GetTargetURL(),
+			})
+		}
+
+		if resp.NextPage == 0 {
+			return checks, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// loadCheckRuns loads the check runs of the commit, a page at a time
+func (repo *GithubRepo) loadCheckRuns(github *ghb.Client, sha string) ([]Check, error) {
+	checks := []Check{}
+	opts := &ghb.ListCheckRunsOptions{ListOptions: ghb.ListOptions{PerPage: 100}}
+
+	for {
+		results, resp, err := github.Checks.ListCheckRunsForRef(context.Background(), repo.Owner, repo.Name, sha, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, run := range results.CheckRuns {
+			checks = append(checks, Check{
+				Description: run.GetOutput().GetTitle(),
+				Name:        run.GetName(),
+				State:       CheckRunState(run),
+				URL:         run.GetHTMLURL(),
+			})
+		}
+
+		if resp.NextPage == 0 {
+			return checks, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// loadReviews loads all the pull request's reviews, oldest first, a page at a
+// time. The newest reviews decide whether it's approved, so none can be left out
+func (repo *GithubRepo) loadReviews(github *ghb.Client, number int) ([]*ghb.PullRequestReview, error) {
+	reviews := []*ghb.PullRequestReview{}
+	opts := &ghb.ListOptions{PerPage: 100}
+
+	for {
+		page, resp, err := github.PullRequests.ListReviews(context.Background(), repo.Owner, repo.Name, number, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		reviews = append(reviews, page...)
+
+		if resp.NextPage == 0 {
+			return reviews, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// StatusState returns the check state of a commit status's state
+func StatusState(state string) string {
+	switch state {
+	case "success":
+		return checkPassing
+	case "pending":
+		return checkPending
+	}
+
+	return checkFailing
+}
+
+// CheckRunState returns the check state of a check run. Neutral and skipped runs
+// don't hold a pull request up, so they count as passing
+func CheckRunState(run *ghb.CheckRun) string {
+	if run.GetStatus() != "completed" {
+		return checkPending
+	}
+
+	switch run.GetConclusion() {
+	case "success", "neutral", "skipped":
+		return checkPassing
+	}
+
+	return checkFailing
+}
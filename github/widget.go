@@ -1,7 +1,10 @@
 package github
 
 import (
+	"strings"
+
 	"github.com/gdamore/tcell"
+	ghb "github.com/google/go-github/github"
 	"github.com/rivo/tview"
 	"github.com/senorprogrammer/wtf/wtf"
 )
@@ -11,13 +14,44 @@ const HelpText = `
 
     /: Show/hide this help window
     h: Previous git repository
+    i: Toggle the inbox of notifications and searches
+    j: Select the next item in the list
+    k: Select the previous item in the list
     l: Next git repository
+    m: Mark the selected notification as read
     r: Refresh the data
+    s: Show/hide the repository's stats
+    y: Copy the selected item's URL, or the repository's, to the clipboard
 
+    arrow down:  Select the next item in the list
     arrow left:  Previous git repository
     arrow right: Next git repository
+    arrow up:    Select the previous item in the list
+
+    return: Show the selected pull request's reviews and checks, or open the
+            selected issue or inbox item, or the repository, in a browser
+
+  In a pull request's reviews and checks:
 
-    return: Open the selected repository in a browser
+    j:      Select the next check
+    k:      Select the previous check
+    o:      Open the pull request in a browser
+    return: Open the selected check, or the pull request, in a browser
+    esc:    Close the pull request
+`
+
+const ConfigDefaults = `
+apiKey: ""
+baseURL: ""
+enableStatus: false
+follow: ""
+inbox: false
+inboxCount: 10
+refreshInterval: 300
+repositories: {}
+sections: []
+uploadURL: ""
+username: ""
 `
 
 type Widget struct {
@@ -26,6 +60,14 @@ type Widget struct {
 
 	GithubRepos []*GithubRepo
 	Idx         int
+	Inbox       *Inbox
+
+	app            *tview.Application
+	inboxMode      bool
+	myPullRequests []*ghb.PullRequest
+	pages          *tview.Pages
+	reviewRequests []*ghb.PullRequest
+	selected       int
 }
 
 func NewWidget(app *tview.Application, pages *tview.Pages) *Widget {
@@ -33,25 +75,59 @@ func NewWidget(app *tview.Application, pages *tview.Pages) *Widget {
 		HelpfulWidget: wtf.NewHelpfulWidget(app, pages, HelpText),
 		TextWidget:    wtf.NewTextWidget(app, "GitHub", "github", true),
 
-		Idx: 0,
+		Idx:      0,
+		app:      app,
+		pages:    pages,
+		selected: -1,
 	}
 
 	widget.GithubRepos = widget.buildRepoCollection(wtf.Config.UMap("wtf.mods.github.repositories"))
+	widget.Inbox = NewInbox()
+
+	// Without any repositories, the inbox is all there is to show
+	widget.inboxMode = wtf.Config.UBool("wtf.mods.github.inbox", false) || len(widget.GithubRepos) == 0
+
+	if len(widget.GithubRepos) > 0 {
+		widget.SetRateLimitHost(widget.GithubRepos[0].APIHost())
+	} else {
+		widget.SetRateLimitHost(widget.Inbox.APIHost())
+	}
+
+	widget.restoreRepo()
 
 	widget.HelpfulWidget.SetView(widget.View)
+	widget.View.SetRegions(true)
 	widget.View.SetInputCapture(widget.keyboardIntercept)
 
+	widget.Follow(widget.follow)
+
 	return &widget
 }
 
 /* -------------------- Exported Functions -------------------- */
 
+// Refresh reloads what's displayed: the inbox, or the repositories
 func (widget *Widget) Refresh() {
-	for _, repo := range widget.GithubRepos {
-		repo.Refresh()
+	if widget.inboxMode {
+		widget.Inbox.Refresh(wtf.Config.UString("wtf.mods.github.username"))
+	} else {
+		username := wtf.Config.UString("wtf.mods.github.username")
+
+		for _, repo := range widget.GithubRepos {
+			repo.Refresh()
+
+			if showStatus() {
+				repo.RefreshStatuses(username)
+			}
+		}
+
+		if hasConfiguredSections() {
+			refreshRepoSections(widget.GithubRepos)
+		}
 	}
 
 	widget.UpdateRefreshedAt()
+	widget.loadPullRequests()
 	widget.display()
 }
 
@@ -61,7 +137,9 @@ func (widget *Widget) Next() {
 		widget.Idx = 0
 	}
 
-	widget.display()
+	widget.saveRepo()
+	widget.loadPullRequests()
+	widget.unselect()
 }
 
 func (widget *Widget) Prev() {
@@ -70,9 +148,55 @@ func (widget *Widget) Prev() {
 		widget.Idx = len(widget.GithubRepos) - 1
 	}
 
+	widget.saveRepo()
+	widget.loadPullRequests()
+	widget.unselect()
+}
+
+// ActionData returns the fields of the selected pull request or, if none is
+// selected, of the current repo, for the module's actions. In the inbox, they're
+// the fields of the selected item
+func (widget *Widget) ActionData() wtf.ActionData {
+	if widget.inboxMode {
+		return widget.inboxActionData()
+	}
+
+	repo := widget.currentGithubRepo()
+	if repo == nil {
+		return nil
+	}
+
+	data := wtf.ActionData{
+		"Owner": repo.Owner,
+		"Repo":  repo.Name,
+		"URL":   widget.selectedURL(),
+	}
+
+	if pr := widget.selectedPullRequest(); pr != nil {
+		data["Branch"] = pr.GetHead().GetRef()
+		data["Number"] = pr.GetNumber()
+		data["Title"] = pr.GetTitle()
+	} else if item := widget.selectedSectionItem(); item.issue != nil {
+		data["Number"] = item.issue.GetNumber()
+		data["Title"] = item.title()
+	}
+
+	return data
+}
+
+// SelectItem selects the pull request, or the inbox item, at idx, when it's
+// clicked on
+func (widget *Widget) SelectItem(idx int) {
+	widget.selected = idx
 	widget.display()
 }
 
+// OpenItem shows the selected pull request's checks, or opens the selected inbox
+// item in a browser, when it's double-clicked
+func (widget *Widget) OpenItem() {
+	widget.openItem()
+}
+
 /* -------------------- Unexported Functions -------------------- */
 
 func (widget *Widget) buildRepoCollection(repoData map[string]interface{}) []*GithubRepo {
@@ -98,6 +222,32 @@ func (widget *Widget) currentGithubRepo() *GithubRepo {
 	return widget.GithubRepos[widget.Idx]
 }
 
+// follow switches to the repo selected in a followed widget, i.e. Git, if it's
+// one of the configured repos
+func (widget *Widget) follow(event wtf.Event) {
+	if event.Type != wtf.EventRepoSelected {
+		return
+	}
+
+	owner, _ := event.Data["Owner"].(string)
+	name, _ := event.Data["Repo"].(string)
+
+	for idx, repo := range widget.GithubRepos {
+		if !strings.EqualFold(repo.Name, name) || (owner != "" && !strings.EqualFold(repo.Owner, owner)) {
+			continue
+		}
+
+		if idx != widget.Idx {
+			widget.Idx = idx
+			widget.saveRepo()
+			widget.loadPullRequests()
+			widget.unselect()
+		}
+
+		return
+	}
+}
+
 func (widget *Widget) keyboardIntercept(event *tcell.EventKey) *tcell.EventKey {
 	switch string(event.Rune()) {
 	case "/":
@@ -106,33 +256,336 @@ func (widget *Widget) keyboardIntercept(event *tcell.EventKey) *tcell.EventKey {
 	case "h":
 		widget.Prev()
 		return nil
+	case "i":
+		widget.toggleInbox()
+		return nil
+	case "j":
+		widget.nextPullRequest()
+		return nil
+	case "k":
+		widget.prevPullRequest()
+		return nil
 	case "l":
 		widget.Next()
 		return nil
+	case "m":
+		widget.markRead()
+		return nil
 	case "r":
 		widget.Refresh()
 		return nil
+	case "s":
+		widget.toggleStats()
+		return nil
+	case "y":
+		widget.copyURL()
+		return nil
 	}
 
 	switch event.Key() {
+	case tcell.KeyDown:
+		widget.nextPullRequest()
+		return nil
 	case tcell.KeyEnter:
-		widget.openRepo()
+		widget.openItem()
 		return nil
+	case tcell.KeyEsc:
+		widget.unselect()
+		return event
 	case tcell.KeyLeft:
 		widget.Prev()
 		return nil
 	case tcell.KeyRight:
 		widget.Next()
 		return nil
+	case tcell.KeyUp:
+		widget.prevPullRequest()
+		return nil
 	default:
 		return event
 	}
 }
 
-func (widget *Widget) openRepo() {
+// loadPullRequests picks the current repo's pull requests that are displayed,
+// and can be selected, out of all of its pull requests
+func (widget *Widget) loadPullRequests() {
+	widget.myPullRequests = nil
+	widget.reviewRequests = nil
+
+	repo := widget.currentGithubRepo()
+	if repo == nil {
+		return
+	}
+
+	username := wtf.Config.UString("wtf.mods.github.username")
+
+	widget.reviewRequests = repo.myReviewRequests(username)
+	widget.myPullRequests = repo.myPullRequests(username)
+}
+
+func (widget *Widget) nextPullRequest() {
+	widget.selected++
+	if widget.selected >= widget.itemCount() {
+		widget.selected = 0
+	}
+
+	widget.display()
+}
+
+func (widget *Widget) prevPullRequest() {
+	widget.selected--
+	if widget.selected < 0 {
+		widget.selected = widget.itemCount() - 1
+	}
+
+	widget.display()
+}
+
+// itemCount returns the number of selectable items: in the inbox, its items, and
+// otherwise the results of the configured sections or, if there aren't any, the
+// review requests followed by the user's own pull requests
+func (widget *Widget) itemCount() int {
+	if widget.inboxMode {
+		return len(widget.inboxItems())
+	}
+
+	if hasConfiguredSections() {
+		return len(widget.repoSectionItems())
+	}
+
+	return widget.pullRequestCount()
+}
+
+func (widget *Widget) pullRequestCount() int {
+	return len(widget.reviewRequests) + len(widget.myPullRequests)
+}
+
+// selectedPullRequest returns the selected pull request, or nil if none is selected.
+// Amongst the configured sections' results, it's the selected item if that's one
+// of the repo's open pull requests
+func (widget *Widget) selectedPullRequest() *ghb.PullRequest {
+	if widget.inboxMode {
+		return nil
+	}
+
+	if hasConfiguredSections() {
+		item := widget.selectedSectionItem()
+		repo := widget.currentGithubRepo()
+
+		if item.issue == nil || !item.issue.IsPullRequest() || repo == nil {
+			return nil
+		}
+
+		return repo.pullRequest(item.issue.GetNumber())
+	}
+
+	sel := widget.selected
+
+	switch {
+	case sel >= 0 && sel < len(widget.reviewRequests):
+		return widget.reviewRequests[sel]
+	case sel >= len(widget.reviewRequests) && sel < widget.pullRequestCount():
+		return widget.myPullRequests[sel-len(widget.reviewRequests)]
+	}
+
+	return nil
+}
+
+// selectedURL returns the URL of the selected pull request or inbox item or, if
+// none is selected, of the current repo
+func (widget *Widget) selectedURL() string {
+	if widget.inboxMode {
+		return widget.selectedInboxItem().url()
+	}
+
+	if pr := widget.selectedPullRequest(); pr != nil {
+		return pr.GetHTMLURL()
+	}
+
+	if item := widget.selectedSectionItem(); item.issue != nil {
+		return item.url()
+	}
+
+	repo := widget.currentGithubRepo()
+	if repo == nil || repo.RemoteRepo == nil {
+		return ""
+	}
+
+	return repo.RemoteRepo.GetHTMLURL()
+}
+
+// inboxActionData returns the fields of the selected inbox item, for the module's
+// actions
+func (widget *Widget) inboxActionData() wtf.ActionData {
+	item := widget.selectedInboxItem()
+	owner, name := item.repo()
+
+	data := wtf.ActionData{
+		"Owner": owner,
+		"Repo":  name,
+		"Title": item.title(),
+		"URL":   item.url(),
+	}
+
+	if item.issue != nil {
+		data["Number"] = item.issue.GetNumber()
+	}
+
+	return data
+}
+
+// inboxItems returns the inbox's rows: the notifications, followed by the results
+// of each section's search
+func (widget *Widget) inboxItems() []inboxItem {
+	items := []inboxItem{}
+
+	for _, notification := range widget.Inbox.Notifications {
+		items = append(items, inboxItem{notification: notification})
+	}
+
+	return append(items, sectionItems(widget.Inbox.Sections)...)
+}
+
+// repoSectionItems returns the results of the configured sections' searches in
+// the current repo
+func (widget *Widget) repoSectionItems() []inboxItem {
+	repo := widget.currentGithubRepo()
+	if repo == nil || !hasConfiguredSections() {
+		return []inboxItem{}
+	}
+
+	return sectionItems(repo.Sections)
+}
+
+// selectedSectionItem returns the selected result of the configured sections in
+// the current repo, which is empty if none is selected
+func (widget *Widget) selectedSectionItem() inboxItem {
+	if widget.inboxMode {
+		return inboxItem{}
+	}
+
+	items := widget.repoSectionItems()
+	if widget.selected < 0 || widget.selected >= len(items) {
+		return inboxItem{}
+	}
+
+	return items[widget.selected]
+}
+
+// sectionItems returns the results of each of the sections' searches
+func sectionItems(sections []*SearchSection) []inboxItem {
+	items := []inboxItem{}
+
+	for _, section := range sections {
+		for idx := range section.Issues {
+			items = append(items, inboxItem{issue: &section.Issues[idx]})
+		}
+	}
+
+	return items
+}
+
+// markRead marks the selected notification as read in the background, and takes
+// it out of the inbox once GitHub has
+func (widget *Widget) markRead() {
+	notification := widget.selectedInboxItem().notification
+	if notification == nil {
+		return
+	}
+
+	go func() {
+		err := widget.Inbox.MarkRead(notification)
+
+		widget.app.QueueUpdateDraw(func() {
+			if err != nil {
+				widget.ShowNotice("[red]" + tview.Escape(err.Error()))
+				return
+			}
+
+			widget.Inbox.Remove(notification)
+
+			if widget.selected >= widget.itemCount() {
+				widget.selected = widget.itemCount() - 1
+			}
+
+			widget.display()
+		})
+	}()
+}
+
+// selectedInboxItem returns the selected inbox item, which is empty if none is
+// selected
+func (widget *Widget) selectedInboxItem() inboxItem {
+	items := widget.inboxItems()
+	if widget.selected < 0 || widget.selected >= len(items) {
+		return inboxItem{}
+	}
+
+	return items[widget.selected]
+}
+
+// toggleInbox switches between the inbox and the repositories, and loads the one
+// switched to
+func (widget *Widget) toggleInbox() {
+	widget.inboxMode = !widget.inboxMode
+	widget.unselect()
+
+	go wtf.RefreshWidget(widget)
+}
+
+// toggleStats collapses the repository's stats, or expands them, which is
+// remembered across restarts
+func (widget *Widget) toggleStats() {
+	collapsed := wtf.State.IsCollapsed(widget.ConfigKey(), statsSection)
+	wtf.State.SetCollapsed(widget.ConfigKey(), statsSection, !collapsed)
+
+	widget.display()
+}
+
+func (widget *Widget) unselect() {
+	widget.selected = -1
+	widget.display()
+}
+
+// restoreRepo displays the repo that was displayed when the app last ran
+func (widget *Widget) restoreRepo() {
+	source := wtf.State.Get(widget.ConfigKey()).Source
+
+	for idx, repo := range widget.GithubRepos {
+		if repo.FullName() == source {
+			widget.Idx = idx
+			return
+		}
+	}
+}
+
+func (widget *Widget) saveRepo() {
 	repo := widget.currentGithubRepo()
 
 	if repo != nil {
-		repo.Open()
+		wtf.State.SetSource(widget.ConfigKey(), repo.FullName())
+	}
+}
+
+func (widget *Widget) copyURL() {
+	widget.Copy(widget.selectedURL())
+}
+
+// openItem shows the selected pull request's reviews and checks. Anything else
+// that's selected, or the repository if nothing is, is opened in a browser
+func (widget *Widget) openItem() {
+	repo := widget.currentGithubRepo()
+
+	if pr := widget.selectedPullRequest(); pr != nil && repo != nil {
+		newPRPage(widget, repo, pr).show()
+		return
+	}
+
+	widget.openURL()
+}
+
+func (widget *Widget) openURL() {
+	if url := widget.selectedURL(); url != "" {
+		wtf.OpenFile(url)
 	}
 }
@@ -0,0 +1,91 @@
+package github_tests
+
+import (
+	"testing"
+
+	ghb "github.com/google/go-github/github"
+	"github.com/olebedev/config"
+	. "github.com/senorprogrammer/wtf/github"
+	"github.com/senorprogrammer/wtf/wtf"
+	. "github.com/stretchr/testify/assert"
+)
+
+const sectionsConfig = `
+wtf:
+  mods:
+    github:
+      sections:
+      - title: "Triage"
+        query: "is:open label:triage"
+        count: 5
+      - query: "is:open is:pr draft:true"
+      - title: "No query"
+      - "not a section"
+      - title: "Zero count"
+        query: "is:open label:p1"
+        count: 0
+`
+
+/* -------------------- ConfiguredSections() -------------------- */
+
+func TestConfiguredSections(t *testing.T) {
+	wtf.Config, _ = config.ParseYaml(sectionsConfig)
+
+	sections := ConfiguredSections(10)
+
+	Equal(t, 3, len(sections))
+
+	Equal(t, "Triage", sections[0].Title)
+	Equal(t, "is:open label:triage", sections[0].Query)
+	Equal(t, 5, sections[0].Count)
+
+	Equal(t, "is:open is:pr draft:true", sections[1].Title)
+	Equal(t, "is:open is:pr draft:true", sections[1].Query)
+	Equal(t, 10, sections[1].Count)
+
+	Equal(t, "Zero count", sections[2].Title)
+	Equal(t, 10, sections[2].Count)
+}
+
+func TestConfiguredSectionsNone(t *testing.T) {
+	wtf.Config, _ = config.ParseYaml("wtf:\n  mods:\n    github:\n      enabled: true\n")
+
+	Equal(t, []*SearchSection{}, ConfiguredSections(10))
+}
+
+/* -------------------- NotificationURL() -------------------- */
+
+func notification(repoURL, apiURL string) *ghb.Notification {
+	return &ghb.Notification{
+		Repository: &ghb.Repository{HTMLURL: &repoURL},
+		Subject:    &ghb.NotificationSubject{URL: &apiURL},
+	}
+}
+
+func TestNotificationURL(t *testing.T) {
+	repoURL := "https://github.com/senorprogrammer/wtf"
+	apiURL := "https://api.github.com/repos/senorprogrammer/wtf"
+
+	Equal(t, repoURL+"/pull/12", NotificationURL(notification(repoURL, apiURL+"/pulls/12")))
+	Equal(t, repoURL+"/issues/34", NotificationURL(notification(repoURL, apiURL+"/issues/34")))
+	Equal(t, repoURL+"/commit/1e65a3e", NotificationURL(notification(repoURL, apiURL+"/commits/1e65a3e")))
+
+	// A release's page is found by its tag, which the API URL doesn't have
+	Equal(t, repoURL+"/releases", NotificationURL(notification(repoURL, apiURL+"/releases/5678")))
+}
+
+func TestNotificationURLEnterprise(t *testing.T) {
+	Equal(t,
+		"https://github.example.com/team/app/pull/7",
+		NotificationURL(notification("https://github.example.com/team/app", "https://github.example.com/api/v3/repos/team/app/pulls/7")),
+	)
+}
+
+func TestNotificationURLUnknown(t *testing.T) {
+	repoURL := "https://github.com/senorprogrammer/wtf"
+
+	Equal(t, repoURL, NotificationURL(notification(repoURL, "https://api.github.com/repos/senorprogrammer/wtf/discussions/9")))
+	Equal(t, repoURL, NotificationURL(notification(repoURL, "")))
+	Equal(t, repoURL, NotificationURL(notification(repoURL, "://api.github.com/repos")))
+	Equal(t, "", NotificationURL(notification("", "https://api.github.com/repos/senorprogrammer/wtf/pulls/12")))
+}
@@ -0,0 +1,84 @@
+package github_tests
+
+import (
+	"testing"
+
+	ghb "github.com/google/go-github/github"
+	. "github.com/senorprogrammer/wtf/github"
+	. "github.com/stretchr/testify/assert"
+)
+
+func checkRun(status, conclusion string) *ghb.CheckRun {
+	return &ghb.CheckRun{Status: &status, Conclusion: &conclusion}
+}
+
+func statusWithChecks(states ...string) *PRStatus {
+	status := &PRStatus{Checks: []Check{}, Reviews: map[string]string{}}
+	for _, state := range states {
+		status.Checks = append(status.Checks, Check{State: state})
+	}
+
+	return status
+}
+
+/* -------------------- PRStatus.State() -------------------- */
+
+func TestPRStatusState(t *testing.T) {
+	Equal(t, "", statusWithChecks().State())
+	Equal(t, "passing", statusWithChecks("passing").State())
+	Equal(t, "passing", statusWithChecks("passing", "passing").State())
+	Equal(t, "pending", statusWithChecks("passing", "pending").State())
+	Equal(t, "failing", statusWithChecks("pending", "failing", "passing").State())
+	Equal(t, "failing", statusWithChecks("failing", "pending").State())
+}
+
+/* -------------------- PRStatus.ReviewDecision() -------------------- */
+
+func TestPRStatusReviewDecision(t *testing.T) {
+	status := statusWithChecks()
+	Equal(t, "", status.ReviewDecision())
+
+	status.Reviews["anna"] = "approved"
+	Equal(t, "approved", status.ReviewDecision())
+
+	status.Reviews["bob"] = "approved"
+	Equal(t, "approved", status.ReviewDecision())
+
+	status.Reviews["chris"] = "changes requested"
+	Equal(t, "changes requested", status.ReviewDecision())
+}
+
+/* -------------------- PRStatus.Reviewers() -------------------- */
+
+func TestPRStatusReviewers(t *testing.T) {
+	status := statusWithChecks()
+	Equal(t, []string{}, status.Reviewers())
+
+	status.Reviews["chris"] = "approved"
+	status.Reviews["anna"] = "changes requested"
+	Equal(t, []string{"anna", "chris"}, status.Reviewers())
+}
+
+/* -------------------- StatusState() -------------------- */
+
+func TestStatusState(t *testing.T) {
+	Equal(t, "passing", StatusState("success"))
+	Equal(t, "pending", StatusState("pending"))
+	Equal(t, "failing", StatusState("failure"))
+	Equal(t, "failing", StatusState("error"))
+	Equal(t, "failing", StatusState(""))
+}
+
+/* -------------------- CheckRunState() -------------------- */
+
+func TestCheckRunState(t *testing.T) {
+	Equal(t, "pending", CheckRunState(checkRun("queued", "")))
+	Equal(t, "pending", CheckRunState(checkRun("in_progress", "")))
+	Equal(t, "passing", CheckRunState(checkRun("completed", "success")))
+	Equal(t, "passing", CheckRunState(checkRun("completed", "neutral")))
+	Equal(t, "passing", CheckRunState(checkRun("completed", "skipped")))
+	Equal(t, "failing", CheckRunState(checkRun("completed", "failure")))
+	Equal(t, "failing", CheckRunState(checkRun("completed", "timed_out")))
+	Equal(t, "failing", CheckRunState(checkRun("completed", "cancelled")))
+	Equal(t, "failing", CheckRunState(checkRun("completed", "action_required")))
+}
@@ -2,15 +2,21 @@ package gitlab
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 
+	"github.com/rivo/tview"
 	"github.com/senorprogrammer/wtf/wtf"
 )
 
+// sectionTitles are the titles of the sections a project's items are displayed in
+var sectionTitles = []string{"Pipelines", "Open Approval Requests", "My Merge Requests", "Assigned Issues"}
+
 func (widget *Widget) display() {
 
 	project := widget.currentGitlabProject()
 	if project == nil {
-		widget.View.SetText(" Gitlab project data is unavailable ")
+		widget.SetText(" Gitlab project data is unavailable ")
 		return
 	}
 
@@ -19,44 +25,57 @@ func (widget *Widget) display() {
 	str := wtf.SigilStr(len(widget.GitlabProjects), widget.Idx, widget.View) + "\n"
 	str = str + " [red]Stats[white]\n"
 	str = str + widget.displayStats(project)
-	str = str + "\n"
-	str = str + " [red]Open Approval Requests[white]\n"
-	str = str + widget.displayMyApprovalRequests(project, wtf.Config.UString("wtf.mods.gitlab.username"))
-	str = str + "\n"
-	str = str + " [red]My Merge Requests[white]\n"
-	str = str + widget.displayMyMergeRequests(project, wtf.Config.UString("wtf.mods.gitlab.username"))
-
-	widget.View.SetText(str)
-}
 
-func (widget *Widget) displayMyMergeRequests(project *GitlabProject, username string) string {
-	mrs := project.myMergeRequests(username)
+	row := 0
+	for idx, items := range project.itemSections() {
+		str = str + "\n"
+		str = str + fmt.Sprintf(" [red]%s[white]\n", sectionTitles[idx])
+		str = str + widget.displayItems(project, items, row)
+		row = row + len(items)
+	}
+
+	widget.View.Highlight(strconv.Itoa(widget.selected)).ScrollToHighlight()
+	widget.SetText(str)
+}
 
-	if len(mrs) == 0 {
+// displayItems lists the items, which are numbered from firstRow
+func (widget *Widget) displayItems(project *GitlabProject, items []projectItem, firstRow int) string {
+	if len(items) == 0 {
 		return " [grey]none[white]\n"
 	}
 
 	str := ""
-	for _, mr := range mrs {
-		str = str + fmt.Sprintf(" [green]%4d[white] %s\n", mr.IID, mr.Title)
+	for idx, item := range items {
+		str = str + widget.displayItem(project, item, firstRow+idx)
 	}
 
 	return str
 }
 
-func (widget *Widget) displayMyApprovalRequests(project *GitlabProject, username string) string {
-	mrs := project.myApprovalRequests(username)
-
-	if len(mrs) == 0 {
-		return " [grey]none[white]\n"
+// displayItem formats the item's line. A branch's pipeline is shown with its
+// status, and a merge request with the status of each stage of its pipeline
+func (widget *Widget) displayItem(project *GitlabProject, item projectItem, row int) string {
+	str := fmt.Sprintf(`["%d"][""] [%s]%s`, row, widget.rowColor(row), widget.selectionIndicator(row))
+
+	switch {
+	case item.pipeline != nil:
+		str = str + fmt.Sprintf(
+			"%s[%s]%s [grey]#%d",
+			statusIcon(item.pipeline.Status),
+			widget.rowColor(row),
+			tview.Escape(item.pipeline.Ref),
+			item.pipeline.ID,
+		)
+	case item.mr != nil:
+		str = str + fmt.Sprintf("[green]%4d[%s] %s", item.mr.IID, widget.rowColor(row), tview.Escape(item.mr.Title))
+		if mrPipeline := project.mrPipeline(item.mr); mrPipeline != nil {
+			str = str + " " + stagesString(mrPipeline.stages)
+		}
+	case item.issue != nil:
+		str = str + fmt.Sprintf("[green]%4d[%s] %s", item.issue.IID, widget.rowColor(row), tview.Escape(item.issue.Title))
 	}
 
-	str := ""
-	for _, mr := range mrs {
-		str = str + fmt.Sprintf(" [green]%4d[white] %s\n", mr.IID, mr.Title)
-	}
-
-	return str
+	return str + fmt.Sprintf("[%s]\n", wtf.DefaultRowColor())
 }
 
 func (widget *Widget) displayStats(project *GitlabProject) string {
@@ -70,6 +89,63 @@ func (widget *Widget) displayStats(project *GitlabProject) string {
 	return str
 }
 
+func (widget *Widget) rowColor(index int) string {
+	if widget.View.HasFocus() && (index == widget.selected) {
+		return wtf.DefaultFocussedRowColor()
+	}
+
+	return wtf.DefaultRowColor()
+}
+
+func (widget *Widget) selectionIndicator(index int) string {
+	return wtf.SelectionIndicator(widget.View.HasFocus() && (index == widget.selected))
+}
+
 func (widget *Widget) title(project *GitlabProject) string {
 	return fmt.Sprintf("[green]%s [white]", project.Path)
 }
+
+var statusIcons = map[string]string{
+	"canceled": "[grey]⊘[white] ",
+	"created":  "[grey]●[white] ",
+	"failed":   "[red]✖[white] ",
+	"manual":   "[grey]▶[white] ",
+	"pending":  "[yellow]●[white] ",
+	"running":  "[blue]●[white] ",
+	"skipped":  "[grey]»[white] ",
+	"success":  "[green]✔[white] ",
+}
+
+var asciiStatusIcons = map[string]string{
+	"canceled": "[grey][CANCELED[][white] ",
+	"created":  "[grey][CREATED[][white] ",
+	"failed":   "[red][FAIL[][white] ",
+	"manual":   "[grey][MANUAL[][white] ",
+	"pending":  "[yellow][PENDING[][white] ",
+	"running":  "[blue][RUNNING[][white] ",
+	"skipped":  "[grey][SKIPPED[][white] ",
+	"success":  "[green][OK[][white] ",
+}
+
+// statusIcon marks the status of a pipeline, a stage or a job
+func statusIcon(status string) string {
+	icons := statusIcons
+	if wtf.Accessible() {
+		icons = asciiStatusIcons
+	}
+
+	if str, ok := icons[status]; ok {
+		return str
+	}
+	return "? "
+}
+
+// stagesString lists the stages of a pipeline, each marked with its status
+func stagesString(stages []Stage) string {
+	strs := []string{}
+	for _, stage := range stages {
+		strs = append(strs, strings.TrimSpace(statusIcon(stage.Status))+tview.Escape(stage.Name))
+	}
+
+	return strings.Join(strs, " ")
+}
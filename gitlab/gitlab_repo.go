@@ -1,6 +1,7 @@
 package gitlab
 
 import (
+	"github.com/senorprogrammer/wtf/wtf"
 	glb "github.com/xanzy/go-gitlab"
 )
 
@@ -8,8 +9,14 @@ type GitlabProject struct {
 	gitlab *glb.Client
 	Path   string
 
-	MergeRequests []*glb.MergeRequest
-	RemoteProject *glb.Project
+	ApprovalRequests []*glb.MergeRequest
+	Issues           []*glb.Issue
+	MergeRequests    []*glb.MergeRequest
+	MyMergeRequests  []*glb.MergeRequest
+	Pipelines        []*glb.Pipeline
+	RemoteProject    *glb.Project
+
+	mrPipelines map[int]*mrPipeline
 }
 
 func NewGitlabProject(name string, namespace string, gitlab *glb.Client) *GitlabProject {
@@ -22,10 +29,17 @@ func NewGitlabProject(name string, namespace string, gitlab *glb.Client) *Gitlab
 	return &project
 }
 
-// Refresh reloads the gitlab data via the Gitlab API
-func (project *GitlabProject) Refresh() {
+// Refresh reloads the gitlab data via the Gitlab API. username is the user's
+// GitLab username, whose merge requests and approval requests are picked out
+func (project *GitlabProject) Refresh(username string) {
 	project.MergeRequests, _ = project.loadMergeRequests()
 	project.RemoteProject, _ = project.loadRemoteProject()
+	project.Issues, _ = project.loadAssignedIssues()
+	project.Pipelines, _ = project.latestPipelines(wtf.Config.UInt("wtf.mods.gitlab.pipelineCount", 5))
+
+	project.ApprovalRequests = project.myApprovalRequests(username)
+	project.MyMergeRequests = project.myMergeRequests(username)
+	project.mrPipelines = project.loadMRPipelines(project.MyMergeRequests)
 }
 
 /* -------------------- Counts -------------------- */
@@ -87,6 +101,30 @@ func (project *GitlabProject) myApprovalRequests(username string) []*glb.MergeRe
 	return mrs
 }
 
+// mrPipeline returns the latest pipeline of the merge request, or nil if it has
+// none or it hasn't been loaded
+func (project *GitlabProject) mrPipeline(mr *glb.MergeRequest) *mrPipeline {
+	return project.mrPipelines[mr.IID]
+}
+
+// loadAssignedIssues loads the project's open issues that are assigned to the user
+// the API key belongs to
+func (project *GitlabProject) loadAssignedIssues() ([]*glb.Issue, error) {
+	scope := "assigned_to_me"
+	state := "opened"
+	opts := glb.ListProjectIssuesOptions{
+		Scope: &scope,
+		State: &state,
+	}
+
+	issues, _, err := project.gitlab.Issues.ListProjectIssues(project.Path, &opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return issues, nil
+}
+
 func (project *GitlabProject) loadMergeRequests() ([]*glb.MergeRequest, error) {
 	state := "opened"
 	opts := glb.ListProjectMergeRequestsOptions{
@@ -111,3 +149,80 @@ func (project *GitlabProject) loadRemoteProject() (*glb.Project, error) {
 
 	return projectsitory, nil
 }
+
+/* -------------------- Project Items -------------------- */
+
+// projectItem is a selectable row of a project: the latest pipeline of a branch,
+// a merge request, or an issue
+type projectItem struct {
+	issue    *glb.Issue
+	mr       *glb.MergeRequest
+	pipeline *glb.Pipeline
+}
+
+// itemSections returns the project's rows, grouped by the sections they're
+// displayed in: the branches' pipelines, the approval requests, the user's merge
+// requests and the issues assigned to them
+func (project *GitlabProject) itemSections() [][]projectItem {
+	pipelines := []projectItem{}
+	for _, pipeline := range project.Pipelines {
+		pipelines = append(pipelines, projectItem{pipeline: pipeline})
+	}
+
+	approvalRequests := []projectItem{}
+	for _, mr := range project.ApprovalRequests {
+		approvalRequests = append(approvalRequests, projectItem{mr: mr})
+	}
+
+	myMergeRequests := []projectItem{}
+	for _, mr := range project.MyMergeRequests {
+		myMergeRequests = append(myMergeRequests, projectItem{mr: mr})
+	}
+
+	issues := []projectItem{}
+	for _, issue := range project.Issues {
+		issues = append(issues, projectItem{issue: issue})
+	}
+
+	return [][]projectItem{pipelines, approvalRequests, myMergeRequests, issues}
+}
+
+// items returns the project's rows, in the order they're displayed in
+func (project *GitlabProject) items() []projectItem {
+	items := []projectItem{}
+	for _, section := range project.itemSections() {
+		items = append(items, section...)
+	}
+
+	return items
+}
+
+// itemPipeline returns the pipeline of the item: the pipeline itself, or the latest
+// pipeline of a merge request. It's nil for issues
+func (project *GitlabProject) itemPipeline(item projectItem) *glb.Pipeline {
+	switch {
+	case item.pipeline != nil:
+		return item.pipeline
+	case item.mr != nil:
+		if mrPipeline := project.mrPipeline(item.mr); mrPipeline != nil {
+			return mrPipeline.pipeline
+		}
+	}
+
+	return nil
+}
+
+// itemURL returns the web page of the item or, if no item is selected, of the
+// project
+func (project *GitlabProject) itemURL(item projectItem) string {
+	switch {
+	case item.pipeline != nil:
+		return project.pipelineURL(item.pipeline.ID)
+	case item.mr != nil:
+		return item.mr.WebURL
+	case item.issue != nil:
+		return item.issue.WebURL
+	}
+
+	return project.pageURL("")
+}
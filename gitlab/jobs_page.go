@@ -0,0 +1,267 @@
+package gitlab
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/gdamore/tcell"
+	"github.com/rivo/tview"
+	"github.com/senorprogrammer/wtf/wtf"
+	glb "github.com/xanzy/go-gitlab"
+)
+
+// jobsPageName is the name of the page a pipeline's jobs are displayed on
+const jobsPageName = "pipelineJobs"
+
+const jobsPageHelp = "j/k: select a job   return: open the job   o: open the pipeline   R: retry   esc: close"
+
+// jobsPage displays a pipeline's jobs, stage by stage, on a page of its own. The
+// jobs can be selected, and opened in a browser, and the pipeline retried
+type jobsPage struct {
+	jobs     []*glb.Job
+	loading  bool
+	pipeline *glb.Pipeline
+	project  *GitlabProject
+	selected int
+	widget   *Widget
+
+	frame *tview.Frame
+	view  *tview.TextView
+}
+
+func newJobsPage(widget *Widget, project *GitlabProject, pipeline *glb.Pipeline) *jobsPage {
+	page := jobsPage{
+		pipeline: pipeline,
+		project:  project,
+		selected: -1,
+		widget:   widget,
+	}
+
+	page.view = tview.NewTextView()
+	page.view.SetDynamicColors(true)
+	page.view.SetRegions(true)
+	page.view.SetScrollable(true)
+	page.view.SetInputCapture(page.keyboardIntercept)
+
+	page.frame = tview.NewFrame(page.view)
+	page.frame.SetBorder(true)
+	page.frame.SetBorders(0, 0, 0, 1, 1, 1)
+	page.frame.SetTitle(fmt.Sprintf(" %s pipeline #%d ", tview.Escape(project.Path), pipeline.ID))
+
+	return &page
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+func (page *jobsPage) close() {
+	page.widget.pages.RemovePage(jobsPageName)
+	page.widget.app.SetFocus(page.widget.View)
+	page.widget.display()
+}
+
+func (page *jobsPage) keyboardIntercept(event *tcell.EventKey) *tcell.EventKey {
+	switch string(event.Rune()) {
+	case "j":
+		page.selectJob(1)
+		return nil
+	case "k":
+		page.selectJob(-1)
+		return nil
+	case "o":
+		wtf.OpenFile(page.project.pipelineURL(page.pipeline.ID))
+		return nil
+	case "q":
+		page.close()
+		return nil
+	case "R":
+		page.retry()
+		return nil
+	}
+
+	switch event.Key() {
+	case tcell.KeyDown:
+		page.selectJob(1)
+		return nil
+	case tcell.KeyEnter:
+		page.open()
+		return nil
+	case tcell.KeyEsc:
+		page.close()
+		return nil
+	case tcell.KeyUp:
+		page.selectJob(-1)
+		return nil
+	default:
+		return event
+	}
+}
+
+// loadJobs loads the pipeline's jobs afresh, ordered by the stages they run in.
+// It doesn't change the page, so it can be called off the app's event loop
+func (page *jobsPage) loadJobs(pipelineID int) ([]*glb.Job, error) {
+	jobs, err := page.project.pipelineJobs(pipelineID)
+	if err != nil {
+		return nil, err
+	}
+
+	stageOrder := map[string]int{}
+	for idx, stage := range PipelineStages(jobs) {
+		stageOrder[stage.Name] = idx
+	}
+
+	sort.SliceStable(jobs, func(i, j int) bool {
+		if stageOrder[jobs[i].Stage] != stageOrder[jobs[j].Stage] {
+			return stageOrder[jobs[i].Stage] < stageOrder[jobs[j].Stage]
+		}
+		return jobs[i].ID < jobs[j].ID
+	})
+
+	return jobs, nil
+}
+
+// open opens the selected job's page or, if no job is selected, the pipeline's
+func (page *jobsPage) open() {
+	if page.selected >= 0 && page.selected < len(page.jobs) {
+		wtf.OpenFile(page.project.jobURL(page.jobs[page.selected].ID))
+		return
+	}
+
+	wtf.OpenFile(page.project.pipelineURL(page.pipeline.ID))
+}
+
+// render lists the jobs, with the error, if any, or the result of retrying the
+// pipeline below them
+func (page *jobsPage) render(err error, notice string) {
+	page.frame.Clear()
+
+	switch {
+	case err != nil:
+		page.frame.AddText(tview.Escape(err.Error()), false, tview.AlignCenter, tcell.ColorRed)
+	case notice != "":
+		page.frame.AddText(tview.Escape(notice), false, tview.AlignCenter, tcell.ColorGreen)
+	default:
+		page.frame.AddText(jobsPageHelp, false, tview.AlignCenter, tcell.ColorGrey)
+	}
+
+	pipeline := page.pipeline
+
+	str := fmt.Sprintf(
+		" %s[white]%s [grey]#%d %s[white]\n",
+		statusIcon(pipeline.Status),
+		tview.Escape(pipeline.Ref),
+		pipeline.ID,
+		tview.Escape(shortSha(pipeline.Sha)),
+	)
+
+	switch {
+	case page.loading:
+		str = str + "\n [grey]Loading jobs...[white]\n"
+	case len(page.jobs) == 0:
+		str = str + "\n [grey]no jobs[white]\n"
+	}
+
+	stageName := ""
+	for idx, job := range page.jobs {
+		if idx == 0 || job.Stage != stageName {
+			stageName = job.Stage
+			str = str + fmt.Sprintf("\n [red]%s[white]\n", tview.Escape(stageName))
+		}
+
+		selected := idx == page.selected
+
+		rowColor := wtf.DefaultRowColor()
+		if selected {
+			rowColor = wtf.DefaultFocussedRowColor()
+		}
+
+		str = str + fmt.Sprintf(
+			`["%d"][""] [%s]%s%s[%s]%s [grey]%s[%s]`+"\n",
+			idx,
+			rowColor,
+			wtf.SelectionIndicator(selected),
+			statusIcon(job.Status),
+			rowColor,
+			tview.Escape(job.Name),
+			job.Status,
+			wtf.DefaultRowColor(),
+		)
+	}
+
+	page.view.Highlight(strconv.Itoa(page.selected)).ScrollToHighlight()
+	page.view.SetText(str)
+}
+
+// retry retries the pipeline's failed jobs in the background, and lists its jobs
+// again to show them running
+func (page *jobsPage) retry() {
+	current := page.pipeline
+
+	go func() {
+		pipeline, err := page.widget.retryPipeline(page.project, current)
+		if err != nil {
+			page.widget.app.QueueUpdateDraw(func() {
+				page.render(err, "")
+			})
+			return
+		}
+
+		jobs, err := page.loadJobs(pipeline.ID)
+
+		page.widget.app.QueueUpdateDraw(func() {
+			page.pipeline = pipeline
+			page.jobs = jobs
+			page.render(err, fmt.Sprintf("Retrying pipeline #%d", pipeline.ID))
+		})
+
+		go wtf.RefreshWidget(page.widget)
+	}()
+}
+
+func (page *jobsPage) selectJob(step int) {
+	if len(page.jobs) == 0 {
+		return
+	}
+
+	count := len(page.jobs)
+
+	switch {
+	case page.selected < 0 && step < 0:
+		page.selected = count - 1
+	case page.selected < 0:
+		page.selected = 0
+	default:
+		page.selected = ((page.selected+step)%count + count) % count
+	}
+
+	page.render(nil, "")
+}
+
+// show displays the page, and loads the pipeline's jobs afresh in the background,
+// displaying them once they've loaded
+func (page *jobsPage) show() {
+	page.loading = true
+	page.render(nil, "")
+
+	page.widget.pages.AddPage(jobsPageName, page.frame, true, true)
+	page.widget.app.SetFocus(page.view)
+
+	go func() {
+		jobs, err := page.loadJobs(page.pipeline.ID)
+
+		page.widget.app.QueueUpdateDraw(func() {
+			page.loading = false
+			page.jobs = jobs
+			page.render(err, "")
+		})
+	}()
+}
+
+// shortSha returns the abbreviated form of a commit's SHA
+func shortSha(sha string) string {
+	if len(sha) > 8 {
+		return sha[:8]
+	}
+
+	return sha
+}
@@ -0,0 +1,217 @@
+package gitlab
+
+import (
+	"fmt"
+	"sort"
+
+	glb "github.com/xanzy/go-gitlab"
+)
+
+// statusPriority orders the statuses of jobs by how much they say about a stage.
+// A stage takes the status of its job that comes first: it's failed if any job
+// failed, running if any is still running, and so on
+var statusPriority = []string{
+	"failed",
+	"running",
+	"pending",
+	"created",
+	"canceled",
+	"manual",
+	"success",
+	"skipped",
+}
+
+// Stage is one of the stages of a pipeline, i.e.: build, test or deploy
+type Stage struct {
+	Name   string
+	Status string
+}
+
+// mrPipeline is the latest pipeline run for a merge request, and its stages
+type mrPipeline struct {
+	pipeline *glb.Pipeline
+	stages   []Stage
+}
+
+// PipelineStages groups the jobs by their stages, in the order the stages run in,
+// and works out the status of each stage from the statuses of its jobs. A job
+// that's been retried is listed again, and only its newest run counts
+func PipelineStages(jobs []*glb.Job) []Stage {
+	sorted := make([]*glb.Job, len(jobs))
+	copy(sorted, jobs)
+
+	// Jobs are listed newest first, but the jobs of earlier stages are created first
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].ID < sorted[j].ID
+	})
+
+	stages := []Stage{}
+	seen := map[string]bool{}
+	latest := map[string]*glb.Job{}
+
+	for _, job := range sorted {
+		if !seen[job.Stage] {
+			seen[job.Stage] = true
+			stages = append(stages, Stage{Name: job.Stage})
+		}
+
+		latest[job.Name] = job
+	}
+
+	statuses := map[string][]string{}
+	for _, job := range sorted {
+		if latest[job.Name] == job {
+			statuses[job.Stage] = append(statuses[job.Stage], job.Status)
+		}
+	}
+
+	for idx := range stages {
+		stages[idx].Status = CombinedStatus(statuses[stages[idx].Name])
+	}
+
+	return stages
+}
+
+// CombinedStatus returns the status, out of the statuses of a stage's jobs, that
+// comes first in statusPriority. Statuses GitLab adds later don't have a
+// priority, and are only returned if there's no status that does
+func CombinedStatus(statuses []string) string {
+	for _, status := range statusPriority {
+		for _, other := range statuses {
+			if other == status {
+				return status
+			}
+		}
+	}
+
+	if len(statuses) > 0 {
+		return statuses[0]
+	}
+
+	return ""
+}
+
+// retryable returns true if the pipeline has jobs that failed, or were canceled,
+// which retrying it runs again
+func retryable(pipeline *glb.Pipeline) bool {
+	return pipeline.Status == "failed" || pipeline.Status == "canceled"
+}
+
+/* -------------------- Loading -------------------- */
+
+// latestPipelines returns the latest pipeline run on each branch, the most recently
+// run first, up to count of them. Branches whose latest pipeline isn't amongst the
+// project's 100 most recent ones are left out
+func (project *GitlabProject) latestPipelines(count int) ([]*glb.Pipeline, error) {
+	orderBy := "id"
+	sortOrder := "desc"
+	opts := glb.ListProjectPipelinesOptions{
+		ListOptions: glb.ListOptions{PerPage: 100},
+		OrderBy:     &orderBy,
+		Sort:        &sortOrder,
+	}
+
+	list, _, err := project.gitlab.Pipelines.ListProjectPipelines(project.Path, &opts)
+	if err != nil {
+		return nil, err
+	}
+
+	pipelines := []*glb.Pipeline{}
+	seen := map[string]bool{}
+
+	for _, item := range list {
+		if seen[item.Ref] {
+			continue
+		}
+		seen[item.Ref] = true
+
+		pipelines = append(pipelines, &glb.Pipeline{ID: item.ID, Ref: item.Ref, Sha: item.Sha, Status: item.Status})
+
+		if len(pipelines) >= count {
+			break
+		}
+	}
+
+	return pipelines, nil
+}
+
+// loadMRPipelines loads the latest pipeline of each of the merge requests, and its
+// stages, keyed on the merge requests' IIDs. Merge requests without a pipeline,
+// or whose pipeline can't be loaded, are left out
+func (project *GitlabProject) loadMRPipelines(mrs []*glb.MergeRequest) map[int]*mrPipeline {
+	mrPipelines := map[int]*mrPipeline{}
+
+	for _, mr := range mrs {
+		list, _, err := project.gitlab.MergeRequests.ListMergeRequestPipelines(project.Path, mr.IID)
+		if err != nil || len(list) == 0 {
+			continue
+		}
+
+		// The merge request's pipelines are listed newest first
+		latest := list[0]
+		pipeline := &glb.Pipeline{ID: latest.ID, Ref: latest.Ref, Sha: latest.Sha, Status: latest.Status}
+
+		jobs, err := project.pipelineJobs(pipeline.ID)
+		if err != nil {
+			continue
+		}
+
+		mrPipelines[mr.IID] = &mrPipeline{pipeline: pipeline, stages: PipelineStages(jobs)}
+	}
+
+	return mrPipelines
+}
+
+// pipelineJobs loads all the pipeline's jobs, a page at a time, as GitLab only
+// lists 20 of them unless asked for more
+func (project *GitlabProject) pipelineJobs(pipelineID int) ([]*glb.Job, error) {
+	opts := glb.ListJobsOptions{ListOptions: glb.ListOptions{PerPage: 100}}
+	jobs := []*glb.Job{}
+
+	for {
+		page, resp, err := project.gitlab.Jobs.ListPipelineJobs(project.Path, pipelineID, &opts)
+		if err != nil {
+			return nil, err
+		}
+
+		jobs = append(jobs, page...)
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	return jobs, nil
+}
+
+// retryPipeline runs the pipeline's failed and canceled jobs again, and returns
+// the pipeline as it is once they've been retried
+func (project *GitlabProject) retryPipeline(pipelineID int) (*glb.Pipeline, error) {
+	pipeline, _, err := project.gitlab.Pipelines.RetryPipelineBuild(project.Path, pipelineID)
+	if err != nil {
+		return nil, err
+	}
+
+	return pipeline, nil
+}
+
+/* -------------------- URLs -------------------- */
+
+func (project *GitlabProject) jobURL(jobID int) string {
+	return project.pageURL(fmt.Sprintf("/-/jobs/%d", jobID))
+}
+
+func (project *GitlabProject) pipelineURL(pipelineID int) string {
+	return project.pageURL(fmt.Sprintf("/pipelines/%d", pipelineID))
+}
+
+// pageURL returns the URL of one of the project's pages, which is empty before the
+// project's been loaded
+func (project *GitlabProject) pageURL(path string) string {
+	if project.RemoteProject == nil || project.RemoteProject.WebURL == "" {
+		return ""
+	}
+
+	return project.RemoteProject.WebURL + path
+}
@@ -1,6 +1,7 @@
 package gitlab
 
 import (
+	"fmt"
 	"os"
 
 	"github.com/gdamore/tcell"
@@ -14,26 +15,56 @@ const HelpText = `
 
     /: Show/hide this help window
     h: Previous project
+    j: Select the next item in the list
+    k: Select the previous item in the list
     l: Next project
+    o: Open the selected item, or the project, in a browser
     r: Refresh the data
+    R: Retry the failed jobs of the selected pipeline, or merge request
 
+    arrow down:  Select the next item in the list
     arrow left:  Previous project
     arrow right: Next project
+    arrow up:    Select the previous item in the list
+
+    return: Show the jobs of the selected pipeline, or of your selected merge
+            request, or open the selected item in a browser
+
+  In a pipeline's jobs:
+
+    j:      Select the next job
+    k:      Select the previous job
+    o:      Open the pipeline in a browser
+    R:      Retry the pipeline's failed jobs
+    return: Open the selected job, or the pipeline, in a browser
+    esc:    Close the jobs
+`
+
+const ConfigDefaults = `
+apiKey: ""
+domain: "https://gitlab.com"
+pipelineCount: 5
+projects: {}
+refreshInterval: 300
+username: ""
 `
 
 type Widget struct {
 	wtf.HelpfulWidget
 	wtf.TextWidget
 
-	gitlab *glb.Client
-
 	GitlabProjects []*GitlabProject
 	Idx            int
+
+	app      *tview.Application
+	gitlab   *glb.Client
+	pages    *tview.Pages
+	selected int
 }
 
 func NewWidget(app *tview.Application, pages *tview.Pages) *Widget {
 	baseURL := wtf.Config.UString("wtf.mods.gitlab.domain")
-	gitlab := glb.NewClient(nil, apiKey())
+	gitlab := glb.NewClient(wtf.NewHTTPClient("gitlab", nil), apiKey())
 
 	if baseURL != "" {
 		gitlab.SetBaseURL(baseURL)
@@ -43,14 +74,20 @@ func NewWidget(app *tview.Application, pages *tview.Pages) *Widget {
 		HelpfulWidget: wtf.NewHelpfulWidget(app, pages, HelpText),
 		TextWidget:    wtf.NewTextWidget(app, "Gitlab", "gitlab", true),
 
-		gitlab: gitlab,
-
-		Idx: 0,
+		Idx:      0,
+		app:      app,
+		gitlab:   gitlab,
+		pages:    pages,
+		selected: -1,
 	}
 
 	widget.GitlabProjects = widget.buildProjectCollection(wtf.Config.UMap("wtf.mods.gitlab.projects"))
 
+	widget.SetRateLimitHost(wtf.HostFor(baseURL, "gitlab.com"))
+	widget.restoreProject()
+
 	widget.HelpfulWidget.SetView(widget.View)
+	widget.View.SetRegions(true)
 	widget.View.SetInputCapture(widget.keyboardIntercept)
 
 	return &widget
@@ -59,8 +96,14 @@ func NewWidget(app *tview.Application, pages *tview.Pages) *Widget {
 /* -------------------- Exported Functions -------------------- */
 
 func (widget *Widget) Refresh() {
+	username := wtf.Config.UString("wtf.mods.gitlab.username")
+
 	for _, project := range widget.GitlabProjects {
-		project.Refresh()
+		project.Refresh(username)
+	}
+
+	if widget.selected >= widget.itemCount() {
+		widget.selected = widget.itemCount() - 1
 	}
 
 	widget.UpdateRefreshedAt()
@@ -73,7 +116,8 @@ func (widget *Widget) Next() {
 		widget.Idx = 0
 	}
 
-	widget.display()
+	widget.saveProject()
+	widget.unselect()
 }
 
 func (widget *Widget) Prev() {
@@ -82,9 +126,23 @@ func (widget *Widget) Prev() {
 		widget.Idx = len(widget.GitlabProjects) - 1
 	}
 
+	widget.saveProject()
+	widget.unselect()
+}
+
+// SelectItem selects the pipeline, merge request or issue at idx, when it's
+// clicked on
+func (widget *Widget) SelectItem(idx int) {
+	widget.selected = idx
 	widget.display()
 }
 
+// OpenItem shows the selected pipeline's jobs, or opens the selected item in a
+// browser, when it's double-clicked
+func (widget *Widget) OpenItem() {
+	widget.openItem()
+}
+
 /* -------------------- Unexported Functions -------------------- */
 
 func apiKey() string {
@@ -117,6 +175,26 @@ func (widget *Widget) currentGitlabProject() *GitlabProject {
 	return widget.GitlabProjects[widget.Idx]
 }
 
+// restoreProject displays the project that was displayed when the app last ran
+func (widget *Widget) restoreProject() {
+	source := wtf.State.Get(widget.ConfigKey()).Source
+
+	for idx, project := range widget.GitlabProjects {
+		if project.Path == source {
+			widget.Idx = idx
+			return
+		}
+	}
+}
+
+func (widget *Widget) saveProject() {
+	project := widget.currentGitlabProject()
+
+	if project != nil {
+		wtf.State.SetSource(widget.ConfigKey(), project.Path)
+	}
+}
+
 func (widget *Widget) keyboardIntercept(event *tcell.EventKey) *tcell.EventKey {
 	switch string(event.Rune()) {
 	case "/":
@@ -125,22 +203,161 @@ func (widget *Widget) keyboardIntercept(event *tcell.EventKey) *tcell.EventKey {
 	case "h":
 		widget.Prev()
 		return nil
+	case "j":
+		widget.next()
+		return nil
+	case "k":
+		widget.prev()
+		return nil
 	case "l":
 		widget.Next()
 		return nil
+	case "o":
+		widget.openURL()
+		return nil
 	case "r":
 		widget.Refresh()
 		return nil
+	case "R":
+		widget.retry()
+		return nil
 	}
 
 	switch event.Key() {
+	case tcell.KeyDown:
+		widget.next()
+		return nil
+	case tcell.KeyEnter:
+		widget.openItem()
+		return nil
+	case tcell.KeyEsc:
+		widget.unselect()
+		return event
 	case tcell.KeyLeft:
 		widget.Prev()
 		return nil
 	case tcell.KeyRight:
 		widget.Next()
 		return nil
+	case tcell.KeyUp:
+		widget.prev()
+		return nil
 	default:
 		return event
 	}
 }
+
+// itemCount returns the number of selectable items of the current project
+func (widget *Widget) itemCount() int {
+	project := widget.currentGitlabProject()
+	if project == nil {
+		return 0
+	}
+
+	return len(project.items())
+}
+
+func (widget *Widget) next() {
+	widget.selected++
+	if widget.selected >= widget.itemCount() {
+		widget.selected = 0
+	}
+
+	widget.display()
+}
+
+func (widget *Widget) prev() {
+	widget.selected--
+	if widget.selected < 0 {
+		widget.selected = widget.itemCount() - 1
+	}
+
+	widget.display()
+}
+
+// selectedItem returns the selected item, which is empty if none is selected
+func (widget *Widget) selectedItem() projectItem {
+	project := widget.currentGitlabProject()
+	if project == nil {
+		return projectItem{}
+	}
+
+	items := project.items()
+	if widget.selected < 0 || widget.selected >= len(items) {
+		return projectItem{}
+	}
+
+	return items[widget.selected]
+}
+
+func (widget *Widget) unselect() {
+	widget.selected = -1
+	widget.display()
+}
+
+// openItem shows the jobs of the selected pipeline, or of the selected merge
+// request's pipeline. Anything else that's selected, or the project if nothing
+// is, is opened in a browser
+func (widget *Widget) openItem() {
+	project := widget.currentGitlabProject()
+	if project == nil {
+		return
+	}
+
+	if pipeline := project.itemPipeline(widget.selectedItem()); pipeline != nil {
+		newJobsPage(widget, project, pipeline).show()
+		return
+	}
+
+	widget.openURL()
+}
+
+func (widget *Widget) openURL() {
+	project := widget.currentGitlabProject()
+	if project == nil {
+		return
+	}
+
+	if url := project.itemURL(widget.selectedItem()); url != "" {
+		wtf.OpenFile(url)
+	}
+}
+
+// retry retries the selected pipeline, or the selected merge request's pipeline,
+// if it failed, and reloads the project to show it running again
+func (widget *Widget) retry() {
+	project := widget.currentGitlabProject()
+	if project == nil {
+		return
+	}
+
+	pipeline := project.itemPipeline(widget.selectedItem())
+	if pipeline == nil {
+		return
+	}
+
+	go func() {
+		_, err := widget.retryPipeline(project, pipeline)
+
+		widget.app.QueueUpdateDraw(func() {
+			if err != nil {
+				widget.ShowNotice("[red]" + tview.Escape(err.Error()))
+				return
+			}
+
+			widget.ShowNotice(fmt.Sprintf("[green]Retrying pipeline #%d", pipeline.ID))
+
+			go wtf.RefreshWidget(widget)
+		})
+	}()
+}
+
+// retryPipeline retries the pipeline's failed and canceled jobs. Pipelines that
+// didn't fail have nothing to retry
+func (widget *Widget) retryPipeline(project *GitlabProject, pipeline *glb.Pipeline) (*glb.Pipeline, error) {
+	if !retryable(pipeline) {
+		return nil, fmt.Errorf("pipeline #%d is %s, only failed pipelines can be retried", pipeline.ID, pipeline.Status)
+	}
+
+	return project.retryPipeline(pipeline.ID)
+}
@@ -0,0 +1,76 @@
+package gitlab_tests
+
+import (
+	"testing"
+
+	. "github.com/senorprogrammer/wtf/gitlab"
+	. "github.com/stretchr/testify/assert"
+	glb "github.com/xanzy/go-gitlab"
+)
+
+/* -------------------- PipelineStages() -------------------- */
+
+func TestPipelineStages(t *testing.T) {
+	Equal(t, []Stage{}, PipelineStages([]*glb.Job{}))
+
+	// Jobs are listed newest first, and the stages are in the order they run in
+	jobs := []*glb.Job{
+		{ID: 3, Name: "release", Stage: "deploy", Status: "created"},
+		{ID: 2, Name: "unit", Stage: "test", Status: "running"},
+		{ID: 1, Name: "compile", Stage: "build", Status: "success"},
+	}
+
+	Equal(t, []Stage{
+		{Name: "build", Status: "success"},
+		{Name: "test", Status: "running"},
+		{Name: "deploy", Status: "created"},
+	}, PipelineStages(jobs))
+
+	// The job listed first keeps its place
+	Equal(t, 3, jobs[0].ID)
+}
+
+func TestPipelineStagesSeveralJobs(t *testing.T) {
+	jobs := []*glb.Job{
+		{ID: 5, Name: "lint", Stage: "test", Status: "success"},
+		{ID: 4, Name: "integration", Stage: "test", Status: "failed"},
+		{ID: 3, Name: "unit", Stage: "test", Status: "running"},
+		{ID: 2, Name: "docs", Stage: "build", Status: "skipped"},
+		{ID: 1, Name: "compile", Stage: "build", Status: "success"},
+	}
+
+	Equal(t, []Stage{
+		{Name: "build", Status: "success"},
+		{Name: "test", Status: "failed"},
+	}, PipelineStages(jobs))
+}
+
+func TestPipelineStagesRetriedJobs(t *testing.T) {
+	jobs := []*glb.Job{
+		{ID: 4, Name: "compile", Stage: "build", Status: "pending"},
+		{ID: 3, Name: "unit", Stage: "test", Status: "created"},
+		{ID: 2, Name: "unit", Stage: "test", Status: "canceled"},
+		{ID: 1, Name: "compile", Stage: "build", Status: "failed"},
+	}
+
+	Equal(t, []Stage{
+		{Name: "build", Status: "pending"},
+		{Name: "test", Status: "created"},
+	}, PipelineStages(jobs))
+}
+
+/* -------------------- CombinedStatus() -------------------- */
+
+func TestCombinedStatus(t *testing.T) {
+	Equal(t, "", CombinedStatus([]string{}))
+	Equal(t, "success", CombinedStatus([]string{"success"}))
+	Equal(t, "failed", CombinedStatus([]string{"success", "running", "failed"}))
+	Equal(t, "running", CombinedStatus([]string{"pending", "running", "success"}))
+	Equal(t, "manual", CombinedStatus([]string{"success", "manual"}))
+	Equal(t, "success", CombinedStatus([]string{"skipped", "success"}))
+}
+
+func TestCombinedStatusUnknown(t *testing.T) {
+	Equal(t, "skipped", CombinedStatus([]string{"waiting_for_resource", "skipped"}))
+	Equal(t, "preparing", CombinedStatus([]string{"preparing", "scheduled"}))
+}
@@ -57,7 +57,7 @@ func apiRequest(path string) (*http.Response, error) {
 	bearer := fmt.Sprintf("Bearer %s", apiToken())
 	req.Header.Add("Authorization", bearer)
 
-	httpClient := &http.Client{}
+	httpClient := wtf.NewHTTPClient("gitter", nil)
 	resp, err := httpClient.Do(req)
 	if err != nil {
 		return nil, err
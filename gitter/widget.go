@@ -15,11 +15,19 @@ const HelpText = `
    j: Select the next message in the list
    k: Select the previous message in the list
    r: Refresh the data
+   y: Copy the selected message to the clipboard
 
    arrow down: Select the next message in the list
    arrow up:   Select the previous message in the list
 `
 
+const ConfigDefaults = `
+apiToken: ""
+numberOfMessages: 10
+refreshInterval: 300
+roomUri: "wtfutil/Lobby"
+`
+
 type Widget struct {
 	wtf.HelpfulWidget
 	wtf.TextWidget
@@ -35,6 +43,7 @@ func NewWidget(app *tview.Application, pages *tview.Pages) *Widget {
 	}
 
 	widget.HelpfulWidget.SetView(widget.View)
+	widget.SetRateLimitHost(wtf.HostFor(apiBaseURL, "api.gitter.im"))
 	widget.unselect()
 
 	widget.View.SetScrollable(true)
@@ -53,9 +62,9 @@ func (widget *Widget) Refresh() {
 
 	room, err := GetRoom(wtf.Config.UString("wtf.mods.gitter.roomUri", "wtfutil/Lobby"))
 	if err != nil {
-		widget.View.SetWrap(true)
+		widget.SetWrap(true)
 		widget.View.SetTitle(widget.Name)
-		widget.View.SetText(err.Error())
+		widget.SetText(err.Error())
 		return
 	}
 
@@ -67,9 +76,9 @@ func (widget *Widget) Refresh() {
 	widget.UpdateRefreshedAt()
 
 	if err != nil {
-		widget.View.SetWrap(true)
+		widget.SetWrap(true)
 		widget.View.SetTitle(widget.Name)
-		widget.View.SetText(err.Error())
+		widget.SetText(err.Error())
 	} else {
 		widget.messages = messages
 	}
@@ -78,6 +87,32 @@ func (widget *Widget) Refresh() {
 	widget.View.ScrollToEnd()
 }
 
+// ActionData returns the fields of the selected message for the module's actions
+func (widget *Widget) ActionData() wtf.ActionData {
+	sel := widget.selected
+	if sel < 0 || widget.messages == nil || sel >= len(widget.messages) {
+		return nil
+	}
+
+	message := widget.messages[sel]
+
+	return wtf.ActionData{
+		"From": message.From.Username,
+		"Text": message.Text,
+	}
+}
+
+// SelectItem selects the message at idx, when it's clicked on
+func (widget *Widget) SelectItem(idx int) {
+	widget.selected = idx
+	widget.display()
+}
+
+// OpenItem opens the selected message, when it's double-clicked
+func (widget *Widget) OpenItem() {
+	widget.openMessage()
+}
+
 /* -------------------- Unexported Functions -------------------- */
 
 func (widget *Widget) display() {
@@ -85,10 +120,10 @@ func (widget *Widget) display() {
 		return
 	}
 
-	widget.View.SetWrap(true)
+	widget.SetWrap(true)
 	widget.View.Clear()
 	widget.View.SetTitle(widget.ContextualTitle(fmt.Sprintf("%s - %s", widget.Name, wtf.Config.UString("wtf.mods.gitter.roomUri", "wtfutil/Lobby"))))
-	widget.View.SetText(widget.contentFrom(widget.messages))
+	widget.SetText(widget.contentFrom(widget.messages))
 	widget.View.Highlight(strconv.Itoa(widget.selected)).ScrollToHighlight()
 }
 
@@ -96,9 +131,10 @@ func (widget *Widget) contentFrom(messages []Message) string {
 	var str string
 	for idx, message := range messages {
 		str = str + fmt.Sprintf(
-			`["%d"][""][%s] [blue]%s [lightslategray]%s: [%s]%s [aqua]%s`,
+			`["%d"][""][%s] %s[blue]%s [lightslategray]%s: [%s]%s [aqua]%s`,
 			idx,
 			widget.rowColor(idx),
+			wtf.SelectionIndicator(widget.View.HasFocus() && idx == widget.selected),
 			message.From.DisplayName,
 			message.From.Username,
 			widget.rowColor(idx),
@@ -146,6 +182,13 @@ func (widget *Widget) openMessage() {
 	}
 }
 
+func (widget *Widget) copyMessage() {
+	sel := widget.selected
+	if sel >= 0 && widget.messages != nil && sel < len(widget.messages) {
+		widget.Copy(widget.messages[sel].Text)
+	}
+}
+
 func (widget *Widget) unselect() {
 	widget.selected = -1
 	widget.display()
@@ -164,6 +207,9 @@ func (widget *Widget) keyboardIntercept(event *tcell.EventKey) *tcell.EventKey {
 	case "r":
 		widget.Refresh()
 		return nil
+	case "y":
+		widget.copyMessage()
+		return nil
 	}
 
 	switch event.Key() {
@@ -8,6 +8,17 @@ import (
 	sheets "google.golang.org/api/sheets/v4"
 )
 
+const ConfigDefaults = `
+cells:
+  addresses: []
+  names: []
+colors:
+  values: "green"
+refreshInterval: 300
+secretFile: "~/.config/wtf/gspreadsheets/client_secret.json"
+sheetId: ""
+`
+
 type Widget struct {
 	wtf.TextWidget
 }
@@ -27,7 +38,7 @@ func (widget *Widget) Refresh() {
 
 	widget.UpdateRefreshedAt()
 
-	widget.View.SetText(widget.contentFrom(cells))
+	widget.SetText(widget.contentFrom(cells))
 }
 
 /* -------------------- Unexported Functions -------------------- */
@@ -17,6 +17,7 @@ const HelpText = `
    j: Select the next story in the list
    k: Select the previous story in the list
    r: Refresh the data
+   y: Copy the selected story's URL to the clipboard
 
    arrow down: Select the next story in the list
    arrow up:   Select the previous story in the list
@@ -24,6 +25,16 @@ const HelpText = `
    return: Open the selected story in a browser
 `
 
+const ConfigDefaults = `
+colors:
+  rows:
+    even: "white"
+    odd: "lightblue"
+numberOfStories: 10
+refreshInterval: 900
+storyType: "top"
+`
+
 type Widget struct {
 	wtf.HelpfulWidget
 	wtf.TextWidget
@@ -63,7 +74,7 @@ func (widget *Widget) Refresh() {
 	if err != nil {
 		widget.View.SetWrap(true)
 		widget.View.SetTitle(widget.Name)
-		widget.View.SetText(err.Error())
+		widget.SetText(err.Error())
 	} else {
 		var stories []Story
 		numberOfStoriesToDisplay := wtf.Config.UInt("wtf.mods.hackernews.numberOfStories", 10)
@@ -83,6 +94,34 @@ func (widget *Widget) Refresh() {
 	widget.display()
 }
 
+// ActionData returns the fields of the selected story for the module's actions
+func (widget *Widget) ActionData() wtf.ActionData {
+	sel := widget.selected
+	if sel < 0 || widget.stories == nil || sel >= len(widget.stories) {
+		return nil
+	}
+
+	story := widget.stories[sel]
+
+	return wtf.ActionData{
+		"By":    story.By,
+		"ID":    story.ID,
+		"Title": story.Title,
+		"URL":   story.URL,
+	}
+}
+
+// SelectItem selects the story at idx, when it's clicked on
+func (widget *Widget) SelectItem(idx int) {
+	widget.selected = idx
+	widget.display()
+}
+
+// OpenItem opens the selected story in a browser, when it's double-clicked
+func (widget *Widget) OpenItem() {
+	widget.openStory()
+}
+
 /* -------------------- Unexported Functions -------------------- */
 
 func (widget *Widget) display() {
@@ -94,7 +133,7 @@ func (widget *Widget) display() {
 
 	widget.View.Clear()
 	widget.View.SetTitle(widget.ContextualTitle(fmt.Sprintf("%s - %sstories", widget.Name, wtf.Config.UString("wtf.mods.hackernews.storyType", "top"))))
-	widget.View.SetText(widget.contentFrom(widget.stories))
+	widget.SetText(widget.contentFrom(widget.stories))
 	widget.View.Highlight(strconv.Itoa(widget.selected)).ScrollToHighlight()
 }
 
@@ -103,9 +142,10 @@ func (widget *Widget) contentFrom(stories []Story) string {
 	for idx, story := range stories {
 		u, _ := url.Parse(story.URL)
 		str = str + fmt.Sprintf(
-			`["%d"][""][%s] [yellow]%d. [%s]%s [blue](%s)`,
+			`["%d"][""][%s] %s[yellow]%d. [%s]%s [blue](%s)`,
 			idx,
 			widget.rowColor(idx),
+			wtf.SelectionIndicator(widget.View.HasFocus() && idx == widget.selected),
 			idx+1,
 			widget.rowColor(idx),
 			story.Title,
@@ -152,6 +192,13 @@ func (widget *Widget) openStory() {
 	}
 }
 
+func (widget *Widget) copyStory() {
+	sel := widget.selected
+	if sel >= 0 && widget.stories != nil && sel < len(widget.stories) {
+		widget.Copy(widget.stories[sel].URL)
+	}
+}
+
 func (widget *Widget) unselect() {
 	widget.selected = -1
 	widget.display()
@@ -170,6 +217,9 @@ func (widget *Widget) keyboardIntercept(event *tcell.EventKey) *tcell.EventKey {
 	case "r":
 		widget.Refresh()
 		return nil
+	case "y":
+		widget.copyStory()
+		return nil
 	}
 
 	switch event.Key() {
@@ -12,6 +12,10 @@ import (
 	"github.com/senorprogrammer/wtf/wtf"
 )
 
+const ConfigDefaults = `
+refreshInterval: 150
+`
+
 // Widget widget struct
 type Widget struct {
 	wtf.TextWidget
@@ -53,7 +57,7 @@ func NewWidget(app *tview.Application) *Widget {
 func (widget *Widget) Refresh() {
 	widget.UpdateRefreshedAt()
 	widget.ipinfo()
-	widget.View.SetText(widget.result)
+	widget.SetText(widget.result)
 }
 
 //this method reads the config and calls ipinfo for ip information
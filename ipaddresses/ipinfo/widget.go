@@ -11,6 +11,13 @@ import (
 	"github.com/senorprogrammer/wtf/wtf"
 )
 
+const ConfigDefaults = `
+colors:
+  name: "white"
+  value: "white"
+refreshInterval: 150
+`
+
 type Widget struct {
 	wtf.TextWidget
 	result string
@@ -47,7 +54,7 @@ func (widget *Widget) Refresh() {
 	widget.ipinfo()
 	widget.View.Clear()
 
-	widget.View.SetText(widget.result)
+	widget.SetText(widget.result)
 }
 
 //this method reads the config and calls ipinfo for ip information
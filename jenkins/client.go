@@ -30,12 +30,11 @@ func Create(jenkinsURL string, username string, apiKey string) (*View, error) {
 	req.SetBasicAuth(username, apiKey)
 
 	verifyServerCertificate := wtf.Config.UBool("wtf.mods.jenkins.verifyServerCertificate", true)
-	httpClient := &http.Client{Transport: &http.Transport{
+	httpClient := wtf.NewHTTPClient("jenkins", &http.Transport{
 		TLSClientConfig: &tls.Config{
 			InsecureSkipVerify: !verifyServerCertificate,
 		},
-	},
-	}
+	})
 	resp, err := httpClient.Do(req)
 
 	if err != nil {
@@ -16,6 +16,7 @@ const HelpText = `
    j: Select the next job in the list
    k: Select the previous job in the list
    r: Refresh the data
+   y: Copy the selected job's URL to the clipboard
 
    arrow down: Select the next job in the list
    arrow up:   Select the previous job in the list
@@ -23,6 +24,14 @@ const HelpText = `
    return: Open the selected job in a browser
 `
 
+const ConfigDefaults = `
+apiKey: ""
+refreshInterval: 300
+url: ""
+user: ""
+verifyServerCertificate: true
+`
+
 type Widget struct {
 	wtf.HelpfulWidget
 	wtf.TextWidget
@@ -38,6 +47,7 @@ func NewWidget(app *tview.Application, pages *tview.Pages) *Widget {
 	}
 
 	widget.HelpfulWidget.SetView(widget.View)
+	widget.SetRateLimitHost(wtf.HostFor(wtf.Config.UString("wtf.mods.jenkins.url"), ""))
 	widget.unselect()
 
 	widget.View.SetScrollable(true)
@@ -60,18 +70,47 @@ func (widget *Widget) Refresh() {
 		widget.apiKey(),
 	)
 	widget.view = view
+	widget.restoreSelected()
 
 	widget.UpdateRefreshedAt()
 
 	if err != nil {
 		widget.View.SetWrap(true)
 		widget.View.SetTitle(widget.ContextualTitle(widget.Name))
-		widget.View.SetText(err.Error())
+		widget.SetText(err.Error())
 	}
 
 	widget.display()
 }
 
+// ActionData returns the fields of the selected job for the module's actions
+func (widget *Widget) ActionData() wtf.ActionData {
+	sel := widget.selected
+	if sel < 0 || widget.view == nil || sel >= len(widget.view.Jobs) {
+		return nil
+	}
+
+	job := widget.view.Jobs[sel]
+
+	return wtf.ActionData{
+		"Name":  job.Name,
+		"Color": job.Color,
+		"URL":   job.Url,
+	}
+}
+
+// SelectItem selects the job at idx, when it's clicked on
+func (widget *Widget) SelectItem(idx int) {
+	widget.selected = idx
+	widget.saveSelected()
+	widget.display()
+}
+
+// OpenItem opens the selected job in a browser, when it's double-clicked
+func (widget *Widget) OpenItem() {
+	widget.openJob()
+}
+
 /* -------------------- Unexported Functions -------------------- */
 
 func (widget *Widget) display() {
@@ -83,7 +122,7 @@ func (widget *Widget) display() {
 
 	widget.View.Clear()
 	widget.View.SetTitle(widget.ContextualTitle(fmt.Sprintf("%s: [red]%s", widget.Name, widget.view.Name)))
-	widget.View.SetText(widget.contentFrom(widget.view))
+	widget.SetText(widget.contentFrom(widget.view))
 	widget.View.Highlight(strconv.Itoa(widget.selected)).ScrollToHighlight()
 }
 
@@ -98,10 +137,12 @@ func (widget *Widget) contentFrom(view *View) string {
 	var str string
 	for idx, job := range view.Jobs {
 		str = str + fmt.Sprintf(
-			`["%d"][""][%s] [%s]%-6s[white]`,
+			`["%d"][""][%s] %s[%s]%s%-6s[white]`,
 			idx,
 			widget.rowColor(idx),
+			wtf.SelectionIndicator(widget.View.HasFocus() && idx == widget.selected),
 			widget.jobColor(&job),
+			wtf.StatusMarker(widget.jobColor(&job)),
 			job.Name,
 		)
 
@@ -136,6 +177,7 @@ func (widget *Widget) next() {
 		widget.selected = 0
 	}
 
+	widget.saveSelected()
 	widget.display()
 }
 
@@ -145,6 +187,7 @@ func (widget *Widget) prev() {
 		widget.selected = len(widget.view.Jobs) - 1
 	}
 
+	widget.saveSelected()
 	widget.display()
 }
 
@@ -156,6 +199,39 @@ func (widget *Widget) openJob() {
 	}
 }
 
+func (widget *Widget) copyJobURL() {
+	sel := widget.selected
+	if sel >= 0 && widget.view != nil && sel < len(widget.view.Jobs) {
+		widget.Copy(widget.view.Jobs[sel].Url)
+	}
+}
+
+// restoreSelected re-selects the previously-selected job, by name
+func (widget *Widget) restoreSelected() {
+	name := wtf.State.Get(widget.ConfigKey()).Selected
+	if name == "" || widget.view == nil {
+		return
+	}
+
+	for idx, job := range widget.view.Jobs {
+		if job.Name == name {
+			widget.selected = idx
+			return
+		}
+	}
+}
+
+func (widget *Widget) saveSelected() {
+	name := ""
+
+	sel := widget.selected
+	if sel >= 0 && widget.view != nil && sel < len(widget.view.Jobs) {
+		name = widget.view.Jobs[sel].Name
+	}
+
+	wtf.State.SetSelected(widget.ConfigKey(), name)
+}
+
 func (widget *Widget) unselect() {
 	widget.selected = -1
 	widget.display()
@@ -174,6 +250,9 @@ func (widget *Widget) keyboardIntercept(event *tcell.EventKey) *tcell.EventKey {
 	case "r":
 		widget.Refresh()
 		return nil
+	case "y":
+		widget.copyJobURL()
+		return nil
 	}
 
 	switch event.Key() {
@@ -185,6 +264,7 @@ func (widget *Widget) keyboardIntercept(event *tcell.EventKey) *tcell.EventKey {
 		return nil
 	case tcell.KeyEsc:
 		widget.unselect()
+		widget.saveSelected()
 		return event
 	case tcell.KeyUp:
 		widget.prev()
@@ -71,12 +71,11 @@ func jiraRequest(path string) (*http.Response, error) {
 	req.SetBasicAuth(wtf.Config.UString("wtf.mods.jira.email"), apiKey())
 
 	verifyServerCertificate := wtf.Config.UBool("wtf.mods.jira.verifyServerCertificate", true)
-	httpClient := &http.Client{Transport: &http.Transport{
+	httpClient := wtf.NewHTTPClient("jira", &http.Transport{
 		TLSClientConfig: &tls.Config{
 			InsecureSkipVerify: !verifyServerCertificate,
 		},
-	},
-	}
+	})
 	resp, err := httpClient.Do(req)
 	if err != nil {
 		return nil, err
@@ -15,6 +15,8 @@ const HelpText = `
    /: Show/hide this help window
    j: Select the next item in the list
    k: Select the previous item in the list
+   y: Copy the selected issue's URL to the clipboard
+   Y: Copy the selected issue's key to the clipboard
 
    arrow down: Select the next item in the list
    arrow up:   Select the previous item in the list
@@ -22,6 +24,21 @@ const HelpText = `
    return: Open the selected issue in a browser
 `
 
+const ConfigDefaults = `
+apiKey: ""
+colors:
+  rows:
+    even: "white"
+    odd: "lightblue"
+domain: ""
+email: ""
+jql: ""
+project: ""
+refreshInterval: 900
+username: ""
+verifyServerCertificate: true
+`
+
 type Widget struct {
 	wtf.HelpfulWidget
 	wtf.TextWidget
@@ -37,6 +54,7 @@ func NewWidget(app *tview.Application, pages *tview.Pages) *Widget {
 	}
 
 	widget.HelpfulWidget.SetView(widget.View)
+	widget.SetRateLimitHost(wtf.HostFor(wtf.Config.UString("wtf.mods.jira.domain"), ""))
 	widget.unselect()
 
 	widget.View.SetScrollable(true)
@@ -60,14 +78,46 @@ func (widget *Widget) Refresh() {
 		widget.result = nil
 		widget.View.SetWrap(true)
 		widget.View.SetTitle(widget.Name)
-		widget.View.SetText(err.Error())
+		widget.SetText(err.Error())
 	} else {
 		widget.result = searchResult
+		widget.restoreSelected()
 	}
 
 	widget.display()
 }
 
+// ActionData returns the fields of the selected issue for the module's actions
+func (widget *Widget) ActionData() wtf.ActionData {
+	issue := widget.selectedIssue()
+	if issue == nil {
+		return nil
+	}
+
+	return wtf.ActionData{
+		"Key":     issue.Key,
+		"Summary": issue.IssueFields.Summary,
+		"Type":    issue.IssueFields.IssueType.Name,
+		"URL":     issueURL(issue),
+	}
+}
+
+// SelectItem selects the issue at idx, when it's clicked on
+func (widget *Widget) SelectItem(idx int) {
+	widget.selected = idx
+	widget.saveSelected()
+	widget.display()
+}
+
+// OpenItem opens the selected issue in a browser, when it's double-clicked or
+// Return is pressed
+func (widget *Widget) OpenItem() {
+	issue := widget.selectedIssue()
+	if issue != nil {
+		wtf.OpenFile(issueURL(issue))
+	}
+}
+
 /* -------------------- Unexported Functions -------------------- */
 
 func (widget *Widget) display() {
@@ -80,8 +130,10 @@ func (widget *Widget) display() {
 
 	widget.View.Clear()
 	widget.View.SetTitle(widget.ContextualTitle(str))
-	widget.View.SetText(fmt.Sprintf("%s", widget.contentFrom(widget.result)))
+	widget.SetText(fmt.Sprintf("%s", widget.contentFrom(widget.result)))
 	widget.View.Highlight(strconv.Itoa(widget.selected)).ScrollToHighlight()
+
+	widget.Publish(wtf.EventIssueSelected, widget.ActionData())
 }
 
 func (widget *Widget) next() {
@@ -89,6 +141,8 @@ func (widget *Widget) next() {
 	if widget.result != nil && widget.selected >= len(widget.result.Issues) {
 		widget.selected = 0
 	}
+
+	widget.saveSelected()
 }
 
 func (widget *Widget) prev() {
@@ -96,14 +150,62 @@ func (widget *Widget) prev() {
 	if widget.selected < 0 && widget.result != nil {
 		widget.selected = len(widget.result.Issues) - 1
 	}
+
+	widget.saveSelected()
+}
+
+func (widget *Widget) copyKey() {
+	issue := widget.selectedIssue()
+	if issue != nil {
+		widget.Copy(issue.Key)
+	}
+}
+
+func (widget *Widget) copyURL() {
+	issue := widget.selectedIssue()
+	if issue != nil {
+		widget.Copy(issueURL(issue))
+	}
 }
 
-func (widget *Widget) openItem() {
+func (widget *Widget) selectedIssue() *Issue {
 	sel := widget.selected
 	if sel >= 0 && widget.result != nil && sel < len(widget.result.Issues) {
-		issue := &widget.result.Issues[widget.selected]
-		wtf.OpenFile(wtf.Config.UString("wtf.mods.jira.domain") + "/browse/" + issue.Key)
+		return &widget.result.Issues[sel]
 	}
+
+	return nil
+}
+
+func issueURL(issue *Issue) string {
+	return wtf.Config.UString("wtf.mods.jira.domain") + "/browse/" + issue.Key
+}
+
+// restoreSelected re-selects the previously-selected issue, by key, so that the
+// selection survives both restarts and changes in the order of the issues
+func (widget *Widget) restoreSelected() {
+	key := wtf.State.Get(widget.ConfigKey()).Selected
+	if key == "" {
+		return
+	}
+
+	for idx, issue := range widget.result.Issues {
+		if issue.Key == key {
+			widget.selected = idx
+			return
+		}
+	}
+}
+
+func (widget *Widget) saveSelected() {
+	key := ""
+
+	sel := widget.selected
+	if sel >= 0 && widget.result != nil && sel < len(widget.result.Issues) {
+		key = widget.result.Issues[sel].Key
+	}
+
+	wtf.State.SetSelected(widget.ConfigKey(), key)
 }
 
 func (widget *Widget) unselect() {
@@ -115,9 +217,10 @@ func (widget *Widget) contentFrom(searchResult *SearchResult) string {
 
 	for idx, issue := range searchResult.Issues {
 		fmtStr := fmt.Sprintf(
-			`["%d"][""][%s] [%s]%-6s[white] [green]%-10s[white] [%s]%s`,
+			`["%d"][""][%s] %s[%s]%-6s[white] [green]%-10s[white] [%s]%s`,
 			idx,
 			widget.rowColor(idx),
+			wtf.SelectionIndicator(widget.View.HasFocus() && idx == widget.selected),
 			widget.issueTypeColor(&issue),
 			issue.IssueFields.IssueType.Name,
 			issue.Key,
@@ -186,6 +289,12 @@ func (widget *Widget) keyboardIntercept(event *tcell.EventKey) *tcell.EventKey {
 		widget.prev()
 		widget.display()
 		return nil
+	case "y":
+		widget.copyURL()
+		return nil
+	case "Y":
+		widget.copyKey()
+		return nil
 	}
 
 	switch event.Key() {
@@ -195,11 +304,12 @@ func (widget *Widget) keyboardIntercept(event *tcell.EventKey) *tcell.EventKey {
 		widget.display()
 		return nil
 	case tcell.KeyEnter:
-		widget.openItem()
+		widget.OpenItem()
 		return nil
 	case tcell.KeyEsc:
 		// Unselect the current row
 		widget.unselect()
+		widget.saveSelected()
 		widget.display()
 		return event
 	case tcell.KeyUp:
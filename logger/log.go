@@ -12,6 +12,10 @@ import (
 	"github.com/senorprogrammer/wtf/wtf"
 )
 
+const ConfigDefaults = `
+refreshInterval: 1
+`
+
 const maxBufferSize int64 = 1024
 
 type Widget struct {
@@ -56,7 +60,7 @@ func (widget *Widget) Refresh() {
 	widget.View.SetTitle(widget.Name)
 
 	logLines := widget.tailFile()
-	widget.View.SetText(widget.contentFrom(logLines))
+	widget.SetText(widget.contentFrom(logLines))
 }
 
 /* -------------------- Unexported Functions -------------------- */
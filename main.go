@@ -4,6 +4,9 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"os/signal"
+	"sort"
+	"syscall"
 	"time"
 
 	"github.com/gdamore/tcell"
@@ -85,7 +88,25 @@ func initializeFocusTracker(app *tview.Application) {
 }
 
 func keyboardIntercept(event *tcell.EventKey) *tcell.EventKey {
+	if focusTracker.HandleHintKey(event) {
+		return nil
+	}
+
+	if wtf.HandleActionKey(focusTracker.FocusedWidget(), event) {
+		return nil
+	}
+
+	if wtf.HandleFilterKey(focusTracker.FocusedWidget(), event) {
+		return nil
+	}
+
 	switch event.Key() {
+	case tcell.KeyCtrlE:
+		refreshFocusedWidget()
+	case tcell.KeyCtrlG:
+		focusTracker.ShowHints()
+	case tcell.KeyCtrlP:
+		togglePause()
 	case tcell.KeyCtrlR:
 		refreshAllWidgets()
 	case tcell.KeyTab:
@@ -103,15 +124,88 @@ func keyboardIntercept(event *tcell.EventKey) *tcell.EventKey {
 	return event
 }
 
+func afterDraw(screen tcell.Screen) {
+	focusTracker.DrawHints(screen)
+	wtf.DrawMonochrome(screen)
+}
+
+// mouseIntercept passes mouse events to the focus tracker, rather than to the
+// widgets' views, which only know how to scroll and focus themselves. The app
+// passes an event on once for each of the actions it makes up, i.e. a move and
+// a click. Once it's been stopped, the event is nil for the rest of them
+func mouseIntercept(event *tcell.EventMouse, action tview.MouseAction) (*tcell.EventMouse, tview.MouseAction) {
+	if event != nil {
+		focusTracker.HandleMouse(event)
+	}
+
+	return nil, action
+}
+
 func loadConfigFile(filePath string) {
 	Config = cfg.LoadConfigFile(filePath)
 	wtf.Config = Config
 }
 
+// checkConfigVersion logs the changes made to the config file, as it's loaded,
+// if it was written for an older config version. The file itself is only
+// updated by 'wtf config migrate'
+func checkConfigVersion(filePath string) {
+	changes, _, err := cfg.MigrateConfigFile(filePath, true)
+	if err != nil {
+		logger.Log(err.Error())
+		return
+	}
+
+	for _, change := range changes {
+		logger.Log(fmt.Sprintf("Config migration %s", change))
+	}
+
+	if len(changes) > 0 {
+		logger.Log(fmt.Sprintf("Run 'wtf config migrate' to update %s to config version %d", filePath, cfg.ConfigVersion))
+	}
+}
+
+func loadResponseCache(offline bool) {
+	if !offline && !Config.UBool("wtf.cache.enabled", true) {
+		return
+	}
+
+	cacheDir, err := cfg.CacheDir()
+	if err != nil {
+		logger.Log(err.Error())
+		return
+	}
+
+	wtf.Cache = wtf.NewResponseCache(cacheDir, offline)
+}
+
+func loadStateFile(reset bool) {
+	filePath, err := cfg.CreateFile(cfg.StateFileName)
+	if err != nil {
+		logger.Log(err.Error())
+		return
+	}
+
+	wtf.State = wtf.LoadStateFile(filePath)
+
+	if reset {
+		wtf.State.Reset()
+	}
+}
+
 func refreshAllWidgets() {
 	for _, widget := range widgets {
-		go widget.Refresh()
+		go wtf.RefreshWidget(widget)
+	}
+}
+
+func refreshFocusedWidget() {
+	widget := focusTracker.FocusedWidget()
+	if widget == nil {
+		return
 	}
+
+	go wtf.RefreshWidget(widget)
 }
 
 func setTerm() {
@@ -121,6 +215,23 @@ func setTerm() {
 	}
 }
 
+// stopOnSignal stops the app when wtf is terminated, so that it exits the way it
+// does when it's quit
+func stopOnSignal(app *tview.Application) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP, syscall.SIGTERM)
+
+	go func() {
+		<-signals
+		app.Stop()
+	}()
+}
+
+func togglePause() {
+	wtf.TogglePause()
+	focusTracker.App.ForceDraw()
+}
+
 func watchForConfigChanges(app *tview.Application, configFilePath string, grid *tview.Grid, pages *tview.Pages) {
 	watch := watcher.New()
 	absPath, _ := wtf.ExpandHomeDir(configFilePath)
@@ -136,6 +247,7 @@ func watchForConfigChanges(app *tview.Application, configFilePath string, grid *
 				// Disable all widgets to stop scheduler goroutines and rmeove widgets from memory.
 				disableAllWidgets()
 				widgets = nil
+				wtf.ResetSubscriptions()
 				makeWidgets(app, pages)
 				initializeFocusTracker(app)
 				display := wtf.NewDisplay(widgets)
@@ -245,11 +357,22 @@ func addWidget(app *tview.Application, pages *tview.Pages, widgetName string) {
 func makeWidgets(app *tview.Application, pages *tview.Pages) {
 	mods, _ := Config.Map("wtf.mods")
 
+	// Sorted, so that the widgets are always created in the same order
+	names := []string{}
 	for mod := range mods {
+		names = append(names, mod)
+	}
+	sort.Strings(names)
+
+	for _, mod := range names {
 		if enabled := Config.UBool("wtf.mods."+mod+".enabled", false); enabled {
 			addWidget(app, pages, mod)
 		}
 	}
+
+	for _, widget := range widgets {
+		wtf.CaptureFilterKeys(widget)
+	}
 }
 
 /* -------------------- Main -------------------- */
@@ -264,7 +387,10 @@ func main() {
 	cfg.MigrateOldConfig()
 	cfg.CreateConfigDir()
 	cfg.CreateConfigFile()
+	checkConfigVersion(flags.ConfigFilePath())
 	loadConfigFile(flags.ConfigFilePath())
+	loadStateFile(flags.HasResetState())
+	loadResponseCache(flags.HasOffline())
 
 	if flags.Profile {
 		defer profile.Start(profile.MemProfile).Stop()
@@ -281,11 +407,27 @@ func main() {
 	display := wtf.NewDisplay(widgets)
 	pages.AddPage("grid", display.Grid, true, true)
 	app.SetInputCapture(keyboardIntercept)
+	app.SetMouseCapture(mouseIntercept)
+	app.EnableMouse(Config.UBool("wtf.mouse", true))
+	app.SetAfterDrawFunc(afterDraw)
 
 	go watchForConfigChanges(app, flags.Config, display.Grid, pages)
+	stopOnSignal(app)
+
+	app.SetRoot(pages, true)
+	focusTracker.FocusOnKey(wtf.State.FocusedWidget())
 
-	if err := app.SetRoot(pages, true).Run(); err != nil {
+	if err := app.Run(); err != nil {
+		disableAllWidgets()
 		fmt.Printf("Error: %v\n", err)
 		os.Exit(1)
 	}
+
+	// Stops the commands widgets are still running, such as streamed ones
+	disableAllWidgets()
+	wtf.SaveScrollRows(widgets)
+
+	if err := wtf.State.Err(); err != nil {
+		logger.Log(fmt.Sprintf("Couldn't save the UI state: %s", err))
+	}
 }
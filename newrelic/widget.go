@@ -8,6 +8,13 @@ import (
 	nr "github.com/yfronto/newrelic"
 )
 
+const ConfigDefaults = `
+apiKey: ""
+applicationId: 0
+deployCount: 5
+refreshInterval: 900
+`
+
 type Widget struct {
 	wtf.TextWidget
 }
@@ -44,7 +51,7 @@ func (widget *Widget) Refresh() {
 		content = widget.contentFrom(deploys)
 	}
 
-	widget.View.SetText(content)
+	widget.SetText(content)
 }
 
 /* -------------------- Unexported Functions -------------------- */
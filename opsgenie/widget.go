@@ -8,6 +8,12 @@ import (
 	"github.com/senorprogrammer/wtf/wtf"
 )
 
+const ConfigDefaults = `
+apiKey: ""
+displayEmpty: true
+refreshInterval: 21600
+`
+
 type Widget struct {
 	wtf.TextWidget
 }
@@ -37,7 +43,7 @@ func (widget *Widget) Refresh() {
 		content = widget.contentFrom(data)
 	}
 
-	widget.View.SetText(content)
+	widget.SetText(content)
 }
 
 /* -------------------- Unexported Functions -------------------- */
@@ -4,7 +4,6 @@ package power
 
 import (
 	"fmt"
-	"os/exec"
 	"regexp"
 	"strconv"
 	"strings"
@@ -46,8 +45,8 @@ func (battery *Battery) String() string {
 /* -------------------- Unexported Functions -------------------- */
 
 func (battery *Battery) execute() string {
-	cmd := exec.Command(battery.cmd, battery.args...)
-	return wtf.ExecuteCommand(cmd)
+	cmd := wtf.NewCommand(battery.cmd, battery.args...)
+	return cmd.Run().Output()
 }
 
 func (battery *Battery) parse(data string) string {
@@ -96,7 +95,7 @@ func (battery *Battery) formatRemaining(data string) string {
 
 	result := r.FindString(data)
 	if result == "" {
-		result = "∞"
+		result = wtf.Glyph("∞", "inf")
 	}
 
 	return result
@@ -4,7 +4,6 @@ package power
 
 import (
 	"fmt"
-	"os/exec"
 	"strconv"
 	"strings"
 
@@ -40,8 +39,8 @@ func (battery *Battery) String() string {
 /* -------------------- Unexported Functions -------------------- */
 
 func (battery *Battery) execute() string {
-	cmd := exec.Command("upower", "-e")
-	lines := strings.Split(wtf.ExecuteCommand(cmd), "\n")
+	cmd := wtf.NewCommand("upower", "-e")
+	lines := strings.Split(cmd.Run().Output(), "\n")
 	var target string
 	for _, l := range lines {
 		if strings.Contains(l, "/battery") {
@@ -49,8 +48,8 @@ func (battery *Battery) execute() string {
 			break
 		}
 	}
-	cmd = exec.Command("upower", "-i", target)
-	return wtf.ExecuteCommand(cmd)
+	cmd = wtf.NewCommand("upower", "-i", target)
+	return cmd.Run().Output()
 }
 
 func (battery *Battery) parse(data string) string {
@@ -67,7 +66,7 @@ func (battery *Battery) parse(data string) string {
 		table[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
 	}
 	if s := table["time to empty"]; s == "" {
-		table["time to empty"] = "∞"
+		table["time to empty"] = wtf.Glyph("∞", "inf")
 	}
 	str := ""
 	str = str + fmt.Sprintf(" %10s: %s\n", "Charge", battery.formatCharge(table["percentage"]))
@@ -3,7 +3,6 @@
 package power
 
 import (
-	"os/exec"
 	"regexp"
 	"strings"
 
@@ -15,8 +14,8 @@ const SingleQuotesRegExp = "'(.*)'"
 // powerSource returns the name of the current power source, probably one of
 // "AC Power" or "Battery Power"
 func powerSource() string {
-	cmd := exec.Command("pmset", []string{"-g", "ps"}...)
-	result := wtf.ExecuteCommand(cmd)
+	cmd := wtf.NewCommand("pmset", []string{"-g", "ps"}...)
+	result := cmd.Run().Output()
 
 	r, _ := regexp.Compile(SingleQuotesRegExp)
 
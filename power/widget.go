@@ -7,6 +7,10 @@ import (
 	"github.com/senorprogrammer/wtf/wtf"
 )
 
+const ConfigDefaults = `
+refreshInterval: 15
+`
+
 type Widget struct {
 	wtf.TextWidget
 
@@ -33,5 +37,5 @@ func (widget *Widget) Refresh() {
 	content = content + "\n"
 	content = content + widget.Battery.String()
 
-	widget.View.SetText(content)
+	widget.SetText(content)
 }
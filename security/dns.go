@@ -3,7 +3,6 @@
 package security
 
 import (
-	"os/exec"
 	"runtime"
 	"strings"
 
@@ -27,8 +26,8 @@ func DnsServers() []string {
 
 func dnsLinux() []string {
 	// This may be very Ubuntu specific
-	cmd := exec.Command("nmcli", "device", "show")
-	out := wtf.ExecuteCommand(cmd)
+	cmd := wtf.NewCommand("nmcli", "device", "show")
+	out := cmd.Run().Output()
 
 	lines := strings.Split(out, "\n")
 
@@ -45,8 +44,8 @@ func dnsLinux() []string {
 
 func dnsMacOS() []string {
 	cmdString := `scutil --dns | head -n 7 | grep -o '[0-9]\{1,3\}\.[0-9]\{1,3\}\.[0-9]\{1,3\}\.[0-9]\{1,3\}'`
-	cmd := exec.Command("sh", "-c", cmdString)
-	out := wtf.ExecuteCommand(cmd)
+	cmd := wtf.NewCommand("sh", "-c", cmdString)
+	out := cmd.Run().Output()
 
 	lines := strings.Split(out, "\n")
 
@@ -3,12 +3,11 @@
 package security
 
 import (
-	"os/exec"
 
 	"github.com/senorprogrammer/wtf/wtf"
 )
 
 func DnsServers() []string {
-	cmd := exec.Command("powershell.exe", "Get-DnsClientServerAddress | Select-Object –ExpandProperty ServerAddresses")
-	return []string{wtf.ExecuteCommand(cmd)}
+	cmd := wtf.NewCommand("powershell.exe", "Get-DnsClientServerAddress | Select-Object –ExpandProperty ServerAddresses")
+	return []string{cmd.Run().Output()}
 }
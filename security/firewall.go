@@ -1,7 +1,6 @@
 package security
 
 import (
-	"os/exec"
 	"runtime"
 	"strings"
 
@@ -41,8 +40,8 @@ func firewallStateLinux() string {
 }
 
 func firewallStateMacOS() string {
-	cmd := exec.Command(osxFirewallCmd, "--getglobalstate")
-	str := wtf.ExecuteCommand(cmd)
+	cmd := wtf.NewCommand(osxFirewallCmd, "--getglobalstate")
+	str := cmd.Run().Output()
 
 	return statusLabel(str)
 }
@@ -52,8 +51,8 @@ func firewallStealthStateLinux() string {
 }
 
 func firewallStealthStateMacOS() string {
-	cmd := exec.Command(osxFirewallCmd, "--getstealthmode")
-	str := wtf.ExecuteCommand(cmd)
+	cmd := wtf.NewCommand(osxFirewallCmd, "--getstealthmode")
+	str := cmd.Run().Output()
 
 	return statusLabel(str)
 }
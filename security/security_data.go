@@ -1,5 +1,9 @@
 package security
 
+const ConfigDefaults = `
+refreshInterval: 3600
+`
+
 type SecurityData struct {
 	Dns             []string
 	FirewallEnabled string
@@ -5,7 +5,6 @@ package security
 // http://applehelpwriter.com/2017/05/21/how-to-reveal-hidden-users/
 
 import (
-	"os/exec"
 	"runtime"
 	"strings"
 
@@ -50,8 +49,8 @@ func cleanUsers(users []string) []string {
 }
 
 func loggedInUsersLinux() []string {
-	cmd := exec.Command("who", "-us")
-	users := wtf.ExecuteCommand(cmd)
+	cmd := wtf.NewCommand("who", "-us")
+	users := cmd.Run().Output()
 
 	cleaned := []string{}
 
@@ -78,8 +77,8 @@ func loggedInUsersLinux() []string {
 }
 
 func loggedInUsersMacOs() []string {
-	cmd := exec.Command("dscl", []string{".", "-list", "/Users"}...)
-	users := wtf.ExecuteCommand(cmd)
+	cmd := wtf.NewCommand("dscl", []string{".", "-list", "/Users"}...)
+	users := cmd.Run().Output()
 
 	return cleanUsers(strings.Split(users, "\n"))
 }
@@ -3,15 +3,14 @@
 package security
 
 import (
-	"os/exec"
 	"strings"
 
 	"github.com/senorprogrammer/wtf/wtf"
 )
 
 func LoggedInUsers() []string {
-	cmd := exec.Command("powershell.exe", "(query user) -replace '\\s{2,}', ','")
-	users := wtf.ExecuteCommand(cmd)
+	cmd := wtf.NewCommand("powershell.exe", "(query user) -replace '\\s{2,}', ','")
+	users := cmd.Run().Output()
 	return cleanUsers(strings.Split(users, "\n")[1:])
 }
 
@@ -29,7 +29,7 @@ func (widget *Widget) Refresh() {
 	data.Fetch()
 
 	widget.UpdateRefreshedAt()
-	widget.View.SetText(widget.contentFrom(data))
+	widget.SetText(widget.contentFrom(data))
 }
 
 /* -------------------- Unexported Functions -------------------- */
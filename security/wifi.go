@@ -1,7 +1,6 @@
 package security
 
 import (
-	"os/exec"
 	"runtime"
 	"strings"
 
@@ -43,8 +42,8 @@ func WifiName() string {
 /* -------------------- Unexported Functions -------------------- */
 
 func wifiEncryptionLinux() string {
-	cmd := exec.Command("nmcli", "-t", "-f", "active,security", "dev", "wifi")
-	out := wtf.ExecuteCommand(cmd)
+	cmd := wtf.NewCommand("nmcli", "-t", "-f", "active,security", "dev", "wifi")
+	out := cmd.Run().Output()
 
 	name := wtf.FindMatch(`yes:(.+)`, out)
 
@@ -61,13 +60,13 @@ func wifiEncryptionMacOS() string {
 }
 
 func wifiInfo() string {
-	cmd := exec.Command(osxWifiCmd, osxWifiArg)
-	return wtf.ExecuteCommand(cmd)
+	cmd := wtf.NewCommand(osxWifiCmd, osxWifiArg)
+	return cmd.Run().Output()
 }
 
 func wifiNameLinux() string {
-	cmd := exec.Command("nmcli", "-t", "-f", "active,ssid", "dev", "wifi")
-	out := wtf.ExecuteCommand(cmd)
+	cmd := wtf.NewCommand("nmcli", "-t", "-f", "active,ssid", "dev", "wifi")
+	out := cmd.Run().Output()
 	name := wtf.FindMatch(`yes:(.+)`, out)
 	if len(name) > 0 {
 		return name[0][1]
@@ -98,12 +97,12 @@ func wifiNameWindows() string {
 }
 
 func parseWlanNetsh(target string) string {
-	cmd := exec.Command("netsh.exe", "wlan", "show", "interfaces")
-	out, err := cmd.Output()
-	if err != nil {
+	cmd := wtf.NewCommand("netsh.exe", "wlan", "show", "interfaces")
+	result := cmd.Run()
+	if result.Err != nil {
 		return ""
 	}
-	splits := strings.Split(string(out), "\n")
+	splits := strings.Split(result.Stdout, "\n")
 	var words []string
 	for _, line := range splits {
 		token := strings.Split(string(line), ":")
@@ -17,6 +17,10 @@ const HelpText = `
 		[l] for Next Song
 `
 
+const ConfigDefaults = `
+refreshInterval: 5
+`
+
 type Widget struct {
 	wtf.HelpfulWidget
 	wtf.TextWidget
@@ -56,9 +60,9 @@ func (w *Widget) render() {
 	err := w.refreshSpotifyInfos()
 	w.View.Clear()
 	if err != nil {
-		w.TextWidget.View.SetText(err.Error())
+		w.SetText(err.Error())
 	} else {
-		w.TextWidget.View.SetText(w.createOutput())
+		w.SetText(w.createOutput())
 	}
 }
 
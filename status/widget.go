@@ -5,6 +5,10 @@ import (
 	"github.com/senorprogrammer/wtf/wtf"
 )
 
+const ConfigDefaults = `
+refreshInterval: 1
+`
+
 type Widget struct {
 	wtf.TextWidget
 
@@ -24,7 +28,7 @@ func NewWidget(app *tview.Application) *Widget {
 
 func (widget *Widget) Refresh() {
 	widget.UpdateRefreshedAt()
-	widget.View.SetText(widget.animation())
+	widget.SetText(widget.animation())
 }
 
 /* -------------------- Unexported Functions -------------------- */
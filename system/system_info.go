@@ -3,7 +3,6 @@
 package system
 
 import (
-	"os/exec"
 	"runtime"
 	"strings"
 
@@ -21,18 +20,18 @@ func NewSystemInfo() *SystemInfo {
 
 	arg := []string{}
 
-	var cmd *exec.Cmd
+	var cmd *wtf.Command
 	switch runtime.GOOS {
 	case "linux":
 		arg = append(arg, "-a")
-		cmd = exec.Command("lsb_release", arg...)
+		cmd = wtf.NewCommand("lsb_release", arg...)
 	case "darwin":
-		cmd = exec.Command("sw_vers", arg...)
+		cmd = wtf.NewCommand("sw_vers", arg...)
 	default:
-		cmd = exec.Command("sw_vers", arg...)
+		cmd = wtf.NewCommand("sw_vers", arg...)
 	}
 
-	raw := wtf.ExecuteCommand(cmd)
+	raw := cmd.Run().Output()
 
 	for _, row := range strings.Split(raw, "\n") {
 		parts := strings.Split(row, ":")
@@ -3,8 +3,9 @@
 package system
 
 import (
-	"os/exec"
 	"strings"
+
+	"github.com/senorprogrammer/wtf/wtf"
 )
 
 type SystemInfo struct {
@@ -16,12 +17,12 @@ type SystemInfo struct {
 func NewSystemInfo() *SystemInfo {
 	m := make(map[string]string)
 
-	cmd := exec.Command("powershell.exe", "(Get-CimInstance Win32_OperatingSystem).version")
-	out, err := cmd.Output()
-	if err != nil {
-		panic(err)
+	cmd := wtf.NewCommand("powershell.exe", "(Get-CimInstance Win32_OperatingSystem).version")
+	result := cmd.Run()
+	if result.Err != nil {
+		panic(result.Err)
 	}
-	s := strings.Split(string(out), ".")
+	s := strings.Split(result.Stdout, ".")
 	m["ProductName"] = "Windows"
 	m["ProductVersion"] = "Windows " + s[0] + "." + s[1]
 	m["BuildVersion"] = s[2]
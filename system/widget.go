@@ -8,6 +8,10 @@ import (
 	"github.com/senorprogrammer/wtf/wtf"
 )
 
+const ConfigDefaults = `
+refreshInterval: 3600
+`
+
 type Widget struct {
 	wtf.TextWidget
 
@@ -32,7 +36,7 @@ func NewWidget(app *tview.Application, date, version string) *Widget {
 func (widget *Widget) Refresh() {
 	widget.UpdateRefreshedAt()
 
-	widget.View.SetText(
+	widget.SetText(
 		fmt.Sprintf(
 			"%8s: %s\n%8s: %s\n\n%8s: %s\n%8s: %s",
 			"Built",
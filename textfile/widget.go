@@ -1,17 +1,12 @@
 package textfile
 
 import (
-	"bytes"
 	"fmt"
 	"io/ioutil"
 	"log"
-	"os"
 	"path/filepath"
 	"time"
 
-	"github.com/alecthomas/chroma/formatters"
-	"github.com/alecthomas/chroma/lexers"
-	"github.com/alecthomas/chroma/styles"
 	"github.com/gdamore/tcell"
 	"github.com/radovskyb/watcher"
 	"github.com/rivo/tview"
@@ -22,18 +17,31 @@ const HelpText = `
   Keyboard commands for Textfile:
 
     /: Show/hide this help window
+    f: Search the text file
     h: Previous text file
     l: Next text file
+    n: Next search match
+    N: Previous search match
     o: Open the text file in the operating system
 
     arrow left:  Previous text file
     arrow right: Next text file
 `
 
+const ConfigDefaults = `
+filePaths:
+- "~/.config/wtf/config.yml"
+format: false
+formatStyle: "vim"
+refreshInterval: 15
+`
+
 type Widget struct {
 	wtf.HelpfulWidget
 	wtf.MultiSourceWidget
 	wtf.TextWidget
+
+	scrollRestored bool
 }
 
 func NewWidget(app *tview.Application, pages *tview.Pages) *Widget {
@@ -51,6 +59,8 @@ func NewWidget(app *tview.Application, pages *tview.Pages) *Widget {
 
 	widget.HelpfulWidget.SetView(widget.View)
 
+	widget.SetFilterMode(wtf.FilterMatches)
+
 	widget.View.SetWrap(true)
 	widget.View.SetWordWrap(true)
 	widget.View.SetInputCapture(widget.keyboardIntercept)
@@ -63,9 +73,15 @@ func NewWidget(app *tview.Application, pages *tview.Pages) *Widget {
 /* -------------------- Exported Functions -------------------- */
 
 // Refresh is only called once on start-up. Its job is to display the
-// text files that first time. After that, the watcher takes over
+// text files that first time. After that, the watcher takes over. The
+// first time, the file is scrolled to where it was when the app last ran
 func (widget *Widget) Refresh() {
 	widget.display()
+
+	if !widget.scrollRestored {
+		widget.scrollRestored = true
+		widget.View.ScrollTo(wtf.State.Get(widget.ConfigKey()).ScrollRow, 0)
+	}
 }
 
 /* -------------------- Unexported Functions -------------------- */
@@ -84,7 +100,7 @@ func (widget *Widget) display() {
 
 	//widget.View.Lock()
 	widget.View.SetTitle(title) // <- Writes to TextView's title
-	widget.View.SetText(text)   // <- Writes to TextView's text
+	widget.SetText(text)        // <- Writes to TextView's text
 	//widget.View.Unlock()
 }
 
@@ -95,32 +111,14 @@ func (widget *Widget) fileName() string {
 func (widget *Widget) formattedText() string {
 	filePath, _ := wtf.ExpandHomeDir(widget.CurrentSource())
 
-	file, err := os.Open(filePath)
+	contents, err := ioutil.ReadFile(filePath)
 	if err != nil {
 		return err.Error()
 	}
 
-	lexer := lexers.Match(filePath)
-	if lexer == nil {
-		lexer = lexers.Fallback
-	}
-
-	style := styles.Get(wtf.Config.UString("wtf.mods.textfile.formatStyle", "vim"))
-	if style == nil {
-		style = styles.Fallback
-	}
-	formatter := formatters.Get("terminal256")
-	if formatter == nil {
-		formatter = formatters.Fallback
-	}
-
-	contents, _ := ioutil.ReadAll(file)
-	iterator, _ := lexer.Tokenise(nil, string(contents))
-
-	var buf bytes.Buffer
-	formatter.Format(&buf, style, iterator)
+	style := wtf.Config.UString("wtf.mods.textfile.formatStyle", "vim")
 
-	return tview.TranslateANSI(buf.String())
+	return wtf.HighlightSyntax(string(contents), filePath, style)
 }
 
 func (widget *Widget) plainText() string {
@@ -32,7 +32,7 @@ func (widget *Widget) display() {
 	widget.SetList(newList)
 
 	widget.View.Clear()
-	widget.View.SetText(str)
+	widget.SetText(str)
 	widget.View.Highlight(strconv.Itoa(widget.list.Selected)).ScrollToHighlight()
 }
 
@@ -48,11 +48,14 @@ func (widget *Widget) formattedItemLine(idx int, item *checklist.ChecklistItem,
 		backColor = wtf.Config.UString("wtf.colors.highlight.back", "orange")
 	}
 
+	indicator := wtf.SelectionIndicator(widget.View.HasFocus() && (item == selectedItem))
+
 	str := fmt.Sprintf(
-		`["%d"][""][%s:%s]|%s| %s[white]`,
+		`["%d"][""][%s:%s]%s|%s| %s[white]`,
 		idx,
 		foreColor,
 		backColor,
+		indicator,
 		item.CheckMark(),
 		tview.Escape(item.Text),
 	)
@@ -62,5 +65,5 @@ func (widget *Widget) formattedItemLine(idx int, item *checklist.ChecklistItem,
 		maxLen = w
 	}
 
-	return str + wtf.PadRow((checkWidth+len(item.Text)), (checkWidth+maxLen+1)) + "\n"
+	return str + wtf.PadRow((checkWidth+len(indicator)+len(item.Text)), (checkWidth+len(indicator)+maxLen+1)) + "\n"
 }
@@ -20,6 +20,7 @@ const HelpText = `
    k: Select the previous item in the list
    n: Create a new list item
    o: Open the todo file in the operating system
+   y: Copy the selected item's text to the clipboard
 
    arrow down: Select the next item in the list
    arrow up:   Select the previous item in the list
@@ -31,9 +32,11 @@ const HelpText = `
    space:  Check the selected item on or off
 `
 
-const offscreen = -1000
-const modalWidth = 80
-const modalHeight = 7
+const ConfigDefaults = `
+checkedIcon: "x"
+filename: "todo.yml"
+refreshInterval: 3600
+`
 
 type Widget struct {
 	wtf.HelpfulWidget
@@ -80,6 +83,30 @@ func (widget *Widget) SetList(newList checklist.Checklist) {
 	widget.list = newList
 }
 
+// ActionData returns the fields of the selected item for the module's actions
+func (widget *Widget) ActionData() wtf.ActionData {
+	item := widget.list.SelectedItem()
+	if item == nil {
+		return nil
+	}
+
+	return wtf.ActionData{
+		"Checked": item.Checked,
+		"Text":    item.Text,
+	}
+}
+
+// SelectItem selects the item at idx, when it's clicked on
+func (widget *Widget) SelectItem(idx int) {
+	widget.list.Selected = idx
+	widget.display()
+}
+
+// OpenItem opens the selected item for editing, when it's double-clicked
+func (widget *Widget) OpenItem() {
+	widget.editItem()
+}
+
 /* -------------------- Unexported Functions -------------------- */
 
 // edit opens a modal dialog that permits editing the text of the currently-selected item
@@ -88,20 +115,15 @@ func (widget *Widget) editItem() {
 		return
 	}
 
-	form := widget.modalForm("Edit:", widget.list.SelectedItem().Text)
-
-	saveFctn := func() {
-		text := form.GetFormItem(0).(*tview.InputField).GetText()
+	form := widget.modalForm("Edit Item", "Edit:", widget.list.SelectedItem().Text)
 
-		widget.list.Update(text)
+	form.SetSubmitFunc(func(values wtf.FormValues) error {
+		widget.list.Update(values["text"])
 		widget.persist()
-		widget.pages.RemovePage("modal")
-		widget.app.SetFocus(widget.View)
-		widget.display()
-	}
+		return nil
+	})
 
-	widget.addButtons(form, saveFctn)
-	widget.modalFocus(form)
+	form.Show()
 }
 
 func (widget *Widget) init() {
@@ -141,6 +163,12 @@ func (widget *Widget) keyboardIntercept(event *tcell.EventKey) *tcell.EventKey {
 		confDir, _ := cfg.ConfigDir()
 		wtf.OpenFile(fmt.Sprintf("%s/%s", confDir, widget.filePath))
 		return nil
+	case "y":
+		// Copy the selected item's text
+		if item := widget.list.SelectedItem(); item != nil {
+			widget.Copy(item.Text)
+		}
+		return nil
 	}
 
 	switch event.Key() {
@@ -196,20 +224,15 @@ func (widget *Widget) load() {
 }
 
 func (widget *Widget) newItem() {
-	form := widget.modalForm("New:", "")
-
-	saveFctn := func() {
-		text := form.GetFormItem(0).(*tview.InputField).GetText()
+	form := widget.modalForm("New Item", "New:", "")
 
-		widget.list.Add(false, text)
+	form.SetSubmitFunc(func(values wtf.FormValues) error {
+		widget.list.Add(false, values["text"])
 		widget.persist()
-		widget.pages.RemovePage("modal")
-		widget.app.SetFocus(widget.View)
-		widget.display()
-	}
+		return nil
+	})
 
-	widget.addButtons(form, saveFctn)
-	widget.modalFocus(form)
+	form.Show()
 }
 
 // persist writes the todo list to Yaml file
@@ -228,55 +251,10 @@ func (widget *Widget) persist() {
 
 /* -------------------- Modal Form -------------------- */
 
-func (widget *Widget) addButtons(form *tview.Form, saveFctn func()) {
-	widget.addSaveButton(form, saveFctn)
-	widget.addCancelButton(form)
-}
-
-func (widget *Widget) addCancelButton(form *tview.Form) {
-	cancelFn := func() {
-		widget.pages.RemovePage("modal")
-		widget.app.SetFocus(widget.View)
-		widget.display()
-	}
-
-	form.AddButton("Cancel", cancelFn)
-	form.SetCancelFunc(cancelFn)
-}
-
-func (widget *Widget) addSaveButton(form *tview.Form, fctn func()) {
-	form.AddButton("Save", fctn)
-}
-
-func (widget *Widget) modalFocus(form *tview.Form) {
-	frame := widget.modalFrame(form)
-	widget.pages.AddPage("modal", frame, false, true)
-	widget.app.SetFocus(frame)
-}
-
-func (widget *Widget) modalForm(lbl, text string) *tview.Form {
-	form := tview.NewForm().
-		SetButtonsAlign(tview.AlignCenter).
-		SetButtonTextColor(tview.Styles.PrimaryTextColor)
-
-	form.AddInputField(lbl, text, 60, nil, nil)
+func (widget *Widget) modalForm(title, lbl, text string) *wtf.ModalForm {
+	form := wtf.NewModalForm(widget.app, widget.pages, title, widget.View)
+	form.AddTextField("text", lbl, text, wtf.Required)
+	form.SetCloseFunc(widget.display)
 
 	return form
 }
-
-func (widget *Widget) modalFrame(form *tview.Form) *tview.Frame {
-	frame := tview.NewFrame(form).SetBorders(0, 0, 0, 0, 0, 0)
-	frame.SetRect(offscreen, offscreen, modalWidth, modalHeight)
-	frame.SetBorder(true)
-	frame.SetBorders(1, 1, 0, 0, 1, 1)
-
-	drawFunc := func(screen tcell.Screen, x, y, width, height int) (int, int, int, int) {
-		w, h := screen.Size()
-		frame.SetRect((w/2)-(width/2), (h/2)-(height/2), width, height)
-		return x, y, width, height
-	}
-
-	frame.SetDrawFunc(drawFunc)
-
-	return frame
-}
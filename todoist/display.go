@@ -31,10 +31,14 @@ func (widget *Widget) display() {
 			backColor = wtf.Config.UString("wtf.colors.highlight.back", "orange")
 		}
 
+		indicator := wtf.SelectionIndicator(index == proj.index)
+
 		row := fmt.Sprintf(
-			"[%s:%s]| | %s[white]",
+			`["%d"][""][%s:%s]%s| | %s[white]`,
+			index,
 			foreColor,
 			backColor,
+			indicator,
 			tview.Escape(item.Content),
 		)
 
@@ -43,9 +47,9 @@ func (widget *Widget) display() {
 			maxLen = w
 		}
 
-		str = str + row + wtf.PadRow((checkWidth+len(item.Content)), (checkWidth+maxLen+1)) + "\n"
+		str = str + row + wtf.PadRow((checkWidth+len(indicator)+len(item.Content)), (checkWidth+len(indicator)+maxLen+1)) + "\n"
 	}
 
 	//widget.View.Clear()
-	widget.View.SetText(str)
+	widget.SetText(str)
 }
@@ -99,6 +99,26 @@ func (proj *Project) closeSelectedTask() {
 	}
 }
 
+// saveTask creates the task in the project if it's new, or updates it otherwise
+func (proj *Project) saveTask(task todoist.Task) error {
+	var err error
+
+	if task.ID == 0 {
+		task.ProjectID = proj.ID
+		_, err = todoist.CreateTask(task)
+	} else {
+		err = task.Update()
+	}
+
+	if err != nil {
+		return err
+	}
+
+	proj.loadTasks()
+
+	return nil
+}
+
 func (proj *Project) deleteSelectedTask() {
 	currTask := proj.currentTask()
 
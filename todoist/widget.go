@@ -2,6 +2,8 @@ package todoist
 
 import (
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/darkSasori/todoist"
 	"github.com/gdamore/tcell"
@@ -19,18 +21,33 @@ const HelpText = `
    j: Select the next item in the list
    k: Select the previous item in the list
    l: Next Todoist list
+   n: Create a new item
    r: Refresh the todo list data
+   y: Copy the selected item's text to the clipboard
 
    arrow down: Select the next item in the list
    arrow left: Previous Todoist list
    arrow right: Next Todoist list
    arrow up: Select the previous item in the list
+
+   return: Edit the selected item
+`
+
+const ConfigDefaults = `
+apiKey: ""
+projects: []
+refreshInterval: 3600
 `
 
+// priorities are the task priorities, from normal to urgent
+var priorities = []string{"1", "2", "3", "4"}
+
 type Widget struct {
 	wtf.HelpfulWidget
 	wtf.TextWidget
 
+	app      *tview.Application
+	pages    *tview.Pages
 	projects []*Project
 	idx      int
 }
@@ -39,12 +56,16 @@ func NewWidget(app *tview.Application, pages *tview.Pages) *Widget {
 	widget := Widget{
 		HelpfulWidget: wtf.NewHelpfulWidget(app, pages, HelpText),
 		TextWidget:    wtf.NewTextWidget(app, "Todoist", "todoist", true),
+
+		app:   app,
+		pages: pages,
 	}
 
 	widget.loadAPICredentials()
 	widget.projects = loadProjects()
 
 	widget.HelpfulWidget.SetView(widget.View)
+	widget.View.SetRegions(true)
 	widget.View.SetInputCapture(widget.keyboardIntercept)
 
 	return &widget
@@ -129,6 +150,38 @@ func (w *Widget) Delete() {
 	w.Down()
 }
 
+// ActionData returns the fields of the selected item for the module's actions
+func (w *Widget) ActionData() wtf.ActionData {
+	if w.CurrentProject() == nil {
+		return nil
+	}
+
+	task := w.CurrentProject().currentTask()
+	if task == nil {
+		return nil
+	}
+
+	return wtf.ActionData{
+		"Text": task.Content,
+	}
+}
+
+// SelectItem selects the task at idx, when it's clicked on
+func (w *Widget) SelectItem(idx int) {
+	proj := w.CurrentProject()
+	if proj == nil {
+		return
+	}
+
+	proj.index = idx
+	w.display()
+}
+
+// OpenItem opens the selected task for editing, when it's double-clicked
+func (w *Widget) OpenItem() {
+	w.editTask()
+}
+
 /* -------------------- Unexported Functions -------------------- */
 
 func (w *Widget) keyboardIntercept(event *tcell.EventKey) *tcell.EventKey {
@@ -149,6 +202,12 @@ func (w *Widget) keyboardIntercept(event *tcell.EventKey) *tcell.EventKey {
 	case "c":
 		w.Close()
 		return nil
+	case "n":
+		w.newTask()
+		return nil
+	case "y":
+		w.copyTask()
+		return nil
 	}
 
 	switch w.vimBindings(event) {
@@ -164,11 +223,72 @@ func (w *Widget) keyboardIntercept(event *tcell.EventKey) *tcell.EventKey {
 	case tcell.KeyDown:
 		w.Down()
 		return nil
+	case tcell.KeyEnter:
+		w.editTask()
+		return nil
 	}
 
 	return event
 }
 
+func (w *Widget) copyTask() {
+	if w.CurrentProject() == nil {
+		return
+	}
+
+	if task := w.CurrentProject().currentTask(); task != nil {
+		w.Copy(task.Content)
+	}
+}
+
+// editTask opens a form for editing the selected task
+func (w *Widget) editTask() {
+	if w.CurrentProject() == nil {
+		return
+	}
+
+	task := w.CurrentProject().currentTask()
+	if task == nil {
+		return
+	}
+
+	w.showTaskForm("Edit Task", *task)
+}
+
+// newTask opens a form for creating a task in the current project, if there is one
+func (w *Widget) newTask() {
+	w.showTaskForm("New Task", todoist.Task{Priority: 1})
+}
+
+func (w *Widget) showTaskForm(title string, task todoist.Task) {
+	project := w.CurrentProject()
+	if project == nil {
+		return
+	}
+
+	due, _ := time.Parse(wtf.DateFormat, task.Due.Date)
+
+	form := wtf.NewModalForm(w.app, w.pages, title, w.View).
+		AddTextField("content", "Task:", task.Content, wtf.Required).
+		AddDateField("due", "Due:", due).
+		AddDropDown("priority", "Priority (4 is urgent):", priorities, strconv.Itoa(task.Priority))
+
+	form.SetSubmitFunc(func(values wtf.FormValues) error {
+		task.Content = values["content"]
+		// Keep the due date as it was, including any recurrence, unless it changed
+		if values["due"] != task.Due.Date {
+			task.Due = todoist.Due{String: values["due"]}
+		}
+		task.Priority, _ = strconv.Atoi(values["priority"])
+
+		return project.saveTask(task)
+	})
+
+	form.SubmitInBackground()
+	form.SetCloseFunc(w.display)
+	form.Show()
+}
+
 func (widget *Widget) loadAPICredentials() {
 	todoist.Token = wtf.Config.UString(
 		"wtf.mods.todoist.apiKey",
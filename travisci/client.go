@@ -57,7 +57,7 @@ func travisRequest(path string) (*http.Response, error) {
 		return nil, err
 	}
 
-	httpClient := &http.Client{}
+	httpClient := wtf.NewHTTPClient("travisci", nil)
 	resp, err := httpClient.Do(req)
 	if err != nil {
 		return nil, err
@@ -15,6 +15,7 @@ const HelpText = `
    j: Select the next build in the list
    k: Select the previous build in the list
    r: Refresh the data
+   y: Copy the selected build's URL to the clipboard
 
    arrow down: Select the next build in the list
    arrow up:   Select the previous build in the list
@@ -22,6 +23,12 @@ const HelpText = `
    return: Open the selected build in a browser
 `
 
+const ConfigDefaults = `
+apiKey: ""
+pro: false
+refreshInterval: 900
+`
+
 type Widget struct {
 	wtf.HelpfulWidget
 	wtf.TextWidget
@@ -37,8 +44,10 @@ func NewWidget(app *tview.Application, pages *tview.Pages) *Widget {
 	}
 
 	widget.HelpfulWidget.SetView(widget.View)
+	widget.SetRateLimitHost("api." + TRAVIS_HOSTS[wtf.Config.UBool("wtf.mods.travisci.pro", false)])
 	widget.unselect()
 
+	widget.View.SetRegions(true)
 	widget.View.SetInputCapture(widget.keyboardIntercept)
 
 	return &widget
@@ -58,7 +67,7 @@ func (widget *Widget) Refresh() {
 	if err != nil {
 		widget.View.SetWrap(true)
 		widget.View.SetTitle(widget.Name)
-		widget.View.SetText(err.Error())
+		widget.SetText(err.Error())
 	} else {
 		widget.builds = builds
 	}
@@ -66,6 +75,35 @@ func (widget *Widget) Refresh() {
 	widget.display()
 }
 
+// ActionData returns the fields of the selected build for the module's actions
+func (widget *Widget) ActionData() wtf.ActionData {
+	sel := widget.selected
+	if sel < 0 || widget.builds == nil || sel >= len(widget.builds.Builds) {
+		return nil
+	}
+
+	build := &widget.builds.Builds[sel]
+
+	return wtf.ActionData{
+		"Branch": build.Branch.Name,
+		"Number": build.Number,
+		"Repo":   build.Repository.Slug,
+		"State":  build.State,
+		"URL":    buildURL(build),
+	}
+}
+
+// SelectItem selects the build at idx, when it's clicked on
+func (widget *Widget) SelectItem(idx int) {
+	widget.selected = idx
+	widget.display()
+}
+
+// OpenItem opens the selected build in a browser, when it's double-clicked
+func (widget *Widget) OpenItem() {
+	widget.openBuild()
+}
+
 /* -------------------- Unexported Functions -------------------- */
 
 func (widget *Widget) display() {
@@ -76,7 +114,7 @@ func (widget *Widget) display() {
 	widget.View.SetWrap(false)
 
 	widget.View.SetTitle(widget.ContextualTitle(fmt.Sprintf("%s - Builds", widget.Name)))
-	widget.View.SetText(widget.contentFrom(widget.builds))
+	widget.SetText(widget.contentFrom(widget.builds))
 }
 
 func (widget *Widget) contentFrom(builds *Builds) string {
@@ -84,9 +122,12 @@ func (widget *Widget) contentFrom(builds *Builds) string {
 	for idx, build := range builds.Builds {
 
 		str = str + fmt.Sprintf(
-			"[%s] [%s] %s-%s (%s) [%s]%s - [blue]%s\n",
+			`["%d"][""][%s] %s[%s] %s%s-%s (%s) [%s]%s - [blue]%s`+"\n",
+			idx,
 			widget.rowColor(idx),
+			wtf.SelectionIndicator(widget.View.HasFocus() && idx == widget.selected),
 			buildColor(&build),
+			wtf.StatusMarker(buildColor(&build)),
 			build.Repository.Name,
 			build.Number,
 			build.Branch.Name,
@@ -148,12 +189,22 @@ func (widget *Widget) prev() {
 func (widget *Widget) openBuild() {
 	sel := widget.selected
 	if sel >= 0 && widget.builds != nil && sel < len(widget.builds.Builds) {
-		build := &widget.builds.Builds[widget.selected]
-		travisHost := TRAVIS_HOSTS[wtf.Config.UBool("wtf.mods.travisci.pro", false)]
-		wtf.OpenFile(fmt.Sprintf("https://%s/%s/%s/%d", travisHost, build.Repository.Slug, "builds", build.ID))
+		wtf.OpenFile(buildURL(&widget.builds.Builds[sel]))
 	}
 }
 
+func (widget *Widget) copyBuild() {
+	sel := widget.selected
+	if sel >= 0 && widget.builds != nil && sel < len(widget.builds.Builds) {
+		widget.Copy(buildURL(&widget.builds.Builds[sel]))
+	}
+}
+
+func buildURL(build *Build) string {
+	travisHost := TRAVIS_HOSTS[wtf.Config.UBool("wtf.mods.travisci.pro", false)]
+	return fmt.Sprintf("https://%s/%s/%s/%d", travisHost, build.Repository.Slug, "builds", build.ID)
+}
+
 func (widget *Widget) unselect() {
 	widget.selected = -1
 	widget.display()
@@ -172,6 +223,9 @@ func (widget *Widget) keyboardIntercept(event *tcell.EventKey) *tcell.EventKey {
 	case "r":
 		widget.Refresh()
 		return nil
+	case "y":
+		widget.copyBuild()
+		return nil
 	}
 
 	switch event.Key() {
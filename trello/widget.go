@@ -9,6 +9,15 @@ import (
 	"github.com/senorprogrammer/wtf/wtf"
 )
 
+const ConfigDefaults = `
+accessToken: ""
+apiKey: ""
+board: ""
+list: ""
+refreshInterval: 3600
+username: ""
+`
+
 type Widget struct {
 	wtf.TextWidget
 }
@@ -50,7 +59,7 @@ func (widget *Widget) Refresh() {
 		content = widget.contentFrom(searchResult)
 	}
 
-	widget.View.SetText(content)
+	widget.SetText(content)
 }
 
 /* -------------------- Unexported Functions -------------------- */
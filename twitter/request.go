@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"fmt"
 	"net/http"
+
+	"github.com/senorprogrammer/wtf/wtf"
 )
 
 func Request(bearerToken string, apiURL string) ([]byte, error) {
@@ -16,7 +18,7 @@ func Request(bearerToken string, apiURL string) ([]byte, error) {
 	req.Header.Add("Authorization",
 		fmt.Sprintf("Bearer %s", bearerToken))
 
-	client := &http.Client{}
+	client := wtf.NewHTTPClient("twitter", nil)
 	resp, err := client.Do(req)
 	if err != nil {
 		return nil, err
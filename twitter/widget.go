@@ -22,6 +22,13 @@ const HelpText = `
     arrow right: Next Twitter name
 `
 
+const ConfigDefaults = `
+bearerToken: ""
+count: 5
+refreshInterval: 20000
+screenNames: []
+`
+
 type Widget struct {
 	wtf.HelpfulWidget
 	wtf.MultiSourceWidget
@@ -45,6 +52,7 @@ func NewWidget(app *tview.Application, pages *tview.Pages) *Widget {
 	widget.SetDisplayFunction(widget.display)
 
 	widget.client = NewClient()
+	widget.SetRateLimitHost(wtf.HostFor(widget.client.apiBase, "api.twitter.com"))
 
 	widget.View.SetBorderPadding(1, 1, 1, 1)
 	widget.View.SetWrap(true)
@@ -72,7 +80,7 @@ func (widget *Widget) display() {
 
 	if len(tweets) == 0 {
 		str := fmt.Sprintf("\n\n\n%s", wtf.CenterText("[blue]No Tweets[white]", 50))
-		widget.View.SetText(str)
+		widget.SetText(str)
 		return
 	}
 
@@ -81,7 +89,7 @@ func (widget *Widget) display() {
 		str = str + widget.format(tweet)
 	}
 
-	widget.View.SetText(str)
+	widget.SetText(str)
 }
 
 // If the tweet's Username is the same as the account we're watching, no
@@ -9,6 +9,14 @@ import (
 	"github.com/senorprogrammer/wtf/wtf"
 )
 
+const ConfigDefaults = `
+city: ""
+language: "en"
+refreshInterval: 300
+unit: "m"
+view: "0"
+`
+
 type Widget struct {
 	wtf.TextWidget
 	result   string
@@ -30,7 +38,7 @@ func (widget *Widget) Refresh() {
 	widget.UpdateRefreshedAt()
 	widget.prettyWeather()
 
-	widget.View.SetText(widget.result)
+	widget.SetText(widget.result)
 }
 
 //this method reads the config and calls wttr.in for pretty weather
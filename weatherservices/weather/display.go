@@ -11,18 +11,18 @@ import (
 func (widget *Widget) display() {
 
 	if widget.apiKeyValid() == false {
-		widget.View.SetText(" Environment variable WTF_OWM_API_KEY is not set")
+		widget.SetText(" Environment variable WTF_OWM_API_KEY is not set")
 		return
 	}
 
 	cityData := widget.currentData()
 	if cityData == nil {
-		widget.View.SetText(" Weather data is unavailable: no city data")
+		widget.SetText(" Weather data is unavailable: no city data")
 		return
 	}
 
 	if len(cityData.Weather) == 0 {
-		widget.View.SetText(" Weather data is unavailable: no weather data")
+		widget.SetText(" Weather data is unavailable: no weather data")
 		return
 	}
 
@@ -33,7 +33,7 @@ func (widget *Widget) display() {
 	content = content + widget.temperatures(cityData) + "\n"
 	content = content + widget.sunInfo(cityData)
 
-	widget.View.SetText(content)
+	widget.SetText(content)
 }
 
 func (widget *Widget) description(cityData *owm.CurrentWeatherData) string {
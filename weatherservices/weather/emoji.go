@@ -2,6 +2,7 @@ package weather
 
 import (
 	owm "github.com/briandowns/openweathermap"
+	"github.com/senorprogrammer/wtf/wtf"
 )
 
 var weatherEmoji = map[string]string{
@@ -39,6 +40,11 @@ func (widget *Widget) emojiFor(data *owm.CurrentWeatherData) string {
 		return ""
 	}
 
+	// In accessibility mode the description stands in for the emoji
+	if wtf.Accessible() {
+		return "(" + data.Weather[0].Description + ")"
+	}
+
 	emoji := weatherEmoji[data.Weather[0].Description]
 	if emoji == "" {
 		emoji = weatherEmoji["default"]
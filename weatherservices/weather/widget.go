@@ -20,6 +20,16 @@ const HelpText = `
     arrow right: Next weather location
 `
 
+const ConfigDefaults = `
+apiKey: ""
+cityids: []
+colors:
+  current: "green"
+language: "EN"
+refreshInterval: 900
+tempUnit: "C"
+`
+
 // Widget is the container for weather data.
 type Widget struct {
 	wtf.HelpfulWidget
@@ -0,0 +1,95 @@
+package wtf
+
+import (
+	"github.com/gdamore/tcell"
+	"github.com/rivo/tview"
+)
+
+// statusMarkers maps the colors modules use to convey a status onto the text
+// markers that replace them in accessibility mode
+var statusMarkers = map[string]string{
+	"blue":   "[OK]",
+	"green":  "[OK]",
+	"red":    "[FAIL]",
+	"yellow": "[RUN]",
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// Accessible returns true if the monochrome, ASCII-only rendering mode is enabled
+func Accessible() bool {
+	return Config.UBool("wtf.accessibility", false)
+}
+
+// DrawMonochrome is used as the app's after-draw function. In accessibility mode
+// it strips all the colors from the screen, reversing the highlighted rows
+// so that the selection remains visible
+func DrawMonochrome(screen tcell.Screen) {
+	if !Accessible() {
+		return
+	}
+
+	highlight := colorFor(Config.UString("wtf.colors.highlight.back", "orange"))
+
+	width, height := screen.Size()
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			mainc, combc, style, cellWidth := screen.GetContent(x, y)
+			_, bg, attrs := style.Decompose()
+
+			mono := tcell.StyleDefault.
+				Bold(attrs&tcell.AttrBold != 0).
+				Underline(attrs&tcell.AttrUnderline != 0).
+				Reverse(attrs&tcell.AttrReverse != 0 || bg == highlight)
+
+			screen.SetContent(x, y, mainc, combc, mono)
+
+			if cellWidth > 1 {
+				x += cellWidth - 1
+			}
+		}
+	}
+}
+
+// Glyph returns ascii in accessibility mode, and glyph otherwise. Use it for
+// the defaults of emoji and other symbols that not every terminal can display
+func Glyph(glyph, ascii string) string {
+	if Accessible() {
+		return ascii
+	}
+
+	return glyph
+}
+
+// SelectionIndicator returns the symbol that prefixes a list row in accessibility
+// mode, so that the selected row isn't marked by its highlight alone. Returns an
+// empty string when accessibility mode is off
+func SelectionIndicator(selected bool) string {
+	if !Accessible() {
+		return ""
+	}
+
+	if selected {
+		return "> "
+	}
+
+	return "  "
+}
+
+// StatusMarker returns the text marker, i.e.: [OK], [FAIL], for a status that's
+// otherwise conveyed by the given color, escaped so that tview doesn't mistake it
+// for a color tag. Returns an empty string when accessibility mode is off, or if
+// the color doesn't convey a status
+func StatusMarker(color string) string {
+	if !Accessible() {
+		return ""
+	}
+
+	marker, ok := statusMarkers[color]
+	if !ok {
+		return ""
+	}
+
+	return tview.Escape(marker) + " "
+}
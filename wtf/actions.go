@@ -0,0 +1,142 @@
+package wtf
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"os/exec"
+	"runtime"
+	"strings"
+	"text/template"
+
+	"github.com/gdamore/tcell"
+)
+
+// ActionData is the data an action's command template is filled in from, i.e.:
+// {"URL": "https://jira.example.com/browse/PROJ-1", "Key": "PROJ-1"}
+type ActionData map[string]interface{}
+
+// Actionable is implemented by widgets whose items can have actions configured
+// in their module's 'actions' setting
+type Actionable interface {
+	// ActionData returns the data of the selected item, or nil if no item
+	// is selected
+	ActionData() ActionData
+}
+
+// actionFuncs are the functions available to action templates
+var actionFuncs = template.FuncMap{
+	"quote": quoteValue,
+	"raw":   rawValue,
+}
+
+// actionValue is a field of an action's data. Fields are shell-quoted when they're
+// filled in, as they come from APIs, unless the template asks for them raw
+type actionValue struct {
+	value interface{}
+}
+
+func (value actionValue) String() string {
+	return shellQuote(value.value)
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// ActionCommand fills in the template with the data, returning the shell command
+// to run. Each field is quoted so that the shell treats it as a single word;
+// {{raw .Field}} fills a field in as it is. If the data has a URL but no Host, the
+// URL's host is provided as Host
+func ActionCommand(tmpl string, data ActionData) (string, error) {
+	parsed, err := template.New("action").Funcs(actionFuncs).Option("missingkey=error").Parse(tmpl)
+	if err != nil {
+		return "", err
+	}
+
+	fields := map[string]actionValue{}
+	for key, value := range data {
+		fields[key] = actionValue{value}
+	}
+
+	if rawURL, ok := data["URL"].(string); ok && data["Host"] == nil {
+		if parsedURL, err := url.Parse(rawURL); err == nil {
+			fields["Host"] = actionValue{parsedURL.Hostname()}
+		}
+	}
+
+	var command bytes.Buffer
+	if err := parsed.Execute(&command, fields); err != nil {
+		return "", err
+	}
+
+	return command.String(), nil
+}
+
+// HandleActionKey runs the action the focused widget's module has configured for
+// the key, if any, on the widget's selected item. The command runs in the
+// background. Actions take precedence over the widget's own keys, except while
+// a filter is being typed. Returns true if the key was handled
+func HandleActionKey(widget Wtfable, event *tcell.EventKey) bool {
+	if widget == nil || event.Key() != tcell.KeyRune || isFilterEditing(widget.TextView()) {
+		return false
+	}
+
+	actionable, ok := widget.(Actionable)
+	if !ok {
+		return false
+	}
+
+	tmpl := Config.UString(fmt.Sprintf("wtf.mods.%s.actions.%s", widget.ConfigKey(), string(event.Rune())))
+	if tmpl == "" {
+		return false
+	}
+
+	data := actionable.ActionData()
+	if data == nil {
+		showNotice(widget.TextView(), "[yellow]nothing selected")
+		return true
+	}
+
+	command, err := ActionCommand(tmpl, data)
+	if err != nil {
+		showNotice(widget.TextView(), "[red]invalid action")
+		return true
+	}
+
+	go func() {
+		if err := shellCommand(command).Run(); err != nil {
+			showNotice(widget.TextView(), "[red]action failed")
+		}
+	}()
+
+	return true
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+func shellCommand(command string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.Command("cmd", "/c", command)
+	}
+
+	return exec.Command("sh", "-c", command)
+}
+
+// quoteValue quotes the value, which is how fields are filled in anyway. It's kept
+// for templates written before they were, i.e.: {{quote .Title}}
+func quoteValue(value interface{}) string {
+	return shellQuote(rawValue(value))
+}
+
+// rawValue returns the field as it is, without quoting it, i.e.: {{raw .Number}}
+func rawValue(value interface{}) string {
+	if field, ok := value.(actionValue); ok {
+		return fmt.Sprint(field.value)
+	}
+
+	return fmt.Sprint(value)
+}
+
+// shellQuote quotes the value so that the shell treats it as a single word
+func shellQuote(value interface{}) string {
+	return "'" + strings.Replace(fmt.Sprint(value), "'", `'\''`, -1) + "'"
+}
@@ -11,6 +11,7 @@ import (
 
 //BarGraph lets make graphs
 type BarGraph struct {
+	configKey   string
 	enabled     bool
 	focusable   bool
 	starChar    string
@@ -28,6 +29,7 @@ type BarGraph struct {
 // NewBarGraph initialize your fancy new graph
 func NewBarGraph(name string, configKey string, focusable bool) BarGraph {
 	widget := BarGraph{
+		configKey:  configKey,
 		enabled:    Config.UBool(fmt.Sprintf("wtf.mods.%s.enabled", configKey), false),
 		focusable:  focusable,
 		starChar:   Config.UString(fmt.Sprintf("wtf.mods.%s.graphIcon", configKey), name),
@@ -56,6 +58,11 @@ func (widget *BarGraph) BorderColor() string {
 	return Config.UString("wtf.colors.border.normal", "gray")
 }
 
+// ConfigKey returns the key this widget is configured under in wtf.mods
+func (widget *BarGraph) ConfigKey() string {
+	return widget.configKey
+}
+
 func (widget *BarGraph) Disable() {
 	widget.enabled = false
 }
@@ -100,6 +107,7 @@ func (widget *BarGraph) addView() {
 	view.SetBackgroundColor(colorFor(Config.UString("wtf.colors.background", "black")))
 	view.SetBorder(true)
 	view.SetBorderColor(colorFor(widget.BorderColor()))
+	view.SetDrawFunc(drawStatus(view))
 	view.SetDynamicColors(true)
 	view.SetTitle(widget.Name)
 	view.SetWrap(false)
@@ -0,0 +1,84 @@
+package wtf
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+/* -------------------- Exported Functions -------------------- */
+
+// CopyToClipboard copies the text to the clipboard of the terminal wtf is
+// displayed in, via the OSC 52 escape sequence, which also works over SSH. If
+// OSC 52 is disabled or the terminal can't be written to, the text is piped
+// to 'wtf.clipboard.command' instead, i.e.: 'pbcopy', 'xclip -selection clipboard'
+func CopyToClipboard(text string) error {
+	if Config.UBool("wtf.clipboard.osc52", true) {
+		err := writeOSC52(text)
+		if err == nil {
+			return nil
+		}
+
+		if Config.UString("wtf.clipboard.command") == "" {
+			return err
+		}
+	}
+
+	return copyWithCommand(text)
+}
+
+// Copy copies the text to the clipboard and briefly displays the outcome in the
+// widget's border
+func (widget *TextWidget) Copy(text string) {
+	if text == "" {
+		return
+	}
+
+	if err := CopyToClipboard(text); err != nil {
+		showNotice(widget.View, "[red]copy failed")
+		return
+	}
+
+	showNotice(widget.View, "[green]copied")
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+func copyWithCommand(text string) error {
+	args := strings.Fields(Config.UString("wtf.clipboard.command"))
+	if len(args) == 0 {
+		return fmt.Errorf("OSC 52 is disabled and wtf.clipboard.command is not set")
+	}
+
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Stdin = strings.NewReader(text)
+
+	return cmd.Run()
+}
+
+// osc52Sequence returns the escape sequence that sets the clipboard to text.
+// Inside tmux the sequence is wrapped so that tmux passes it through to the
+// terminal
+func osc52Sequence(text string, inTmux bool) string {
+	seq := "\x1b]52;c;" + base64.StdEncoding.EncodeToString([]byte(text)) + "\a"
+
+	if inTmux {
+		seq = "\x1bPtmux;\x1b" + seq + "\x1b\\"
+	}
+
+	return seq
+}
+
+func writeOSC52(text string) error {
+	tty, err := os.OpenFile("/dev/tty", os.O_WRONLY, 0)
+	if err != nil {
+		return err
+	}
+	defer tty.Close()
+
+	_, err = tty.WriteString(osc52Sequence(text, os.Getenv("TMUX") != ""))
+
+	return err
+}
@@ -0,0 +1,238 @@
+package wtf
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Command is an external command run with a timeout. When the timeout expires,
+// or the command is stopped, the command's whole process group is killed, so that
+// a hung child process can't keep a widget's refresh waiting forever
+type Command struct {
+	Args    []string
+	Dir     string
+	Env     []string
+	Name    string
+	Stdin   io.Reader
+	Timeout time.Duration
+
+	stopMutex sync.Mutex
+	stop      chan struct{}
+	stopped   bool
+}
+
+// CommandResult is the outcome of running a Command
+type CommandResult struct {
+	ExitCode int
+	Stderr   string
+	Stdout   string
+	Stopped  bool
+	TimedOut bool
+
+	// Err is set if the command couldn't be started, timed out, was stopped,
+	// or exited with a non-zero exit code
+	Err error
+}
+
+// errStopped is the error of a command that was stopped
+var errStopped = errors.New("stopped")
+
+// NewCommand returns a command that times out after the default timeout, set by
+// 'wtf.commandTimeout', in seconds
+func NewCommand(name string, args ...string) *Command {
+	return &Command{
+		Args:    args,
+		Name:    name,
+		Timeout: DefaultCommandTimeout(),
+	}
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// DefaultCommandTimeout returns how long external commands may run before they
+// are killed. Defaults to ten seconds
+func DefaultCommandTimeout() time.Duration {
+	return time.Duration(Config.UInt("wtf.commandTimeout", 10)) * time.Second
+}
+
+// RunCommand runs the named command with the default timeout
+func RunCommand(name string, args ...string) CommandResult {
+	return NewCommand(name, args...).Run()
+}
+
+// Output returns the command's stdout if it succeeded and, if it didn't, the
+// reason why, followed by its stderr
+func (result CommandResult) Output() string {
+	if result.Err == nil {
+		return result.Stdout
+	}
+
+	if stderr := strings.TrimSpace(result.Stderr); stderr != "" {
+		return fmt.Sprintf("%v\n%s\n", result.Err, stderr)
+	}
+
+	return fmt.Sprintf("%v\n", result.Err)
+}
+
+// Run runs the command to completion, or until it times out
+func (cmd *Command) Run() CommandResult {
+	return cmd.Stream(nil)
+}
+
+// Stop kills the command's process group if it's running, and keeps it from
+// starting if it isn't yet. It's safe to call more than once, and from another
+// goroutine than the one running the command
+func (cmd *Command) Stop() {
+	cmd.stopMutex.Lock()
+	defer cmd.stopMutex.Unlock()
+
+	if cmd.stopped {
+		return
+	}
+
+	cmd.stopped = true
+	close(cmd.stopChan())
+}
+
+// Stream runs the command, calling onLine with each line of its stdout as soon as
+// it's written. The result's Stdout is left empty. A zero Timeout lets the command
+// run until it exits, which suits commands that stream indefinitely, i.e.: 'tail -f'
+func (cmd *Command) Stream(onLine func(line string)) CommandResult {
+	execCmd := exec.Command(cmd.Name, cmd.Args...)
+	execCmd.Dir = cmd.Dir
+	execCmd.Stdin = cmd.Stdin
+	if len(cmd.Env) > 0 {
+		execCmd.Env = append(os.Environ(), cmd.Env...)
+	}
+	setProcessGroup(execCmd)
+
+	var stdout, stderr bytes.Buffer
+	execCmd.Stderr = &stderr
+
+	var reader *io.PipeReader
+	var writer *io.PipeWriter
+	var lines sync.WaitGroup
+
+	if onLine == nil {
+		execCmd.Stdout = &stdout
+	} else {
+		reader, writer = io.Pipe()
+		execCmd.Stdout = writer
+
+		lines.Add(1)
+		go func() {
+			defer lines.Done()
+
+			scanner := bufio.NewScanner(reader)
+			for scanner.Scan() {
+				onLine(scanner.Text())
+			}
+
+			// Drain whatever's left so that the command is never blocked writing
+			io.Copy(ioutil.Discard, reader)
+		}()
+	}
+
+	result := CommandResult{}
+
+	cmd.stopMutex.Lock()
+	stop := cmd.stopChan()
+	err := errStopped
+	if !cmd.stopped {
+		err = execCmd.Start()
+	}
+	cmd.stopMutex.Unlock()
+
+	if err != nil {
+		if writer != nil {
+			writer.Close()
+			lines.Wait()
+		}
+
+		result.ExitCode = -1
+		result.Err = err
+
+		return result
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- execCmd.Wait() }()
+
+	var timeout <-chan time.Time
+	if cmd.Timeout > 0 {
+		timer := time.NewTimer(cmd.Timeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	select {
+	case err = <-done:
+	case <-stop:
+		killProcessGroup(execCmd)
+		err = <-done
+		result.Stopped = true
+	case <-timeout:
+		killProcessGroup(execCmd)
+		err = <-done
+		result.TimedOut = true
+	}
+
+	if writer != nil {
+		writer.Close()
+		lines.Wait()
+	}
+
+	result.Stdout = stdout.String()
+	result.Stderr = stderr.String()
+	result.ExitCode = exitCode(execCmd, err)
+
+	switch {
+	case result.Stopped:
+		result.Err = errStopped
+	case result.TimedOut:
+		result.Err = fmt.Errorf("%s timed out after %v", cmd.Name, cmd.Timeout)
+	case err != nil:
+		result.Err = err
+	}
+
+	return result
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// stopChan returns the channel that's closed when the command is stopped. The
+// caller must hold stopMutex
+func (cmd *Command) stopChan() chan struct{} {
+	if cmd.stop == nil {
+		cmd.stop = make(chan struct{})
+	}
+
+	return cmd.stop
+}
+
+func exitCode(execCmd *exec.Cmd, err error) int {
+	if execCmd.ProcessState == nil {
+		return -1
+	}
+
+	if err == nil {
+		return 0
+	}
+
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		if code, ok := exitStatus(exitErr); ok {
+			return code
+		}
+	}
+
+	return -1
+}
@@ -0,0 +1,34 @@
+//go:build !windows
+// +build !windows
+
+package wtf
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// setProcessGroup starts the command in its own process group, so that it can be
+// killed along with any processes it starts
+func setProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+}
+
+func killProcessGroup(cmd *exec.Cmd) {
+	if cmd.Process == nil {
+		return
+	}
+
+	if err := syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL); err != nil {
+		cmd.Process.Kill()
+	}
+}
+
+func exitStatus(err *exec.ExitError) (int, bool) {
+	status, ok := err.Sys().(syscall.WaitStatus)
+	if !ok {
+		return 0, false
+	}
+
+	return status.ExitStatus(), true
+}
@@ -26,6 +26,20 @@ func PrettyDate(dateStr string) string {
 	return fmt.Sprint(newTime.Format("Jan 2, 2006"))
 }
 
+// ShortDuration formats a duration in its largest whole unit, i.e.: 5m, 3h, 2d
+func ShortDuration(duration time.Duration) string {
+	switch {
+	case duration < time.Minute:
+		return fmt.Sprintf("%ds", int(duration.Seconds()))
+	case duration < time.Hour:
+		return fmt.Sprintf("%dm", int(duration.Minutes()))
+	case duration < 24*time.Hour:
+		return fmt.Sprintf("%dh", int(duration.Hours()))
+	default:
+		return fmt.Sprintf("%dd", int(duration.Hours()/24))
+	}
+}
+
 func Tomorrow() time.Time {
 	return Now().AddDate(0, 0, 1)
 }
@@ -45,6 +45,12 @@ func (display *Display) build(widgets []Wtfable) *tview.Grid {
 
 	for _, widget := range widgets {
 		display.add(widget)
+	}
+
+	// Show whatever cached data there is before the first live refresh
+	RefreshFromCache(widgets)
+
+	for _, widget := range widgets {
 		go Schedule(widget)
 	}
 
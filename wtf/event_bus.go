@@ -0,0 +1,112 @@
+package wtf
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+const (
+	// EventIssueSelected is published when an issue is selected. Its data has the
+	// issue's Key, i.e.: PROJ-1, or is nil when the issue is unselected
+	EventIssueSelected = "issue.selected"
+
+	// EventRepoSelected is published when a repository is selected. Its data has
+	// the repository's Owner and Repo names, as they are on its origin remote
+	EventRepoSelected = "repo.selected"
+)
+
+// Event is published by a widget when something happens that other widgets can
+// follow, such as the user selecting an item
+type Event struct {
+	// Source is the config key of the widget that published the event
+	Source string
+	Type   string
+	Data   ActionData
+}
+
+// EventHandler is called with the events published by the widgets a widget follows
+type EventHandler func(Event)
+
+var (
+	eventHandlers = map[string][]EventHandler{}
+	lastEvents    = map[string]Event{}
+	eventMutex    = &sync.Mutex{}
+)
+
+/* -------------------- Exported Functions -------------------- */
+
+// Publish sends the event to the handlers subscribed to its source. An event that
+// is the same as the last one of its type from its source isn't sent again, so
+// widgets can publish their selection every time they're displayed
+func Publish(event Event) {
+	key := event.Source + ":" + event.Type
+
+	eventMutex.Lock()
+	if last, ok := lastEvents[key]; ok && reflect.DeepEqual(last, event) {
+		eventMutex.Unlock()
+		return
+	}
+
+	lastEvents[key] = event
+	handlers := append([]EventHandler{}, eventHandlers[event.Source]...)
+	eventMutex.Unlock()
+
+	for _, handler := range handlers {
+		handler(event)
+	}
+}
+
+// ResetSubscriptions removes all the handlers. It's called when the widgets are
+// rebuilt, so that the old widgets no longer receive events
+func ResetSubscriptions() {
+	eventMutex.Lock()
+	defer eventMutex.Unlock()
+
+	eventHandlers = map[string][]EventHandler{}
+}
+
+// Subscribe calls the handler with each event the source publishes. The handler is
+// called straight away with the last events the source published, if any
+func Subscribe(source string, handler EventHandler) {
+	eventMutex.Lock()
+	eventHandlers[source] = append(eventHandlers[source], handler)
+
+	previous := []Event{}
+	for _, event := range lastEvents {
+		if event.Source == source {
+			previous = append(previous, event)
+		}
+	}
+	eventMutex.Unlock()
+
+	for _, event := range previous {
+		handler(event)
+	}
+}
+
+// Follow subscribes the handler to the widgets named in the module's 'follow'
+// setting, which is either a single config key or a list of them
+func (widget *TextWidget) Follow(handler EventHandler) {
+	configPath := fmt.Sprintf("wtf.mods.%s.follow", widget.configKey)
+
+	sources := ToStrs(Config.UList(configPath))
+	if source, err := Config.String(configPath); err == nil {
+		sources = []string{source}
+	}
+
+	for _, source := range sources {
+		if source != "" && source != widget.configKey {
+			Subscribe(source, handler)
+		}
+	}
+}
+
+// Publish publishes an event of the given type from this widget
+func (widget *TextWidget) Publish(eventType string, data ActionData) {
+	Publish(Event{
+		Source: widget.configKey,
+		Type:   eventType,
+		Data:   data,
+	})
+}
@@ -0,0 +1,495 @@
+package wtf
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gdamore/tcell"
+	"github.com/rivo/tview"
+)
+
+// FilterMode defines what filtering a widget's text does to the lines that don't
+// match the query
+type FilterMode int
+
+const (
+	// FilterLines hides the lines that don't match. Used by list widgets
+	FilterLines FilterMode = iota
+
+	// FilterMatches keeps every line and highlights the matches, which 'n' and
+	// 'N' jump between. Used by widgets that display free-form text
+	FilterMatches
+)
+
+// matchRegionPrefix prefixes the IDs of the regions that wrap matches in
+// FilterMatches mode
+const matchRegionPrefix = "wtf-match-"
+
+// tagPattern matches tview's color and region tags, which are not searched
+var tagPattern = regexp.MustCompile(`\[([a-zA-Z]+|#[0-9a-zA-Z]{6}|\-)?(:([a-zA-Z]+|#[0-9a-zA-Z]{6}|\-)?)?(:([lbdru]+|\-)?)?\]|\["[a-zA-Z0-9_,;: \-\.]*"\]`)
+
+// filterState tracks the filter of a widget's view. Like viewState, it's keyed
+// on the view because widgets copy their embedded structs around
+type filterState struct {
+	text     string
+	hasText  bool
+	rendered string
+	wrap     bool
+
+	mode    FilterMode
+	query   string
+	editing bool
+
+	current int
+	matches int
+
+	// item is the item of a Selectable widget the filter last selected
+	item int
+}
+
+var (
+	filterStates = map[*tview.TextView]*filterState{}
+	filterMutex  = &sync.Mutex{}
+)
+
+/* -------------------- Exported Functions -------------------- */
+
+// SetText sets the text of the widget's view, filtered by the widget's active
+// filter, if any. Widgets use this instead of View.SetText so that they can be
+// filtered
+func (widget *TextWidget) SetText(text string) {
+	filterMutex.Lock()
+	state := filterFor(widget.View)
+	state.text = text
+	state.hasText = true
+	filterMutex.Unlock()
+
+	renderFilter(widget.View)
+}
+
+// SetFilterMode sets whether filtering the widget hides the lines that don't
+// match, which is the default, or highlights the matches
+func (widget *TextWidget) SetFilterMode(mode FilterMode) {
+	filterMutex.Lock()
+	filterFor(widget.View).mode = mode
+	filterMutex.Unlock()
+
+	if mode == FilterMatches {
+		widget.View.SetRegions(true)
+	}
+}
+
+// SetWrap sets whether the view wraps lines that are too long to fit. Widgets
+// whose rows can be clicked use this instead of View.SetWrap, so that clicks
+// find the right row
+func (widget *TextWidget) SetWrap(wrap bool) {
+	filterMutex.Lock()
+	filterFor(widget.View).wrap = wrap
+	filterMutex.Unlock()
+
+	widget.View.SetWrap(wrap)
+}
+
+// HandleFilterKey handles the keys that have to reach the focused widget's filter
+// before the widget itself: while the filter line is open, it filters as you type
+// until Enter or Esc is pressed, and while a filter is active Esc clears it. If a
+// filter hides lines of a Selectable widget, the first item left displayed is
+// selected. Returns true if the key was handled
+func HandleFilterKey(widget Wtfable, event *tcell.EventKey) bool {
+	if widget == nil {
+		return false
+	}
+
+	view := widget.TextView()
+
+	filterMutex.Lock()
+	state, ok := filterStates[view]
+	if !ok || !state.hasText {
+		filterMutex.Unlock()
+		return false
+	}
+
+	query := state.query
+	handled := handleFilterKey(state, event)
+	changed := state.query != query
+	filterMutex.Unlock()
+
+	if handled {
+		renderFilter(view)
+	}
+
+	if changed {
+		selectFirstItem(widget)
+	}
+
+	return handled
+}
+
+// CaptureFilterKeys lets the widget be filtered with the keys its own input capture
+// leaves alone, so that the keys widgets bind themselves keep working: 'f' opens
+// the filter line and, in FilterMatches mode, 'n' and 'N' jump to the next and
+// previous match. While a filter hides lines of a Selectable widget, the up and
+// down keys only select the items that are still displayed. It must be called
+// once the widget has set its input capture
+func CaptureFilterKeys(widget Wtfable) {
+	view := widget.TextView()
+	if view == nil {
+		return
+	}
+
+	capture := view.GetInputCapture()
+
+	view.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if selectVisibleItem(widget, event) {
+			return nil
+		}
+
+		if capture != nil {
+			if event = capture(event); event == nil {
+				return nil
+			}
+		}
+
+		filterMutex.Lock()
+		state, ok := filterStates[view]
+		handled := ok && state.hasText && handleFilterCommand(state, event)
+		filterMutex.Unlock()
+
+		if handled {
+			renderFilter(view)
+			return nil
+		}
+
+		return event
+	})
+}
+
+// FilterText returns the lines of text that contain the query, ignoring case and
+// color tags, with the matches underlined. Lines that don't match are dropped
+func FilterText(text, query string) string {
+	if query == "" {
+		return text
+	}
+
+	lines := []string{}
+	for _, line := range strings.Split(text, "\n") {
+		marked, count := markMatches(line, query, nil)
+		if count > 0 {
+			lines = append(lines, marked)
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// handleFilterKey handles the keys that edit the filter's query, and Esc, which
+// clears it. The caller must hold filterMutex
+func handleFilterKey(state *filterState, event *tcell.EventKey) bool {
+	if state.editing {
+		switch event.Key() {
+		case tcell.KeyEnter:
+			state.editing = false
+		case tcell.KeyEsc:
+			state.editing = false
+			state.query = ""
+		case tcell.KeyBackspace, tcell.KeyBackspace2:
+			if len(state.query) > 0 {
+				runes := []rune(state.query)
+				state.query = string(runes[:len(runes)-1])
+			}
+		case tcell.KeyRune:
+			state.query = state.query + string(event.Rune())
+		default:
+			return false
+		}
+
+		state.current = 0
+
+		return true
+	}
+
+	if event.Key() == tcell.KeyEsc && state.query != "" {
+		state.query = ""
+		return true
+	}
+
+	return false
+}
+
+// handleFilterCommand handles the keys that open the filter line and jump between
+// matches, which widgets can bind to something else. The caller must hold
+// filterMutex
+func handleFilterCommand(state *filterState, event *tcell.EventKey) bool {
+	if state.editing || event.Key() != tcell.KeyRune {
+		return false
+	}
+
+	switch event.Rune() {
+	case 'f':
+		state.editing = true
+		return true
+	case 'n':
+		if state.mode != FilterMatches || state.matches == 0 {
+			return false
+		}
+		state.current = (state.current + 1) % state.matches
+		return true
+	case 'N':
+		if state.mode != FilterMatches || state.matches == 0 {
+			return false
+		}
+		state.current = (state.current - 1 + state.matches) % state.matches
+		return true
+	}
+
+	return false
+}
+
+// filterFor returns the filter state for the view, creating it if necessary. The
+// caller must hold filterMutex
+func filterFor(view *tview.TextView) *filterState {
+	state, ok := filterStates[view]
+	if !ok {
+		state = &filterState{}
+		filterStates[view] = state
+	}
+
+	return state
+}
+
+// isFilterEditing returns true if the view's filter line is open for typing
+func isFilterEditing(view *tview.TextView) bool {
+	filterMutex.Lock()
+	defer filterMutex.Unlock()
+
+	state, ok := filterStates[view]
+	return ok && state.editing
+}
+
+// filterIndicator returns the filter line to display in the view's bottom border
+func filterIndicator(view *tview.TextView) string {
+	filterMutex.Lock()
+	defer filterMutex.Unlock()
+
+	state, ok := filterStates[view]
+	if !ok || (!state.editing && state.query == "") {
+		return ""
+	}
+
+	str := "filter: " + tview.Escape(state.query)
+	if state.editing {
+		str = str + "_"
+	}
+
+	if state.query != "" && state.mode == FilterMatches {
+		if state.matches == 0 {
+			str = str + " [red](no matches)"
+		} else {
+			str = str + fmt.Sprintf(" (%d/%d)", state.current+1, state.matches)
+		}
+	}
+
+	return str
+}
+
+// markMatches underlines the case-insensitive matches of query in the text,
+// skipping over tags. If regionID is not nil, each match is also wrapped in a
+// region whose ID it returns. Returns the marked text and the number of matches
+func markMatches(text, query string, regionID func() string) (string, int) {
+	lowerQuery := strings.ToLower(query)
+
+	var marked strings.Builder
+	count := 0
+
+	mark := func(segment string) {
+		lowerQuery := lowerQuery
+		lower := strings.ToLower(segment)
+		if len(lower) != len(segment) {
+			// Lowercasing changed the byte offsets, so match case-sensitively
+			lower, lowerQuery = segment, query
+		}
+
+		for {
+			idx := strings.Index(lower, lowerQuery)
+			if idx < 0 || lowerQuery == "" {
+				marked.WriteString(segment)
+				return
+			}
+
+			end := idx + len(lowerQuery)
+			marked.WriteString(segment[:idx])
+
+			if regionID != nil {
+				marked.WriteString(`["` + regionID() + `"]`)
+			}
+			marked.WriteString("[::bu]" + segment[idx:end] + "[::-]")
+			if regionID != nil {
+				marked.WriteString(`[""]`)
+			}
+
+			count++
+			segment, lower = segment[end:], lower[end:]
+		}
+	}
+
+	offset := 0
+	for _, loc := range tagPattern.FindAllStringIndex(text, -1) {
+		mark(text[offset:loc[0]])
+		marked.WriteString(text[loc[0]:loc[1]])
+		offset = loc[1]
+	}
+	mark(text[offset:])
+
+	return marked.String(), count
+}
+
+// selectFirstItem selects the first item of a Selectable widget that its filter
+// leaves displayed, or no item if the filter hides them all. Widgets whose
+// filters don't hide lines are left as they are
+func selectFirstItem(widget Wtfable) {
+	selectable, ok := widget.(Selectable)
+	if !ok {
+		return
+	}
+
+	filterMutex.Lock()
+	state := filterFor(widget.TextView())
+	if state.mode != FilterLines || state.query == "" {
+		filterMutex.Unlock()
+		return
+	}
+
+	state.item = -1
+	if items := visibleItems(state.rendered); len(items) > 0 {
+		state.item = items[0]
+	}
+	item := state.item
+	filterMutex.Unlock()
+
+	selectable.SelectItem(item)
+}
+
+// selectVisibleItem moves the selection of a Selectable widget up or down, on the
+// up and down keys or 'k' and 'j', to the items its filter leaves displayed.
+// Returns true if the key was handled
+func selectVisibleItem(widget Wtfable, event *tcell.EventKey) bool {
+	selectable, ok := widget.(Selectable)
+	if !ok {
+		return false
+	}
+
+	step := 0
+	switch {
+	case event.Key() == tcell.KeyDown || (event.Key() == tcell.KeyRune && event.Rune() == 'j'):
+		step = 1
+	case event.Key() == tcell.KeyUp || (event.Key() == tcell.KeyRune && event.Rune() == 'k'):
+		step = -1
+	default:
+		return false
+	}
+
+	filterMutex.Lock()
+	state, ok := filterStates[widget.TextView()]
+	if !ok || state.editing || state.mode != FilterLines || state.query == "" {
+		filterMutex.Unlock()
+		return false
+	}
+
+	items := visibleItems(state.rendered)
+	if len(items) == 0 {
+		filterMutex.Unlock()
+		return true
+	}
+
+	idx := -1
+	for i, item := range items {
+		if item == state.item {
+			idx = i
+		}
+	}
+
+	switch {
+	case idx < 0 && step < 0:
+		idx = len(items) - 1
+	case idx < 0:
+		idx = 0
+	default:
+		idx = ((idx+step)%len(items) + len(items)) % len(items)
+	}
+
+	state.item = items[idx]
+	filterMutex.Unlock()
+
+	selectable.SelectItem(items[idx])
+
+	return true
+}
+
+// visibleItems returns the items whose rows are in the text, in the order they're
+// displayed in, found from the regions the rows start with
+func visibleItems(text string) []int {
+	items := []int{}
+
+	for _, line := range strings.Split(text, "\n") {
+		match := itemRegionPattern.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+
+		if item, err := strconv.Atoi(match[1]); err == nil {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
+
+// renderFilter sets the view's text to the widget's text with the filter applied
+func renderFilter(view *tview.TextView) {
+	filterMutex.Lock()
+	state := filterFor(view)
+	text, query, mode := state.text, state.query, state.mode
+
+	if query == "" || mode == FilterLines {
+		filtered := FilterText(text, query)
+		state.matches = 0
+		state.rendered = filtered
+		filterMutex.Unlock()
+
+		if mode == FilterMatches {
+			view.Highlight()
+		}
+
+		view.SetText(filtered)
+		return
+	}
+
+	matches := 0
+	marked, _ := markMatches(text, query, func() string {
+		id := fmt.Sprintf("%s%d", matchRegionPrefix, matches)
+		matches++
+		return id
+	})
+
+	state.matches = matches
+	state.rendered = marked
+	if state.current >= matches {
+		state.current = 0
+	}
+	current := state.current
+	filterMutex.Unlock()
+
+	// Highlight before setting the text, which redraws the view
+	if matches > 0 {
+		view.Highlight(fmt.Sprintf("%s%d", matchRegionPrefix, current)).ScrollToHighlight()
+	} else {
+		view.Highlight()
+	}
+
+	view.SetText(marked)
+}
@@ -0,0 +1,64 @@
+package wtf
+
+import (
+	"github.com/gdamore/tcell"
+)
+
+/* -------------------- Exported Functions -------------------- */
+
+// DrawHints labels each focusable widget with its focus key, in the top-left
+// corner of its border, while the hints are shown. It's called after the
+// widgets are drawn
+func (tracker *FocusTracker) DrawHints(screen tcell.Screen) {
+	if !tracker.hints {
+		return
+	}
+
+	style := tcell.StyleDefault.
+		Foreground(colorFor(Config.UString("wtf.colors.highlight.fore", "black"))).
+		Background(colorFor(Config.UString("wtf.colors.highlight.back", "orange"))).
+		Bold(true)
+
+	for _, focusable := range tracker.focusables() {
+		if focusable.FocusChar() == "" {
+			continue
+		}
+
+		x, y, width, _ := focusable.TextView().GetRect()
+
+		for idx, char := range " " + focusable.FocusChar() + " " {
+			if idx+1 < width {
+				screen.SetContent(x+idx+1, y, char, nil, style)
+			}
+		}
+	}
+}
+
+// HandleHintKey handles a key while the hints are shown: pressing a widget's
+// focus key focuses it, whatever has focus, and any key hides the hints. Returns
+// true if the key was handled
+func (tracker *FocusTracker) HandleHintKey(event *tcell.EventKey) bool {
+	if !tracker.hints {
+		return false
+	}
+
+	tracker.hints = false
+
+	if event.Key() == tcell.KeyRune {
+		tracker.focusOnChar(string(event.Rune()))
+	}
+
+	tracker.App.ForceDraw()
+
+	return true
+}
+
+// ShowHints labels each widget with its focus key until the next key is pressed
+func (tracker *FocusTracker) ShowHints() {
+	if tracker.focusState() == appBoardFocused {
+		return
+	}
+
+	tracker.hints = true
+	tracker.App.ForceDraw()
+}
@@ -1,9 +1,18 @@
 package wtf
 
 import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gdamore/tcell"
 	"github.com/rivo/tview"
 )
 
+// hotKeyChars are the focus keys assigned to widgets that don't configure their
+// own 'focusChar', in order: the digits, then the letters once they run out
+const hotKeyChars = "123456789abcdefghijklmnopqrstuvwxyz"
+
 type FocusState int
 
 const (
@@ -18,53 +27,97 @@ type FocusTracker struct {
 	App     *tview.Application
 	Idx     int
 	Widgets []Wtfable
+
+	buttons   tcell.ButtonMask
+	hints     bool
+	lastClick mouseClick
 }
 
 /* -------------------- Exported Functions -------------------- */
 
-// AssignHotKeys assigns an alphabetic keyboard character to each focusable
-// widget so that the widget can be brought into focus by pressing that keyboard key
+// AssignHotKeys assigns a keyboard character to each focusable widget so that
+// the widget can be brought into focus by pressing that key. Widgets get the
+// key set in their module's 'focusChar' setting, if it's not taken. The rest
+// are numbered 1 to 9, then lettered, in the order they're displayed in
 func (tracker *FocusTracker) AssignHotKeys() {
+	taken := map[string]bool{}
+
+	for _, focusable := range tracker.focusables() {
+		char := Config.UString(fmt.Sprintf("wtf.mods.%s.focusChar", focusable.ConfigKey()))
+		if len([]rune(char)) != 1 || taken[char] {
+			char = ""
+		}
+
+		focusable.SetFocusChar(char)
+		if char != "" {
+			taken[char] = true
+		}
+	}
+
 	if !tracker.useNavShortcuts() {
 		return
 	}
 
-	i := 1
+	chars := strings.Split(hotKeyChars, "")
 
 	for _, focusable := range tracker.focusables() {
-		// Don't have nav characters > "9"
-		if i >= 10 {
-			break
+		if focusable.FocusChar() != "" {
+			continue
+		}
+
+		for len(chars) > 0 && taken[chars[0]] {
+			chars = chars[1:]
 		}
 
-		focusable.SetFocusChar(string('0' + i))
-		i++
+		if len(chars) == 0 {
+			return
+		}
+
+		focusable.SetFocusChar(chars[0])
+		chars = chars[1:]
 	}
 }
 
+// FocusOn sets the focus on the widget whose focus key is char. Digits always
+// work; other keys only work while no widget has focus, so that they don't take
+// over the focused widget's own keys. Returns true if the key focused a widget
 func (tracker *FocusTracker) FocusOn(char string) bool {
-	if !tracker.useNavShortcuts() {
+	switch tracker.focusState() {
+	case appBoardFocused:
 		return false
+	case widgetFocused:
+		if len(char) != 1 || char[0] < '0' || char[0] > '9' {
+			return false
+		}
 	}
 
-	if tracker.focusState() == appBoardFocused {
+	return tracker.focusOnChar(char)
+}
+
+// FocusOnKey sets the focus on the widget configured under the given config key.
+// Returns true if such a focusable widget exists
+func (tracker *FocusTracker) FocusOnKey(key string) bool {
+	if key == "" {
 		return false
 	}
 
-	hasFocusable := false
+	for _, focusable := range tracker.focusables() {
+		if focusable.ConfigKey() == key {
+			return tracker.focusOnWidget(focusable)
+		}
+	}
 
-	for idx, focusable := range tracker.focusables() {
-		if focusable.FocusChar() == char {
-			tracker.blur(tracker.Idx)
-			tracker.Idx = idx
-			tracker.focus(tracker.Idx)
+	return false
+}
 
-			hasFocusable = true
-			break
-		}
+// FocusedWidget returns the widget that currently has focus, or nil if no
+// widget has focus
+func (tracker *FocusTracker) FocusedWidget() Wtfable {
+	if tracker.focusState() != widgetFocused {
+		return nil
 	}
 
-	return hasFocusable
+	return tracker.focusableAt(tracker.Idx)
 }
 
 // Next sets the focus on the next widget in the widget list. If the current widget is
@@ -86,6 +139,8 @@ func (tracker *FocusTracker) None() {
 	}
 
 	tracker.blur(tracker.Idx)
+	tracker.App.SetFocus(nil)
+	State.SetFocusedWidget("")
 }
 
 // Prev sets the focus on the previous widget in the widget list. If the current widget is
@@ -135,10 +190,45 @@ func (tracker *FocusTracker) focus(idx int) {
 	view := widget.TextView()
 	view.SetBorderColor(colorFor(Config.UString("wtf.colors.border.focused", "gray")))
 
+	State.SetFocusedWidget(widget.ConfigKey())
+
 	tracker.App.SetFocus(view)
-	tracker.App.Draw()
+	tracker.App.ForceDraw()
 }
 
+// focusOnWidget sets the focus on the widget. Returns false if the widget isn't
+// focusable
+func (tracker *FocusTracker) focusOnWidget(widget Wtfable) bool {
+	for idx, focusable := range tracker.focusables() {
+		if focusable == widget {
+			tracker.blur(tracker.Idx)
+			tracker.Idx = idx
+			tracker.focus(tracker.Idx)
+
+			return true
+		}
+	}
+
+	return false
+}
+
+// focusOnChar sets the focus on the widget whose focus key is char
+func (tracker *FocusTracker) focusOnChar(char string) bool {
+	if char == "" {
+		return false
+	}
+
+	for _, focusable := range tracker.focusables() {
+		if focusable.FocusChar() == char {
+			return tracker.focusOnWidget(focusable)
+		}
+	}
+
+	return false
+}
+
+// focusables returns the widgets that can be focused, in the order they're
+// displayed in: top to bottom, then left to right
 func (tracker *FocusTracker) focusables() []Wtfable {
 	focusable := []Wtfable{}
 
@@ -148,6 +238,20 @@ func (tracker *FocusTracker) focusables() []Wtfable {
 		}
 	}
 
+	sort.SliceStable(focusable, func(i, j int) bool {
+		a, b := focusable[i], focusable[j]
+
+		if a.Top() != b.Top() {
+			return a.Top() < b.Top()
+		}
+
+		if a.Left() != b.Left() {
+			return a.Left() < b.Left()
+		}
+
+		return a.ConfigKey() < b.ConfigKey()
+	})
+
 	return focusable
 }
 
@@ -160,7 +264,7 @@ func (tracker *FocusTracker) focusableAt(idx int) Wtfable {
 }
 
 func (tracker *FocusTracker) focusState() FocusState {
-	if tracker.Idx < 0 {
+	if tracker.Idx < 0 || tracker.App.GetFocus() == nil {
 		return neverFocused
 	}
 
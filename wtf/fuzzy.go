@@ -0,0 +1,92 @@
+package wtf
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/rivo/tview"
+)
+
+// FuzzyMatch reports whether the query's characters all appear in the text, in
+// order but not necessarily next to each other, ignoring case. i.e.: "fbar"
+// matches "feature/bar". The score ranks the matches: characters that follow one
+// another, or that start a word, score higher than ones scattered through the
+// text. The positions are the indexes of the matched runes in the text
+func FuzzyMatch(text, query string) (score int, positions []int, ok bool) {
+	runes := []rune(text)
+	queryRunes := []rune(strings.ToLower(query))
+
+	if len(queryRunes) == 0 {
+		return 0, []int{}, true
+	}
+
+	qIdx := 0
+	prev := -1
+
+	for idx, char := range runes {
+		if qIdx == len(queryRunes) {
+			break
+		}
+
+		if unicode.ToLower(char) != queryRunes[qIdx] {
+			continue
+		}
+
+		switch {
+		case prev >= 0 && idx == prev+1:
+			score += 3
+		case idx == 0 || isWordSeparator(runes[idx-1]):
+			score += 2
+		default:
+			score++
+		}
+
+		if prev >= 0 {
+			score -= idx - prev - 1
+		}
+
+		positions = append(positions, idx)
+		prev = idx
+		qIdx++
+	}
+
+	if qIdx < len(queryRunes) {
+		return 0, nil, false
+	}
+
+	return score, positions, true
+}
+
+// MarkFuzzyMatch escapes the text and marks the runes at the positions, as
+// returned by FuzzyMatch, in bold
+func MarkFuzzyMatch(text string, positions []int) string {
+	matched := map[int]bool{}
+	for _, pos := range positions {
+		matched[pos] = true
+	}
+
+	var marked strings.Builder
+	runes := []rune(text)
+
+	// Mark each run of matched runes as a whole, and escape the runs separately
+	start := 0
+	for idx := 1; idx <= len(runes); idx++ {
+		if idx < len(runes) && matched[idx] == matched[start] {
+			continue
+		}
+
+		run := tview.Escape(string(runes[start:idx]))
+		if matched[start] {
+			run = "[::b]" + run + "[::-]"
+		}
+
+		marked.WriteString(run)
+		start = idx
+	}
+
+	return marked.String()
+}
+
+func isWordSeparator(char rune) bool {
+	return strings.ContainsRune("/-_. ", char)
+}
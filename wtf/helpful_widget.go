@@ -33,5 +33,5 @@ func (widget *HelpfulWidget) ShowHelp() {
 
 	widget.pages.AddPage("help", modal, false, true)
 	widget.app.SetFocus(modal)
-	widget.app.Draw()
+	widget.app.ForceDraw()
 }
@@ -0,0 +1,345 @@
+package wtf
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell"
+	"github.com/rivo/tview"
+)
+
+// modalFormPage is the name of the page modal forms are displayed on
+const modalFormPage = "modal"
+
+const modalFormWidth = 80
+
+// FormValues holds the values entered in a modal form, keyed by field name
+type FormValues map[string]string
+
+// Date returns the value of the named date field, and false if it's empty
+func (values FormValues) Date(name string) (time.Time, bool) {
+	date, err := time.Parse(DateFormat, values[name])
+	return date, err == nil
+}
+
+// Validator checks the value of a form field, returning an error that explains
+// what's wrong with it, if anything
+type Validator func(value string) error
+
+// Required is a Validator that rejects empty values
+func Required(value string) error {
+	if strings.TrimSpace(value) == "" {
+		return fmt.Errorf("is required")
+	}
+
+	return nil
+}
+
+type formField struct {
+	label      string
+	name       string
+	value      func() string
+	validators []Validator
+}
+
+// ModalForm is a form displayed in a modal dialog in the middle of the screen,
+// for creating and editing items. Add the fields, then call Show. The form is
+// closed when it's cancelled, or when it's submitted and the submit function
+// doesn't return an error
+type ModalForm struct {
+	app    *tview.Application
+	fields []*formField
+	form   *tview.Form
+	frame  *tview.Frame
+	pages  *tview.Pages
+
+	returnFocus tview.Primitive
+	onClose     func()
+	onSubmit    func(FormValues) error
+
+	// background is true if the submit function is called off the app's event
+	// loop, and submitting while it runs
+	background bool
+	closed     bool
+	submitting bool
+}
+
+// NewModalForm creates a form with the given title. When the form closes, focus
+// returns to returnFocus
+func NewModalForm(app *tview.Application, pages *tview.Pages, title string, returnFocus tview.Primitive) *ModalForm {
+	modal := ModalForm{
+		app:         app,
+		pages:       pages,
+		returnFocus: returnFocus,
+	}
+
+	modal.form = tview.NewForm().
+		SetButtonsAlign(tview.AlignCenter).
+		SetButtonTextColor(tview.Styles.PrimaryTextColor)
+
+	modal.form.SetCancelFunc(modal.Close)
+
+	modal.frame = tview.NewFrame(modal.form)
+	modal.frame.SetBorder(true)
+	modal.frame.SetBorders(1, 1, 0, 0, 1, 1)
+	modal.frame.SetTitle(fmt.Sprintf(" %s ", title))
+
+	return &modal
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// AddDateField adds a field for a date, entered as YYYY-MM-DD. The up and down
+// arrow keys move the date a day forward or back, starting from today
+func (modal *ModalForm) AddDateField(name, label string, value time.Time, validators ...Validator) *ModalForm {
+	text := ""
+	if !value.IsZero() {
+		text = value.Format(DateFormat)
+	}
+
+	field := tview.NewInputField().
+		SetLabel(label).
+		SetText(text).
+		SetFieldWidth(len(DateFormat) + 1).
+		SetPlaceholder("YYYY-MM-DD").
+		SetAcceptanceFunc(func(text string, char rune) bool {
+			return len(text) <= len(DateFormat) && strings.ContainsRune("0123456789-", char)
+		})
+
+	field.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyUp:
+			field.SetText(stepDate(field.GetText(), 1))
+			return nil
+		case tcell.KeyDown:
+			field.SetText(stepDate(field.GetText(), -1))
+			return nil
+		}
+
+		return event
+	})
+
+	validators = append([]Validator{validDate}, validators...)
+
+	return modal.addField(name, field, field.GetText, validators)
+}
+
+// AddDropDown adds a field whose value is one of the options. If value isn't one
+// of the options, the first option is selected
+func (modal *ModalForm) AddDropDown(name, label string, options []string, value string) *ModalForm {
+	initial := 0
+	for idx, option := range options {
+		if option == value {
+			initial = idx
+		}
+	}
+
+	field := tview.NewDropDown().
+		SetLabel(label).
+		SetOptions(options, nil).
+		SetCurrentOption(initial)
+
+	current := func() string {
+		_, option := field.GetCurrentOption()
+		return option
+	}
+
+	return modal.addField(name, field, current, nil)
+}
+
+// AddTextField adds a single-line text field
+func (modal *ModalForm) AddTextField(name, label, value string, validators ...Validator) *ModalForm {
+	field := tview.NewInputField().
+		SetLabel(label).
+		SetText(value).
+		SetFieldWidth(modalFormWidth - 20)
+
+	return modal.addField(name, field, field.GetText, validators)
+}
+
+// Close removes the form from the screen without submitting it
+func (modal *ModalForm) Close() {
+	modal.closed = true
+	modal.pages.RemovePage(modalFormPage)
+	modal.app.SetFocus(modal.returnFocus)
+
+	if modal.onClose != nil {
+		modal.onClose()
+	}
+}
+
+// SetCloseFunc sets a function that's called after the form closes, whether it
+// was submitted or cancelled
+func (modal *ModalForm) SetCloseFunc(onClose func()) *ModalForm {
+	modal.onClose = onClose
+	return modal
+}
+
+// SetSubmitFunc sets the function that's called with the form's values when the
+// form is submitted and all the fields are valid. If it returns an error the
+// error is displayed and the form stays open
+func (modal *ModalForm) SetSubmitFunc(onSubmit func(FormValues) error) *ModalForm {
+	modal.onSubmit = onSubmit
+	return modal
+}
+
+// SubmitInBackground calls the submit function off the app's event loop, so that a
+// submit function that makes a network request doesn't freeze the app. The form
+// says it's saving until the function returns, and then closes or displays the
+// error. Anything the submit function displays must go through QueueUpdateDraw
+func (modal *ModalForm) SubmitInBackground() *ModalForm {
+	modal.background = true
+	return modal
+}
+
+// Show adds the Save and Cancel buttons and displays the form, centered, on top
+// of the widgets
+func (modal *ModalForm) Show() {
+	modal.form.AddButton("Save", modal.submit)
+	modal.form.AddButton("Cancel", modal.Close)
+
+	// Two rows for each field, plus the buttons, the error line and the borders
+	height := 2*len(modal.fields) + 7
+
+	modal.pages.AddPage(modalFormPage, Centered(modal.frame, modalFormWidth, height), true, true)
+	modal.app.SetFocus(modal.form)
+}
+
+// Validate checks each field's value, returning the first error found, prefixed
+// with the field's label
+func (modal *ModalForm) Validate() error {
+	for _, field := range modal.fields {
+		for _, validator := range field.validators {
+			if err := validator(field.value()); err != nil {
+				return fmt.Errorf("%s %v", strings.TrimSuffix(field.label, ":"), err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Values returns the current value of each field
+func (modal *ModalForm) Values() FormValues {
+	values := FormValues{}
+
+	for _, field := range modal.fields {
+		values[field.name] = field.value()
+	}
+
+	return values
+}
+
+// Centered returns a layout that displays the primitive in the middle of the
+// screen at the given size
+func Centered(primitive tview.Primitive, width, height int) tview.Primitive {
+	column := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(nil, 0, 1, false).
+		AddItem(primitive, height, 0, true).
+		AddItem(nil, 0, 1, false)
+
+	return tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(column, width, 0, true).
+		AddItem(nil, 0, 1, false)
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+func (modal *ModalForm) addField(name string, item tview.FormItem, value func() string, validators []Validator) *ModalForm {
+	modal.form.AddFormItem(item)
+
+	modal.fields = append(modal.fields, &formField{
+		label:      item.GetLabel(),
+		name:       name,
+		value:      value,
+		validators: validators,
+	})
+
+	return modal
+}
+
+// showError displays the error below the form's buttons
+func (modal *ModalForm) showError(err error) {
+	modal.frame.Clear()
+	modal.frame.AddText(tview.Escape(err.Error()), false, tview.AlignCenter, tcell.ColorRed)
+}
+
+func (modal *ModalForm) submit() {
+	if err := modal.Validate(); err != nil {
+		modal.showError(err)
+		return
+	}
+
+	if modal.onSubmit != nil && modal.background {
+		modal.submitInBackground()
+		return
+	}
+
+	if modal.onSubmit != nil {
+		if err := modal.onSubmit(modal.Values()); err != nil {
+			modal.showError(err)
+			return
+		}
+	}
+
+	modal.Close()
+}
+
+func (modal *ModalForm) submitInBackground() {
+	if modal.submitting {
+		return
+	}
+	modal.submitting = true
+
+	modal.frame.Clear()
+	modal.frame.AddText("Saving...", false, tview.AlignCenter, tcell.ColorGrey)
+
+	values := modal.Values()
+
+	go func() {
+		err := modal.onSubmit(values)
+
+		modal.app.QueueUpdateDraw(func() {
+			modal.submitting = false
+
+			// The form may have been cancelled while it was saving
+			if modal.closed {
+				return
+			}
+
+			if err != nil {
+				modal.showError(err)
+				return
+			}
+
+			modal.Close()
+		})
+	}()
+}
+
+// stepDate moves the date by the given number of days. An empty or invalid date
+// steps from today
+func stepDate(text string, days int) string {
+	date, err := time.Parse(DateFormat, text)
+	if err != nil {
+		date = time.Now()
+		days = 0
+	}
+
+	return date.AddDate(0, 0, days).Format(DateFormat)
+}
+
+func validDate(value string) error {
+	if value == "" {
+		return nil
+	}
+
+	if _, err := time.Parse(DateFormat, value); err != nil {
+		return fmt.Errorf("must be a date in the form YYYY-MM-DD")
+	}
+
+	return nil
+}
@@ -0,0 +1,198 @@
+package wtf
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell"
+	"github.com/mattn/go-runewidth"
+	"github.com/rivo/tview"
+)
+
+// doubleClickInterval is the longest time between two clicks on the same item
+// for them to count as a double-click
+const doubleClickInterval = 500 * time.Millisecond
+
+// wheelLines is the number of lines each turn of the mouse wheel scrolls
+const wheelLines = 3
+
+// itemRegionPattern matches the region a selectable item's row starts with
+var itemRegionPattern = regexp.MustCompile(`\["([0-9]+)"\]`)
+
+// Selectable is implemented by widgets whose items can be selected by clicking
+// on them. Each item's row must contain a region whose ID is the item's index,
+// i.e.: ["0"][""]
+type Selectable interface {
+	// SelectItem selects the item at idx, or no item if idx is -1
+	SelectItem(idx int)
+
+	// OpenItem does to the selected item what pressing Return does
+	OpenItem()
+}
+
+type mouseClick struct {
+	at   time.Time
+	item int
+	view *tview.TextView
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// HandleMouse handles a mouse event: clicking on a widget focuses it, clicking
+// on an item in a list selects the item, double-clicking on it opens it, and the
+// wheel scrolls the widget under the pointer. Mouse events are ignored while a
+// modal, such as a help window, is displayed
+func (tracker *FocusTracker) HandleMouse(event *tcell.EventMouse) {
+	buttons := event.Buttons()
+	pressed := buttons &^ tracker.buttons
+	tracker.buttons = buttons & (tcell.Button1 | tcell.Button2 | tcell.Button3)
+
+	if tracker.focusState() == appBoardFocused {
+		return
+	}
+
+	x, y := event.Position()
+
+	widget := tracker.widgetAt(x, y)
+	if widget == nil {
+		return
+	}
+
+	switch {
+	case buttons&tcell.WheelUp != 0:
+		scrollBy(widget.TextView(), -wheelLines)
+	case buttons&tcell.WheelDown != 0:
+		scrollBy(widget.TextView(), wheelLines)
+	case pressed&tcell.Button1 != 0:
+		tracker.click(widget, y)
+	}
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// click focuses the widget and selects the item on row y, if any. A second click
+// on the same item opens it
+func (tracker *FocusTracker) click(widget Wtfable, y int) {
+	tracker.focusOnWidget(widget)
+
+	selectable, ok := widget.(Selectable)
+	if !ok {
+		return
+	}
+
+	view := widget.TextView()
+
+	item, ok := itemAt(view, y)
+	if !ok {
+		return
+	}
+
+	selectable.SelectItem(item)
+
+	filterMutex.Lock()
+	filterFor(view).item = item
+	filterMutex.Unlock()
+
+	last := tracker.lastClick
+	if last.view == view && last.item == item && time.Since(last.at) < doubleClickInterval {
+		tracker.lastClick = mouseClick{}
+		selectable.OpenItem()
+		return
+	}
+
+	tracker.lastClick = mouseClick{at: time.Now(), item: item, view: view}
+}
+
+// widgetAt returns the enabled widget displayed at x, y, or nil if there isn't one
+func (tracker *FocusTracker) widgetAt(x, y int) Wtfable {
+	for _, widget := range tracker.Widgets {
+		if widget.Disabled() {
+			continue
+		}
+
+		left, top, width, height := widget.TextView().GetRect()
+		if x >= left && x < left+width && y >= top && y < top+height {
+			return widget
+		}
+	}
+
+	return nil
+}
+
+// itemAt returns the index of the item whose row is displayed on screen row y of
+// the view, found from the region the row contains
+func itemAt(view *tview.TextView, y int) (int, bool) {
+	_, top, width, height := view.GetInnerRect()
+	if y < top || y >= top+height {
+		return 0, false
+	}
+
+	offset, _ := view.GetScrollOffset()
+	if offset < 0 {
+		offset = 0
+	}
+
+	line, ok := lineAt(view, offset+y-top, width)
+	if !ok {
+		return 0, false
+	}
+
+	match := itemRegionPattern.FindStringSubmatch(line)
+	if match == nil {
+		return 0, false
+	}
+
+	item, err := strconv.Atoi(match[1])
+	return item, err == nil
+}
+
+// lineAt returns the line of the view's text that's displayed on the given row of
+// its contents, allowing for long lines that wrap onto several rows
+func lineAt(view *tview.TextView, row, width int) (string, bool) {
+	filterMutex.Lock()
+	state, ok := filterStates[view]
+	if !ok {
+		filterMutex.Unlock()
+		return "", false
+	}
+	text, wrap := state.rendered, state.wrap
+	filterMutex.Unlock()
+
+	for _, line := range strings.Split(text, "\n") {
+		rows := 1
+		if wrap && width > 0 {
+			lineWidth := runewidth.StringWidth(tagPattern.ReplaceAllString(line, ""))
+			if lineWidth > width {
+				rows = (lineWidth + width - 1) / width
+			}
+		}
+
+		if row < rows {
+			return line, true
+		}
+		row = row - rows
+	}
+
+	return "", false
+}
+
+// scrollBy scrolls the view by the number of lines, up if lines is negative
+func scrollBy(view *tview.TextView, lines int) {
+	row, column := view.GetScrollOffset()
+
+	// The offset is -1 until the view is first drawn
+	if row < 0 {
+		row = 0
+	}
+
+	row = row + lines
+	if row < 0 {
+		row = 0
+	}
+
+	// Stop following the end of the text, i.e. of streamed output
+	view.ScrollToBeginning()
+	view.ScrollTo(row, column)
+}
@@ -48,6 +48,7 @@ func (widget *MultiSourceWidget) LoadSources() {
 	}
 
 	widget.Sources = asStrs
+	widget.restoreSource()
 }
 
 func (widget *MultiSourceWidget) Next() {
@@ -56,6 +57,8 @@ func (widget *MultiSourceWidget) Next() {
 		widget.Idx = 0
 	}
 
+	State.SetSource(widget.module, widget.CurrentSource())
+
 	if widget.DisplayFunction != nil {
 		widget.DisplayFunction()
 	}
@@ -67,6 +70,8 @@ func (widget *MultiSourceWidget) Prev() {
 		widget.Idx = len(widget.Sources) - 1
 	}
 
+	State.SetSource(widget.module, widget.CurrentSource())
+
 	if widget.DisplayFunction != nil {
 		widget.DisplayFunction()
 	}
@@ -75,3 +80,17 @@ func (widget *MultiSourceWidget) Prev() {
 func (widget *MultiSourceWidget) SetDisplayFunction(displayFunc func()) {
 	widget.DisplayFunction = displayFunc
 }
+
+/* -------------------- Unexported Functions -------------------- */
+
+// restoreSource selects the source that was displayed when the app last ran
+func (widget *MultiSourceWidget) restoreSource() {
+	source := State.Get(widget.module).Source
+
+	for idx, src := range widget.Sources {
+		if src == source {
+			widget.Idx = idx
+			return
+		}
+	}
+}
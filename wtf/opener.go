@@ -0,0 +1,67 @@
+package wtf
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+/* -------------------- Exported Functions -------------------- */
+
+// Opener returns the command, and its arguments, that opens a file or URL with
+// the application the operating system associates with it. 'wtf.openFileUtil'
+// takes precedence. Otherwise it's 'open' on macOS and 'start' on Windows. On
+// Linux and the BSDs it's wslview when running under WSL, xdg-open, or $BROWSER,
+// whichever is found first. Returns nil if no opener can be found
+func Opener() []string {
+	if util := Config.UString("wtf.openFileUtil"); util != "" {
+		return strings.Fields(util)
+	}
+
+	switch runtime.GOOS {
+	case "darwin":
+		return []string{"open"}
+	case "windows":
+		return []string{"cmd", "/c", "start", ""}
+	}
+
+	if isWSL() && onPath("wslview") {
+		return []string{"wslview"}
+	}
+
+	if onPath("xdg-open") {
+		return []string{"xdg-open"}
+	}
+
+	// $BROWSER is a colon-separated list of browsers, in order of preference
+	for _, browser := range strings.Split(os.Getenv("BROWSER"), ":") {
+		if args := strings.Fields(browser); len(args) > 0 && onPath(args[0]) {
+			return args
+		}
+	}
+
+	return nil
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// isWSL returns true if running under the Windows Subsystem for Linux
+func isWSL() bool {
+	if os.Getenv("WSL_DISTRO_NAME") != "" {
+		return true
+	}
+
+	version, err := ioutil.ReadFile("/proc/version")
+	if err != nil {
+		return false
+	}
+
+	return strings.Contains(strings.ToLower(string(version)), "microsoft")
+}
+
+func onPath(command string) bool {
+	_, err := exec.LookPath(command)
+	return err == nil
+}
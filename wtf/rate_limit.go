@@ -0,0 +1,237 @@
+package wtf
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// lowBudgetRatio defines how much of a host's rate limit can remain before
+// the scheduler starts stretching refresh intervals to make it last until the reset
+const lowBudgetRatio = 0.1
+
+// RateLimit holds the most recent rate limit information reported by an API host
+type RateLimit struct {
+	Limit      int
+	Remaining  int
+	Reset      time.Time
+	RetryAfter time.Time
+}
+
+var (
+	rateLimits     = map[string]*RateLimit{}
+	rateLimitMutex = &sync.Mutex{}
+)
+
+// RateLimitTransport is an http.RoundTripper that records the rate limit headers
+// returned by every host it talks to
+type RateLimitTransport struct {
+	Base http.RoundTripper
+}
+
+// RoundTrip executes the request via the base transport and records the rate limit
+// headers of the response
+func (transport *RateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := transport.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	resp, err := base.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+
+	RecordRateLimit(req.URL.Host, resp.StatusCode, resp.Header)
+
+	return resp, err
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// HostFor returns the host part of rawURL, or fallback if rawURL is empty or
+// cannot be parsed
+func HostFor(rawURL, fallback string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Host == "" {
+		return fallback
+	}
+
+	return parsed.Host
+}
+
+// RateLimitFor returns a copy of the most recent rate limit reported by the host,
+// or nil if the host has never reported one
+func RateLimitFor(host string) *RateLimit {
+	rateLimitMutex.Lock()
+	defer rateLimitMutex.Unlock()
+
+	limit, ok := rateLimits[host]
+	if !ok {
+		return nil
+	}
+
+	limitCopy := *limit
+	return &limitCopy
+}
+
+// RecordRateLimit parses the rate limit headers from a response and stores them
+// against the host. Supports the X-RateLimit-* (GitHub), X-Rate-Limit-* (Twitter),
+// RateLimit-* (GitLab) and Retry-After headers
+func RecordRateLimit(host string, statusCode int, header http.Header) {
+	now := time.Now()
+
+	rateLimitMutex.Lock()
+	defer rateLimitMutex.Unlock()
+
+	limit, ok := rateLimits[host]
+	if !ok {
+		limit = &RateLimit{Limit: -1, Remaining: -1}
+	}
+
+	found := false
+
+	for _, prefix := range []string{"X-RateLimit-", "X-Rate-Limit-", "RateLimit-"} {
+		remaining, err := strconv.Atoi(header.Get(prefix + "Remaining"))
+		if err != nil {
+			continue
+		}
+
+		limit.Remaining = remaining
+
+		if max, err := strconv.Atoi(header.Get(prefix + "Limit")); err == nil {
+			limit.Limit = max
+		}
+
+		if reset, err := strconv.ParseInt(header.Get(prefix+"Reset"), 10, 64); err == nil {
+			limit.Reset = time.Unix(reset, 0)
+		}
+
+		found = true
+		break
+	}
+
+	if retryAfter := parseRetryAfter(header.Get("Retry-After"), now); !retryAfter.IsZero() {
+		limit.RetryAfter = retryAfter
+		found = true
+	} else if statusCode == http.StatusTooManyRequests {
+		// Told to back off without being told for how long
+		limit.RetryAfter = now.Add(time.Minute)
+		found = true
+	}
+
+	if found {
+		rateLimits[host] = limit
+	}
+}
+
+// Exhausted returns true if no requests may be made to the host before the
+// rate limit resets
+func (limit *RateLimit) Exhausted(now time.Time) bool {
+	return limit.Wait(now) > 0
+}
+
+// Wait returns how long to wait before making another request to the host
+func (limit *RateLimit) Wait(now time.Time) time.Duration {
+	if limit.RetryAfter.After(now) {
+		return limit.RetryAfter.Sub(now)
+	}
+
+	if limit.Remaining == 0 && limit.Reset.After(now) {
+		return limit.Reset.Sub(now)
+	}
+
+	return 0
+}
+
+// MinInterval returns the minimum time between refreshes that will make the
+// remaining budget last until the rate limit resets. While plenty of budget
+// remains this is zero
+func (limit *RateLimit) MinInterval(now time.Time) time.Duration {
+	if limit.Remaining <= 0 || limit.Limit <= 0 || !limit.Reset.After(now) {
+		return 0
+	}
+
+	if float64(limit.Remaining) > float64(limit.Limit)*lowBudgetRatio {
+		return 0
+	}
+
+	return limit.Reset.Sub(now) / time.Duration(limit.Remaining)
+}
+
+// String returns the remaining budget in a form suitable for a widget title
+func (limit *RateLimit) String() string {
+	if limit.Remaining < 0 {
+		return ""
+	}
+
+	if limit.Limit > 0 {
+		return fmt.Sprintf("%d/%d", limit.Remaining, limit.Limit)
+	}
+
+	return strconv.Itoa(limit.Remaining)
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// deferredByRateLimit returns true if refreshing the widget now would exceed, or
+// burn through too quickly, the rate limit of the host the widget talks to
+func deferredByRateLimit(widget Wtfable, lastRefresh time.Time) bool {
+	host := hostFor(widget.TextView())
+	if host == "" {
+		return false
+	}
+
+	limit := RateLimitFor(host)
+	if limit == nil {
+		return false
+	}
+
+	now := time.Now()
+
+	if limit.Exhausted(now) {
+		return true
+	}
+
+	return now.Sub(lastRefresh) < limit.MinInterval(now)
+}
+
+// parseRetryAfter parses a Retry-After header, which is either a number of
+// seconds or an HTTP date. Returns the zero time if the header is absent or invalid
+func parseRetryAfter(value string, now time.Time) time.Time {
+	if value == "" {
+		return time.Time{}
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return now.Add(time.Duration(seconds) * time.Second)
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return date
+	}
+
+	return time.Time{}
+}
+
+// rateLimitIndicator returns the remaining budget of the host, for display in a
+// widget's border
+func rateLimitIndicator(host string) string {
+	limit := RateLimitFor(host)
+	if limit == nil {
+		return ""
+	}
+
+	if limit.Exhausted(time.Now()) {
+		return Config.UString("wtf.rateLimit.indicator", "[red]rate limited")
+	}
+
+	if limit.String() == "" {
+		return ""
+	}
+
+	return "[grey]" + limit.String()
+}
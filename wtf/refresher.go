@@ -0,0 +1,229 @@
+package wtf
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gdamore/tcell"
+	"github.com/rivo/tview"
+)
+
+// viewState tracks the refresh state of a widget's view. It's keyed on the view
+// rather than stored on the widget because widgets copy their embedded structs around
+type viewState struct {
+	app        *tview.Application
+	host       string
+	key        string
+	notice     string
+	queued     bool
+	refreshing bool
+}
+
+// noticeDuration is how long a notice is displayed in a view's border
+const noticeDuration = 2 * time.Second
+
+var (
+	viewStates = map[*tview.TextView]*viewState{}
+	viewMutex  = &sync.Mutex{}
+)
+
+/* -------------------- Exported Functions -------------------- */
+
+// RefreshWidget refreshes the widget unless a refresh of that widget is already in
+// progress, in which case it does nothing. While the refresh is running the widget's
+// border displays a refreshing indicator
+func RefreshWidget(widget Wtfable) {
+	refresh(widget, false)
+}
+
+// QueueRefresh refreshes the widget or, if a refresh of that widget is already in
+// progress, refreshes it again once that one's done. It's for when what the widget
+// displays changes after a running refresh has read it, i.e.: a new filter
+func QueueRefresh(widget Wtfable) {
+	refresh(widget, true)
+}
+
+// IsRefreshing returns true if the widget is currently being refreshed
+func IsRefreshing(widget Wtfable) bool {
+	viewMutex.Lock()
+	defer viewMutex.Unlock()
+
+	state, ok := viewStates[widget.TextView()]
+	return ok && state.refreshing
+}
+
+// ShowNotice displays the notice, which may contain color tags, in the widget's
+// top border for a couple of seconds. i.e.: to report that an action failed
+func (widget *TextWidget) ShowNotice(notice string) {
+	showNotice(widget.View, notice)
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// refresh refreshes the widget unless a refresh is already in progress. If one is
+// and queue is true, the running refresh is repeated once it's done instead
+func refresh(widget Wtfable, queue bool) {
+	view := widget.TextView()
+
+	if !beginRefresh(view, queue) {
+		return
+	}
+
+	for {
+		widget.Refresh()
+
+		if !endRefresh(view) {
+			return
+		}
+	}
+}
+
+func beginRefresh(view *tview.TextView, queue bool) bool {
+	viewMutex.Lock()
+	state := stateFor(view)
+	if state.refreshing {
+		state.queued = state.queued || queue
+		viewMutex.Unlock()
+		return false
+	}
+	state.refreshing = true
+	viewMutex.Unlock()
+
+	redraw(state)
+
+	return true
+}
+
+// endRefresh returns true, leaving the view refreshing, if another refresh was
+// queued while this one was running
+func endRefresh(view *tview.TextView) bool {
+	viewMutex.Lock()
+	state := stateFor(view)
+	if state.queued {
+		state.queued = false
+		viewMutex.Unlock()
+		return true
+	}
+	state.refreshing = false
+	viewMutex.Unlock()
+
+	redraw(state)
+
+	return false
+}
+
+// hostFor returns the API host the view's widget is rate-limited by, if any
+func hostFor(view *tview.TextView) string {
+	viewMutex.Lock()
+	defer viewMutex.Unlock()
+
+	return stateFor(view).host
+}
+
+// registerView associates the view with the app that draws it, so that changes
+// in refresh state can be drawn immediately, and with its widget's config key
+func registerView(view *tview.TextView, app *tview.Application, key string) {
+	viewMutex.Lock()
+	defer viewMutex.Unlock()
+
+	stateFor(view).app = app
+	stateFor(view).key = key
+}
+
+func setHostFor(view *tview.TextView, host string) {
+	viewMutex.Lock()
+	defer viewMutex.Unlock()
+
+	stateFor(view).host = host
+}
+
+// stateFor returns the state for the view, creating it if necessary. The caller
+// must hold viewMutex
+func stateFor(view *tview.TextView) *viewState {
+	state, ok := viewStates[view]
+	if !ok {
+		state = &viewState{}
+		viewStates[view] = state
+	}
+
+	return state
+}
+
+// showNotice displays the notice in the view's top border for a couple of seconds
+func showNotice(view *tview.TextView, notice string) {
+	viewMutex.Lock()
+	state := stateFor(view)
+	state.notice = notice
+	viewMutex.Unlock()
+
+	redraw(state)
+
+	time.AfterFunc(noticeDuration, func() {
+		viewMutex.Lock()
+		if state.notice == notice {
+			state.notice = ""
+		}
+		viewMutex.Unlock()
+
+		redraw(state)
+	})
+}
+
+func redraw(state *viewState) {
+	if state.app != nil {
+		go state.app.Draw()
+	}
+}
+
+// statusIndicators returns the markers to display in the view's top border
+func statusIndicators(view *tview.TextView) string {
+	indicators := []string{}
+
+	viewMutex.Lock()
+	state := stateFor(view)
+	refreshing, host, key, notice := state.refreshing, state.host, state.key, state.notice
+	viewMutex.Unlock()
+
+	if notice != "" {
+		indicators = append(indicators, notice)
+	}
+
+	if age := staleIndicator(key); age != "" {
+		indicators = append(indicators, age)
+	}
+
+	if host != "" {
+		if budget := rateLimitIndicator(host); budget != "" {
+			indicators = append(indicators, budget)
+		}
+	}
+
+	if refreshing {
+		indicators = append(indicators, Config.UString("wtf.refreshing.indicator", Glyph("[yellow]↻", "[yellow]*")))
+	}
+
+	if SchedulersPaused() {
+		indicators = append(indicators, Config.UString("wtf.paused.indicator", "[red]paused"))
+	}
+
+	return strings.Join(indicators, " ")
+}
+
+// drawStatus is used as a view's draw function. It draws the status indicators
+// right-aligned in the view's top border, and the filter line in its bottom border
+func drawStatus(view *tview.TextView) func(tcell.Screen, int, int, int, int) (int, int, int, int) {
+	return func(screen tcell.Screen, x, y, width, height int) (int, int, int, int) {
+		indicators := statusIndicators(view)
+
+		if indicators != "" && width > 4 {
+			tview.Print(screen, " "+indicators+"[white] ", x+1, y, width-2, tview.AlignRight, tcell.ColorWhite)
+		}
+
+		if filter := filterIndicator(view); filter != "" && width > 4 && height > 1 {
+			tview.Print(screen, " "+filter+"[white] ", x+1, y+height-1, width-2, tview.AlignLeft, tcell.ColorWhite)
+		}
+
+		return x + 1, y + 1, width - 2, height - 2
+	}
+}
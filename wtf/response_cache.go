@@ -0,0 +1,273 @@
+package wtf
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Cache stores successful API responses on disk so that widgets can display data
+// when the network is unavailable. A nil Cache caches nothing
+var Cache *ResponseCache
+
+// ResponseCache is an on-disk cache of HTTP GET responses, keyed on the module that
+// made the request and the request URL
+type ResponseCache struct {
+	dir     string
+	offline bool
+
+	mutex       *sync.Mutex
+	offlineKeys map[string]bool
+	servedAt    map[string]time.Time
+}
+
+// cachedResponse is the on-disk representation of a response
+type cachedResponse struct {
+	Body       []byte      `json:"body"`
+	Header     http.Header `json:"header"`
+	StatusCode int         `json:"statusCode"`
+	StoredAt   time.Time   `json:"storedAt"`
+}
+
+// CacheTransport is an http.RoundTripper that stores successful GET responses in
+// the Cache, and serves them from it when offline or when the network fails
+type CacheTransport struct {
+	Base http.RoundTripper
+
+	// Key is the config key of the module the requests are made for, i.e.: jira.
+	// Its responses are cached, and served offline, separately from other modules'
+	Key string
+}
+
+// NewResponseCache creates a cache that stores its responses in dir. If offline is
+// true, the cache never lets requests through to the network
+func NewResponseCache(dir string, offline bool) *ResponseCache {
+	os.MkdirAll(dir, 0700)
+
+	return &ResponseCache{
+		dir:     dir,
+		offline: offline,
+
+		mutex:       &sync.Mutex{},
+		offlineKeys: map[string]bool{},
+		servedAt:    map[string]time.Time{},
+	}
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// NewHTTPClient returns the http.Client the module with the config key should use
+// to talk to its API. It caches responses on disk and records the rate limits
+// reported by each host. If base is nil, http.DefaultTransport is used
+func NewHTTPClient(key string, base http.RoundTripper) *http.Client {
+	return &http.Client{
+		Transport: &CacheTransport{
+			Base: &RateLimitTransport{Base: base},
+			Key:  key,
+		},
+	}
+}
+
+// RoundTrip serves the request from the network if possible, falling back to
+// the cache when offline or when the network request fails
+func (transport *CacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if Cache == nil || req.Method != "GET" {
+		return transport.base().RoundTrip(req)
+	}
+
+	if Cache.offlineFor(transport.Key) {
+		resp, err := Cache.load(transport.Key, req)
+		if err != nil {
+			return nil, fmt.Errorf("offline: no cached response for %s", req.URL.Host)
+		}
+
+		return resp, nil
+	}
+
+	resp, err := transport.base().RoundTrip(req)
+	if err != nil {
+		if cached, cacheErr := Cache.load(transport.Key, req); cacheErr == nil {
+			return cached, nil
+		}
+
+		return resp, err
+	}
+
+	if resp.StatusCode >= 200 && resp.StatusCode <= 299 {
+		resp.Body = Cache.store(transport.Key, req, resp)
+	}
+
+	return resp, nil
+}
+
+// Has returns true if the cache holds any responses for the module with the key
+func (cache *ResponseCache) Has(key string) bool {
+	if cache == nil || key == "" {
+		return false
+	}
+
+	matches, _ := filepath.Glob(filepath.Join(cache.dir, key+"-*.json"))
+	return len(matches) > 0
+}
+
+// Offline returns true if requests are never sent to the network
+func (cache *ResponseCache) Offline() bool {
+	if cache == nil {
+		return false
+	}
+
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	return cache.offline
+}
+
+// StaleSince returns when the response most recently served for the module with
+// the key was stored, if that response came from the cache rather than the
+// network. Returns the zero time if the module's data is live
+func (cache *ResponseCache) StaleSince(key string) time.Time {
+	if cache == nil {
+		return time.Time{}
+	}
+
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	return cache.servedAt[key]
+}
+
+// RefreshFromCache refreshes each network widget that has cached data using only
+// that data, so that something is on screen before the first live refresh completes.
+// Only the requests of the widget being refreshed are served from the cache, so
+// other widgets' requests go to the network as usual
+func RefreshFromCache(widgets []Wtfable) {
+	if Cache == nil || Cache.Offline() {
+		return
+	}
+
+	for _, widget := range widgets {
+		key := widget.ConfigKey()
+		if !widget.Enabled() || !Cache.Has(key) {
+			continue
+		}
+
+		Cache.setOffline(key, true)
+		RefreshWidget(widget)
+		Cache.setOffline(key, false)
+	}
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+func (transport *CacheTransport) base() http.RoundTripper {
+	if transport.Base == nil {
+		return http.DefaultTransport
+	}
+
+	return transport.Base
+}
+
+func (cache *ResponseCache) filePath(key string, req *http.Request) string {
+	sum := sha256.Sum256([]byte(req.URL.String()))
+	return filepath.Join(cache.dir, key+"-"+hex.EncodeToString(sum[:])+".json")
+}
+
+func (cache *ResponseCache) load(key string, req *http.Request) (*http.Response, error) {
+	fileData, err := ioutil.ReadFile(cache.filePath(key, req))
+	if err != nil {
+		return nil, err
+	}
+
+	cached := cachedResponse{}
+	if err := json.Unmarshal(fileData, &cached); err != nil {
+		return nil, err
+	}
+
+	cache.setServedAt(key, cached.StoredAt)
+
+	return &http.Response{
+		Body:          ioutil.NopCloser(bytes.NewReader(cached.Body)),
+		ContentLength: int64(len(cached.Body)),
+		Header:        cached.Header,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Request:       req,
+		Status:        fmt.Sprintf("%d %s", cached.StatusCode, http.StatusText(cached.StatusCode)),
+		StatusCode:    cached.StatusCode,
+	}, nil
+}
+
+// offlineFor returns true if the requests of the module with the key are only
+// served from the cache, either because the app is offline or because the module
+// is being refreshed from the cache
+func (cache *ResponseCache) offlineFor(key string) bool {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	return cache.offline || cache.offlineKeys[key]
+}
+
+func (cache *ResponseCache) setOffline(key string, offline bool) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	if offline {
+		cache.offlineKeys[key] = true
+	} else {
+		delete(cache.offlineKeys, key)
+	}
+}
+
+func (cache *ResponseCache) setServedAt(key string, storedAt time.Time) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	cache.servedAt[key] = storedAt
+}
+
+// store writes the response to disk and returns a replacement for its body,
+// which has been consumed in the process
+func (cache *ResponseCache) store(key string, req *http.Request, resp *http.Response) io.ReadCloser {
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	replacement := ioutil.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return replacement
+	}
+
+	cache.setServedAt(key, time.Time{})
+
+	fileData, err := json.Marshal(cachedResponse{
+		Body:       body,
+		Header:     resp.Header,
+		StatusCode: resp.StatusCode,
+		StoredAt:   time.Now(),
+	})
+	if err == nil {
+		ioutil.WriteFile(cache.filePath(key, req), fileData, 0600)
+	}
+
+	return replacement
+}
+
+// staleIndicator returns how old the cached data of the module with the key is,
+// for display in its widget's border. Returns an empty string if the data is live
+func staleIndicator(key string) string {
+	storedAt := Cache.StaleSince(key)
+	if storedAt.IsZero() {
+		return ""
+	}
+
+	return fmt.Sprintf("[grey]cached %s", ShortDuration(time.Since(storedAt)))
+}
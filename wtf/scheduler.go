@@ -1,6 +1,7 @@
 package wtf
 
 import (
+	"sync/atomic"
 	"time"
 )
 
@@ -9,9 +10,14 @@ type Scheduler interface {
 	RefreshInterval() int
 }
 
+// paused is non-zero while the schedulers are paused
+var paused int32
+
 func Schedule(widget Wtfable) {
 	// Kick off the first refresh and then leave the rest to the timer
-	widget.Refresh()
+	if !SchedulersPaused() {
+		RefreshWidget(widget)
+	}
 
 	interval := time.Duration(widget.RefreshInterval()) * time.Second
 
@@ -21,12 +27,17 @@ func Schedule(widget Wtfable) {
 
 	tick := time.NewTicker(interval)
 	quit := make(chan struct{})
+	lastRefresh := time.Now()
 
 	for {
 		select {
 		case <-tick.C:
 			if widget.Enabled() {
-				widget.Refresh()
+				// Skip this tick if paused or if the widget's API host wants us to back off
+				if !SchedulersPaused() && !deferredByRateLimit(widget, lastRefresh) {
+					RefreshWidget(widget)
+					lastRefresh = time.Now()
+				}
 			} else {
 				tick.Stop()
 				return
@@ -37,3 +48,29 @@ func Schedule(widget Wtfable) {
 		}
 	}
 }
+
+// PauseSchedulers stops all widget schedulers from refreshing their widgets until
+// ResumeSchedulers is called. Manual refreshes are still permitted
+func PauseSchedulers() {
+	atomic.StoreInt32(&paused, 1)
+}
+
+// ResumeSchedulers lets the widget schedulers refresh their widgets again
+func ResumeSchedulers() {
+	atomic.StoreInt32(&paused, 0)
+}
+
+// SchedulersPaused returns true if the widget schedulers are currently paused
+func SchedulersPaused() bool {
+	return atomic.LoadInt32(&paused) == 1
+}
+
+// TogglePause pauses the widget schedulers if they're running, and resumes them if
+// they're paused
+func TogglePause() {
+	if SchedulersPaused() {
+		ResumeSchedulers()
+	} else {
+		PauseSchedulers()
+	}
+}
@@ -0,0 +1,224 @@
+package wtf
+
+import (
+	"io/ioutil"
+	"os"
+	"sync"
+
+	"gopkg.in/yaml.v2"
+)
+
+// State holds the UI state that's persisted across restarts
+var State *StateFile
+
+// WidgetState is the persisted UI state of a single widget
+type WidgetState struct {
+	Collapsed []string `yaml:"collapsed,omitempty"`
+	ScrollRow int      `yaml:"scrollRow,omitempty"`
+	Selected  string   `yaml:"selected,omitempty"`
+	Source    string   `yaml:"source,omitempty"`
+}
+
+// StateFile reads and writes the UI state of the app and its widgets, keyed on
+// each widget's config key. All functions are safe to call on a nil StateFile,
+// in which case nothing is restored or persisted
+type StateFile struct {
+	err      error
+	filePath string
+	mutex    *sync.Mutex
+
+	Focused string                  `yaml:"focused,omitempty"`
+	Widgets map[string]*WidgetState `yaml:"widgets,omitempty"`
+}
+
+// LoadStateFile loads the UI state from the file at filePath. If the file cannot
+// be read or parsed, it starts with an empty state
+func LoadStateFile(filePath string) *StateFile {
+	state := StateFile{
+		filePath: filePath,
+		mutex:    &sync.Mutex{},
+	}
+
+	fileData, err := ReadFileBytes(filePath)
+	if err == nil {
+		yaml.Unmarshal(fileData, &state)
+	}
+
+	if state.Widgets == nil {
+		state.Widgets = map[string]*WidgetState{}
+	}
+
+	return &state
+}
+
+/* -------------------- Exported Functions -------------------- */
+
+// Err returns the error that kept the state from being written the last time it
+// was changed, if it couldn't be
+func (state *StateFile) Err() error {
+	if state == nil {
+		return nil
+	}
+
+	state.mutex.Lock()
+	defer state.mutex.Unlock()
+
+	return state.err
+}
+
+// FocusedWidget returns the config key of the widget that had focus
+func (state *StateFile) FocusedWidget() string {
+	if state == nil {
+		return ""
+	}
+
+	state.mutex.Lock()
+	defer state.mutex.Unlock()
+
+	return state.Focused
+}
+
+// Get returns a copy of the persisted state for the widget
+func (state *StateFile) Get(key string) WidgetState {
+	if state == nil {
+		return WidgetState{}
+	}
+
+	state.mutex.Lock()
+	defer state.mutex.Unlock()
+
+	widgetState, ok := state.Widgets[key]
+	if !ok {
+		return WidgetState{}
+	}
+
+	return *widgetState
+}
+
+// IsCollapsed returns true if the named section of the widget was collapsed
+func (state *StateFile) IsCollapsed(key, section string) bool {
+	return !Exclude(state.Get(key).Collapsed, section)
+}
+
+// Reset clears all the persisted state
+func (state *StateFile) Reset() {
+	if state == nil {
+		return
+	}
+
+	state.mutex.Lock()
+	state.Focused = ""
+	state.Widgets = map[string]*WidgetState{}
+	state.mutex.Unlock()
+
+	state.persist()
+}
+
+// SetCollapsed records whether or not the named section of the widget is collapsed
+func (state *StateFile) SetCollapsed(key, section string, collapsed bool) {
+	state.update(key, func(widgetState *WidgetState) {
+		sections := []string{}
+		for _, name := range widgetState.Collapsed {
+			if name != section {
+				sections = append(sections, name)
+			}
+		}
+
+		if collapsed {
+			sections = append(sections, section)
+		}
+
+		widgetState.Collapsed = sections
+	})
+}
+
+// SetFocusedWidget records the config key of the widget that has focus
+func (state *StateFile) SetFocusedWidget(key string) {
+	if state == nil {
+		return
+	}
+
+	state.mutex.Lock()
+	changed := state.Focused != key
+	state.Focused = key
+	state.mutex.Unlock()
+
+	if changed {
+		state.persist()
+	}
+}
+
+// SetScrollRow records the row the widget is scrolled to
+func (state *StateFile) SetScrollRow(key string, row int) {
+	if state.Get(key).ScrollRow == row {
+		return
+	}
+
+	state.update(key, func(widgetState *WidgetState) {
+		widgetState.ScrollRow = row
+	})
+}
+
+// SetSelected records the key of the widget's selected item
+func (state *StateFile) SetSelected(key, selected string) {
+	state.update(key, func(widgetState *WidgetState) {
+		widgetState.Selected = selected
+	})
+}
+
+// SetSource records the name of the source the widget is displaying
+func (state *StateFile) SetSource(key, source string) {
+	state.update(key, func(widgetState *WidgetState) {
+		widgetState.Source = source
+	})
+}
+
+// SaveScrollRows records the scroll position of every widget
+func SaveScrollRows(widgets []Wtfable) {
+	for _, widget := range widgets {
+		row, _ := widget.TextView().GetScrollOffset()
+		State.SetScrollRow(widget.ConfigKey(), row)
+	}
+}
+
+/* -------------------- Unexported Functions -------------------- */
+
+// persist writes the state to the file. It's written while the state is locked, so
+// that changes made at the same time are written in the order they're made, and
+// to a temporary file that then replaces the state file, so that the state file is
+// never left half-written. If it can't be written, Err returns why
+func (state *StateFile) persist() {
+	state.mutex.Lock()
+	defer state.mutex.Unlock()
+
+	fileData, err := yaml.Marshal(state)
+	if err != nil {
+		state.err = err
+		return
+	}
+
+	tmpPath := state.filePath + ".tmp"
+	if err := ioutil.WriteFile(tmpPath, fileData, 0644); err != nil {
+		state.err = err
+		return
+	}
+
+	state.err = os.Rename(tmpPath, state.filePath)
+}
+
+func (state *StateFile) update(key string, updateFunc func(*WidgetState)) {
+	if state == nil || key == "" {
+		return
+	}
+
+	state.mutex.Lock()
+	widgetState, ok := state.Widgets[key]
+	if !ok {
+		widgetState = &WidgetState{}
+		state.Widgets[key] = widgetState
+	}
+	updateFunc(widgetState)
+	state.mutex.Unlock()
+
+	state.persist()
+}
@@ -0,0 +1,45 @@
+package wtf
+
+import (
+	"bytes"
+
+	"github.com/alecthomas/chroma/formatters"
+	"github.com/alecthomas/chroma/lexers"
+	"github.com/alecthomas/chroma/styles"
+	"github.com/rivo/tview"
+)
+
+// HighlightSyntax colors the text with chroma, translated into tview's color tags.
+// The language is the one for fileName, i.e.: main.go, or the lexer it names,
+// i.e.: diff. The style is one of chroma's styles, i.e.: vim, monokai
+func HighlightSyntax(text, fileName, styleName string) string {
+	lexer := lexers.Match(fileName)
+	if lexer == nil {
+		lexer = lexers.Get(fileName)
+	}
+	if lexer == nil {
+		lexer = lexers.Fallback
+	}
+
+	style := styles.Get(styleName)
+	if style == nil {
+		style = styles.Fallback
+	}
+
+	formatter := formatters.Get("terminal256")
+	if formatter == nil {
+		formatter = formatters.Fallback
+	}
+
+	iterator, err := lexer.Tokenise(nil, text)
+	if err != nil {
+		return tview.Escape(text)
+	}
+
+	var buf bytes.Buffer
+	if err := formatter.Format(&buf, style, iterator); err != nil {
+		return tview.Escape(text)
+	}
+
+	return tview.TranslateANSI(buf.String())
+}
@@ -11,6 +11,7 @@ import (
 var Config *config.Config
 
 type TextWidget struct {
+	configKey string
 	enabled   bool
 	focusable bool
 	focusChar string
@@ -25,6 +26,7 @@ type TextWidget struct {
 
 func NewTextWidget(app *tview.Application, name string, configKey string, focusable bool) TextWidget {
 	widget := TextWidget{
+		configKey: configKey,
 		enabled:   Config.UBool(fmt.Sprintf("wtf.mods.%s.enabled", configKey), false),
 		focusable: focusable,
 
@@ -54,6 +56,11 @@ func (widget *TextWidget) BorderColor() string {
 	return Config.UString("wtf.colors.border.normal", "gray")
 }
 
+// ConfigKey returns the key this widget is configured under in wtf.mods
+func (widget *TextWidget) ConfigKey() string {
+	return widget.configKey
+}
+
 func (widget *TextWidget) ContextualTitle(defaultStr string) string {
 	if widget.FocusChar() == "" {
 		return fmt.Sprintf(" %s ", defaultStr)
@@ -90,6 +97,12 @@ func (widget *TextWidget) SetFocusChar(char string) {
 	widget.focusChar = char
 }
 
+// SetRateLimitHost tells the scheduler which API host this widget talks to, so
+// that its refreshes honour that host's rate limits
+func (widget *TextWidget) SetRateLimitHost(host string) {
+	setHostFor(widget.View, host)
+}
+
 func (widget *TextWidget) TextView() *tview.TextView {
 	return widget.View
 }
@@ -123,12 +136,16 @@ func (widget *TextWidget) addView(app *tview.Application, configKey string) {
 	view.SetBorder(true)
 	view.SetBorderColor(colorFor(widget.BorderColor()))
 	view.SetChangedFunc(func() {
-		app.Draw()
+		// Draw waits for the app's event loop, which may be what changed the text
+		go app.Draw()
 	})
+	view.SetDrawFunc(drawStatus(view))
 	view.SetDynamicColors(true)
 	view.SetTitle(widget.ContextualTitle(widget.Name))
 	view.SetWrap(false)
 
+	registerView(view, app, configKey)
+
 	widget.View = view
 }
 
@@ -41,29 +41,6 @@ func DefaultRowColor() string {
 	return fmt.Sprintf("%s:%s", foreColor, backColor)
 }
 
-func ExecuteCommand(cmd *exec.Cmd) string {
-	stdout, err := cmd.StdoutPipe()
-	if err != nil {
-		return fmt.Sprintf("%v\n", err)
-	}
-
-	if err := cmd.Start(); err != nil {
-		return fmt.Sprintf("%v\n", err)
-	}
-
-	var str string
-	if b, err := ioutil.ReadAll(stdout); err == nil {
-		str += string(b)
-	}
-
-	err = cmd.Wait()
-	if err != nil {
-		return fmt.Sprintf("%v\n", err)
-	}
-
-	return str
-}
-
 func Exclude(strs []string, val string) bool {
 	for _, str := range strs {
 		if val == str {
@@ -93,13 +70,22 @@ func NamesFromEmails(emails []string) []string {
 	return names
 }
 
-// OpenFile opens the file defined in `path` via the operating system
+// OpenFile opens the file or URL defined in `path` via the operating system. See
+// Opener for how the application that opens it is chosen
 func OpenFile(path string) {
+	opener := Opener()
+	if opener == nil {
+		return
+	}
+
 	filePath, _ := ExpandHomeDir(path)
-	openFileUtil := Config.UString("wtf.openFileUtil", "open")
-	cmd := exec.Command(openFileUtil, filePath)
+	cmd := exec.Command(opener[0], append(opener[1:], filePath)...)
 
-	ExecuteCommand(cmd)
+	// Don't wait on the opener: $BROWSER, for one, may not return until the
+	// browser is closed
+	if err := cmd.Start(); err == nil {
+		go cmd.Wait()
+	}
 }
 
 // PadRow returns a padding for a row to make it the full width of the containing widget.
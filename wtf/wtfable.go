@@ -9,6 +9,7 @@ type Wtfable interface {
 	Scheduler
 
 	BorderColor() string
+	ConfigKey() string
 	Focusable() bool
 	FocusChar() string
 	SetFocusChar(string)
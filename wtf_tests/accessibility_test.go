@@ -0,0 +1,51 @@
+package wtf_tests
+
+import (
+	"testing"
+
+	"github.com/olebedev/config"
+	. "github.com/senorprogrammer/wtf/wtf"
+	. "github.com/stretchr/testify/assert"
+)
+
+func withAccessibility(enabled bool) {
+	yaml := "wtf:\n  accessibility: false\n"
+	if enabled {
+		yaml = "wtf:\n  accessibility: true\n"
+	}
+
+	Config, _ = config.ParseYaml(yaml)
+}
+
+/* -------------------- Glyph() -------------------- */
+
+func TestGlyph(t *testing.T) {
+	withAccessibility(false)
+	Equal(t, "🚨", Glyph("🚨", "!!"))
+
+	withAccessibility(true)
+	Equal(t, "!!", Glyph("🚨", "!!"))
+}
+
+/* -------------------- SelectionIndicator() -------------------- */
+
+func TestSelectionIndicator(t *testing.T) {
+	withAccessibility(false)
+	Equal(t, "", SelectionIndicator(true))
+
+	withAccessibility(true)
+	Equal(t, "> ", SelectionIndicator(true))
+	Equal(t, "  ", SelectionIndicator(false))
+}
+
+/* -------------------- StatusMarker() -------------------- */
+
+func TestStatusMarker(t *testing.T) {
+	withAccessibility(false)
+	Equal(t, "", StatusMarker("red"))
+
+	withAccessibility(true)
+	Equal(t, "[FAIL[] ", StatusMarker("red"))
+	Equal(t, "[OK[] ", StatusMarker("green"))
+	Equal(t, "", StatusMarker("white"))
+}
@@ -0,0 +1,69 @@
+package wtf_tests
+
+import (
+	"os"
+	"runtime"
+	"testing"
+
+	"github.com/olebedev/config"
+	. "github.com/senorprogrammer/wtf/wtf"
+	. "github.com/stretchr/testify/assert"
+)
+
+/* -------------------- ActionCommand() -------------------- */
+
+func TestActionCommand(t *testing.T) {
+	data := ActionData{"Key": "PROJ-1", "URL": "https://jira.example.com/browse/PROJ-1"}
+
+	command, err := ActionCommand("xdg-open {{.URL}}", data)
+	NoError(t, err)
+	Equal(t, "xdg-open 'https://jira.example.com/browse/PROJ-1'", command)
+
+	command, err = ActionCommand("tmux new-window ssh {{.Host}}", data)
+	NoError(t, err)
+	Equal(t, "tmux new-window ssh 'jira.example.com'", command)
+
+	_, err = ActionCommand("echo {{.Missing}}", data)
+	Error(t, err)
+}
+
+func TestActionCommandQuoting(t *testing.T) {
+	data := ActionData{"Number": 42, "Title": "it's done; rm -rf ~"}
+
+	command, err := ActionCommand("echo {{.Title}}", data)
+	NoError(t, err)
+	Equal(t, `echo 'it'\''s done; rm -rf ~'`, command)
+
+	// Templates written when quoting was opt-in aren't quoted twice
+	command, err = ActionCommand("echo {{quote .Title}}", data)
+	NoError(t, err)
+	Equal(t, `echo 'it'\''s done; rm -rf ~'`, command)
+
+	command, err = ActionCommand("open https://example.com/pull/{{raw .Number}}", data)
+	NoError(t, err)
+	Equal(t, "open https://example.com/pull/42", command)
+
+	command, err = ActionCommand(`{{if eq (raw .Number) "42"}}echo yes{{end}}`, data)
+	NoError(t, err)
+	Equal(t, "echo yes", command)
+}
+
+/* -------------------- Opener() -------------------- */
+
+func TestOpener(t *testing.T) {
+	Config, _ = config.ParseYaml("wtf:\n  openFileUtil: firefox --new-tab\n")
+	Equal(t, []string{"firefox", "--new-tab"}, Opener())
+
+	if runtime.GOOS != "linux" {
+		return
+	}
+
+	path, browser := os.Getenv("PATH"), os.Getenv("BROWSER")
+	defer os.Setenv("PATH", path)
+	defer os.Setenv("BROWSER", browser)
+
+	Config, _ = config.ParseYaml("wtf:\n  refreshInterval: 1\n")
+	os.Setenv("PATH", "")
+	os.Setenv("BROWSER", "")
+	Nil(t, Opener())
+}
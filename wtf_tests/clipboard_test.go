@@ -0,0 +1,33 @@
+package wtf_tests
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/olebedev/config"
+	. "github.com/senorprogrammer/wtf/wtf"
+	. "github.com/stretchr/testify/assert"
+)
+
+/* -------------------- CopyToClipboard() -------------------- */
+
+func TestCopyToClipboardWithCommand(t *testing.T) {
+	file, err := ioutil.TempFile("", "clipboard")
+	NoError(t, err)
+	file.Close()
+	defer os.Remove(file.Name())
+
+	Config, _ = config.ParseYaml("wtf:\n  clipboard:\n    osc52: false\n    command: tee " + file.Name() + "\n")
+
+	NoError(t, CopyToClipboard("PROJ-123"))
+
+	copied, _ := ioutil.ReadFile(file.Name())
+	Equal(t, "PROJ-123", string(copied))
+}
+
+func TestCopyToClipboardWithoutCommand(t *testing.T) {
+	Config, _ = config.ParseYaml("wtf:\n  clipboard:\n    osc52: false\n")
+
+	Error(t, CopyToClipboard("PROJ-123"))
+}
@@ -0,0 +1,121 @@
+package wtf_tests
+
+import (
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/olebedev/config"
+	. "github.com/senorprogrammer/wtf/wtf"
+	. "github.com/stretchr/testify/assert"
+)
+
+func shellCommand(script string) *Command {
+	Config, _ = config.ParseYaml("wtf:\n  commandTimeout: 5\n")
+	return NewCommand("sh", "-c", script)
+}
+
+/* -------------------- Run() -------------------- */
+
+func TestCommandRun(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("requires sh")
+	}
+
+	result := shellCommand("echo out; echo err >&2; exit 3").Run()
+
+	Equal(t, "out\n", result.Stdout)
+	Equal(t, "err\n", result.Stderr)
+	Equal(t, 3, result.ExitCode)
+	False(t, result.TimedOut)
+	Error(t, result.Err)
+	Equal(t, "exit status 3\nerr\n", result.Output())
+
+	result = shellCommand("echo ok").Run()
+	NoError(t, result.Err)
+	Equal(t, 0, result.ExitCode)
+	Equal(t, "ok\n", result.Output())
+}
+
+func TestCommandRunNotFound(t *testing.T) {
+	Config, _ = config.ParseYaml("wtf:\n  commandTimeout: 5\n")
+	result := RunCommand("wtf-no-such-command")
+
+	Error(t, result.Err)
+	Equal(t, -1, result.ExitCode)
+}
+
+func TestCommandRunTimeout(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("requires sh")
+	}
+
+	// The background sleep holds stdout open, so this only returns promptly if
+	// the whole process group is killed
+	cmd := shellCommand("sleep 30 & sleep 30")
+	cmd.Timeout = 200 * time.Millisecond
+
+	start := time.Now()
+	result := cmd.Run()
+
+	True(t, result.TimedOut)
+	Error(t, result.Err)
+	True(t, time.Since(start) < 10*time.Second)
+}
+
+/* -------------------- Stream() -------------------- */
+
+func TestCommandStream(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("requires sh")
+	}
+
+	lines := []string{}
+	result := shellCommand("echo one; echo two").Stream(func(line string) {
+		lines = append(lines, line)
+	})
+
+	NoError(t, result.Err)
+	Equal(t, []string{"one", "two"}, lines)
+}
+
+/* -------------------- Stop() -------------------- */
+
+func TestCommandStop(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("requires sh")
+	}
+
+	// Streamed commands don't time out, so this only returns once it's stopped
+	cmd := shellCommand("echo started; sleep 30 & sleep 30")
+	cmd.Timeout = 0
+
+	started := make(chan bool, 1)
+	results := make(chan CommandResult, 1)
+
+	go func() {
+		results <- cmd.Stream(func(line string) { started <- true })
+	}()
+
+	<-started
+	cmd.Stop()
+	cmd.Stop()
+
+	select {
+	case result := <-results:
+		True(t, result.Stopped)
+		Error(t, result.Err)
+	case <-time.After(10 * time.Second):
+		t.Fatal("the command wasn't stopped")
+	}
+}
+
+func TestCommandStopBeforeRun(t *testing.T) {
+	cmd := shellCommand("echo ran")
+	cmd.Stop()
+
+	result := cmd.Run()
+
+	Error(t, result.Err)
+	Equal(t, "", result.Stdout)
+}
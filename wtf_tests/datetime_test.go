@@ -19,3 +19,12 @@ func TestIsToday(t *testing.T) {
 func TestPrettyDate(t *testing.T) {
 	Equal(t, "Oct 21, 1999", PrettyDate("1999-10-21"))
 }
+
+/* -------------------- ShortDuration() -------------------- */
+
+func TestShortDuration(t *testing.T) {
+	Equal(t, "45s", ShortDuration(45*time.Second))
+	Equal(t, "5m", ShortDuration(5*time.Minute+30*time.Second))
+	Equal(t, "3h", ShortDuration(3*time.Hour))
+	Equal(t, "2d", ShortDuration(50*time.Hour))
+}
@@ -0,0 +1,58 @@
+package wtf_tests
+
+import (
+	"testing"
+
+	. "github.com/senorprogrammer/wtf/wtf"
+	. "github.com/stretchr/testify/assert"
+)
+
+/* -------------------- Publish() -------------------- */
+
+func TestPublish(t *testing.T) {
+	ResetSubscriptions()
+
+	received := []Event{}
+	Subscribe("git", func(event Event) {
+		received = append(received, event)
+	})
+
+	repo := Event{Source: "git", Type: EventRepoSelected, Data: ActionData{"Owner": "senorprogrammer", "Repo": "wtf"}}
+
+	Publish(repo)
+	Equal(t, []Event{repo}, received)
+
+	// The same event again isn't sent
+	Publish(Event{Source: "git", Type: EventRepoSelected, Data: ActionData{"Owner": "senorprogrammer", "Repo": "wtf"}})
+	Equal(t, 1, len(received))
+
+	// Nor are events from other sources
+	Publish(Event{Source: "jira", Type: EventIssueSelected, Data: ActionData{"Key": "PROJ-1"}})
+	Equal(t, 1, len(received))
+
+	other := Event{Source: "git", Type: EventRepoSelected, Data: ActionData{"Repo": "dotfiles"}}
+	Publish(other)
+	Equal(t, []Event{repo, other}, received)
+
+	ResetSubscriptions()
+
+	Publish(repo)
+	Equal(t, 2, len(received))
+}
+
+/* -------------------- Subscribe() -------------------- */
+
+func TestSubscribeReplaysLastEvents(t *testing.T) {
+	ResetSubscriptions()
+
+	issue := Event{Source: "tracker", Type: EventIssueSelected, Data: ActionData{"Key": "PROJ-2"}}
+	Publish(Event{Source: "tracker", Type: EventIssueSelected, Data: ActionData{"Key": "PROJ-1"}})
+	Publish(issue)
+
+	received := []Event{}
+	Subscribe("tracker", func(event Event) {
+		received = append(received, event)
+	})
+
+	Equal(t, []Event{issue}, received)
+}
@@ -0,0 +1,94 @@
+package wtf_tests
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell"
+	"github.com/rivo/tview"
+	. "github.com/senorprogrammer/wtf/wtf"
+	. "github.com/stretchr/testify/assert"
+)
+
+/* -------------------- FilterText() -------------------- */
+
+func TestFilterText(t *testing.T) {
+	text := "[green]Build one[white]\n[red]Deploy[white]\nbuild two"
+
+	Equal(t, text, FilterText(text, ""))
+	Equal(t, "[green][::bu]Build[::-] one[white]\n[::bu]build[::-] two", FilterText(text, "BUILD"))
+	Equal(t, "", FilterText(text, "nothing"))
+}
+
+func TestFilterTextIgnoresTags(t *testing.T) {
+	Equal(t, "", FilterText("[green]text", "green"))
+	Equal(t, `["1"][::bu]1[::-][""]`, FilterText(`["1"]1[""]`, "1"))
+}
+
+/* -------------------- CaptureFilterKeys() -------------------- */
+
+func key(r rune) *tcell.EventKey {
+	return tcell.NewEventKey(tcell.KeyRune, r, tcell.ModNone)
+}
+
+func typeFilter(widget Wtfable, query string) {
+	widget.TextView().GetInputCapture()(key('f'))
+
+	for _, r := range query {
+		HandleFilterKey(widget, key(r))
+	}
+	HandleFilterKey(widget, tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone))
+}
+
+func TestCaptureFilterKeysLeavesWidgetKeys(t *testing.T) {
+	widget := newListWidget(tview.NewApplication())
+
+	captured := 0
+	widget.View.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Rune() == 'f' {
+			captured++
+			return nil
+		}
+		return event
+	})
+	CaptureFilterKeys(widget)
+
+	Nil(t, widget.View.GetInputCapture()(key('f')))
+	Equal(t, 1, captured)
+
+	// The filter line isn't open, so typing goes to the widget
+	False(t, HandleFilterKey(widget, key('s')))
+}
+
+func TestCaptureFilterKeysOpensFilter(t *testing.T) {
+	widget := newListWidget(tview.NewApplication())
+	widget.SetText(" [red]Items[white]\n" + `["0"][""] first` + "\n" + `["1"][""] second` + "\n" + `["2"][""] third` + "\n")
+	CaptureFilterKeys(widget)
+
+	typeFilter(widget, "s")
+
+	// The first of first and second, which are displayed, is selected
+	Equal(t, 0, widget.selected)
+
+	capture := widget.View.GetInputCapture()
+	Nil(t, capture(key('j')))
+	Equal(t, 1, widget.selected)
+	Nil(t, capture(key('j')))
+	Equal(t, 0, widget.selected)
+	Nil(t, capture(tcell.NewEventKey(tcell.KeyUp, 0, tcell.ModNone)))
+	Equal(t, 1, widget.selected)
+}
+
+func TestCaptureFilterKeysHidesEverything(t *testing.T) {
+	widget := newListWidget(tview.NewApplication())
+	CaptureFilterKeys(widget)
+
+	typeFilter(widget, "nothing")
+
+	Equal(t, -1, widget.selected)
+	Nil(t, widget.View.GetInputCapture()(key('j')))
+	Equal(t, -1, widget.selected)
+
+	// Once the filter's cleared, the widget moves its selection itself
+	True(t, HandleFilterKey(widget, tcell.NewEventKey(tcell.KeyEsc, 0, tcell.ModNone)))
+	NotNil(t, widget.View.GetInputCapture()(key('j')))
+}
@@ -0,0 +1,127 @@
+package wtf_tests
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/gdamore/tcell"
+	"github.com/olebedev/config"
+	"github.com/rivo/tview"
+	. "github.com/senorprogrammer/wtf/wtf"
+	. "github.com/stretchr/testify/assert"
+)
+
+type focusWidget struct {
+	TextWidget
+}
+
+func (widget *focusWidget) Refresh() {}
+
+// newFocusTracker creates a tracker for count widgets, named mod0, mod1, etc.,
+// laid out in a column in reverse order, so that mod0 is at the bottom
+func newFocusTracker(count int, settings string) *FocusTracker {
+	yaml := "wtf:\n  mods:\n"
+	for i := 0; i < count; i++ {
+		yaml = yaml + fmt.Sprintf("    mod%d:\n      enabled: true\n      position:\n        top: %d\n        left: 0\n", i, count-i)
+	}
+
+	Config, _ = config.ParseYaml(yaml + settings)
+
+	app := tview.NewApplication()
+	tracker := &FocusTracker{App: app, Idx: -1}
+
+	for i := 0; i < count; i++ {
+		widget := focusWidget{TextWidget: NewTextWidget(app, "Mod", fmt.Sprintf("mod%d", i), true)}
+		tracker.Widgets = append(tracker.Widgets, &widget)
+	}
+
+	return tracker
+}
+
+func focusChars(tracker *FocusTracker) map[string]string {
+	chars := map[string]string{}
+	for _, widget := range tracker.Widgets {
+		chars[widget.ConfigKey()] = widget.FocusChar()
+	}
+
+	return chars
+}
+
+/* -------------------- AssignHotKeys() -------------------- */
+
+func TestAssignHotKeysInDisplayOrder(t *testing.T) {
+	tracker := newFocusTracker(3, "")
+	tracker.AssignHotKeys()
+
+	Equal(t, map[string]string{"mod2": "1", "mod1": "2", "mod0": "3"}, focusChars(tracker))
+}
+
+func TestAssignHotKeysUsesLettersAfterDigits(t *testing.T) {
+	tracker := newFocusTracker(11, "")
+	tracker.AssignHotKeys()
+
+	chars := focusChars(tracker)
+	Equal(t, "1", chars["mod10"])
+	Equal(t, "9", chars["mod2"])
+	Equal(t, "a", chars["mod1"])
+	Equal(t, "b", chars["mod0"])
+}
+
+func TestAssignHotKeysHonoursFocusChar(t *testing.T) {
+	tracker := newFocusTracker(3, "")
+	Config.Set("wtf.mods.mod0.focusChar", "1")
+	Config.Set("wtf.mods.mod1.focusChar", "g")
+
+	tracker.AssignHotKeys()
+
+	Equal(t, map[string]string{"mod2": "2", "mod1": "g", "mod0": "1"}, focusChars(tracker))
+}
+
+func TestAssignHotKeysWithoutShortcuts(t *testing.T) {
+	tracker := newFocusTracker(3, "  navigation:\n    shortcuts: false\n")
+	Config.Set("wtf.mods.mod1.focusChar", "g")
+
+	tracker.AssignHotKeys()
+
+	Equal(t, map[string]string{"mod2": "", "mod1": "g", "mod0": ""}, focusChars(tracker))
+}
+
+/* -------------------- FocusOn() -------------------- */
+
+func TestFocusOn(t *testing.T) {
+	tracker := newFocusTracker(11, "")
+	tracker.AssignHotKeys()
+
+	True(t, tracker.FocusOn("a"))
+	Equal(t, "mod1", tracker.FocusedWidget().ConfigKey())
+
+	// Letters belong to the focused widget
+	False(t, tracker.FocusOn("b"))
+	Equal(t, "mod1", tracker.FocusedWidget().ConfigKey())
+
+	True(t, tracker.FocusOn("1"))
+	Equal(t, "mod10", tracker.FocusedWidget().ConfigKey())
+
+	tracker.None()
+	Nil(t, tracker.FocusedWidget())
+
+	True(t, tracker.FocusOn("b"))
+	Equal(t, "mod0", tracker.FocusedWidget().ConfigKey())
+}
+
+/* -------------------- HandleHintKey() -------------------- */
+
+func TestHandleHintKey(t *testing.T) {
+	tracker := newFocusTracker(11, "")
+	tracker.AssignHotKeys()
+	tracker.FocusOn("1")
+
+	False(t, tracker.HandleHintKey(tcell.NewEventKey(tcell.KeyRune, 'b', tcell.ModNone)))
+
+	tracker.ShowHints()
+	True(t, tracker.HandleHintKey(tcell.NewEventKey(tcell.KeyRune, 'b', tcell.ModNone)))
+	Equal(t, "mod0", tracker.FocusedWidget().ConfigKey())
+
+	// The hints are hidden by the first key
+	False(t, tracker.HandleHintKey(tcell.NewEventKey(tcell.KeyRune, 'a', tcell.ModNone)))
+}
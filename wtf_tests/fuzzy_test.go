@@ -0,0 +1,40 @@
+package wtf_tests
+
+import (
+	"testing"
+
+	. "github.com/senorprogrammer/wtf/wtf"
+	. "github.com/stretchr/testify/assert"
+)
+
+/* -------------------- FuzzyMatch() -------------------- */
+
+func TestFuzzyMatch(t *testing.T) {
+	_, positions, ok := FuzzyMatch("feature/bar", "fBar")
+	True(t, ok)
+	Equal(t, []int{0, 8, 9, 10}, positions)
+
+	_, _, ok = FuzzyMatch("feature/bar", "baf")
+	False(t, ok)
+
+	_, positions, ok = FuzzyMatch("master", "")
+	True(t, ok)
+	Equal(t, []int{}, positions)
+}
+
+func TestFuzzyMatchScore(t *testing.T) {
+	together, _, _ := FuzzyMatch("fix-login", "log")
+	wordStart, _, _ := FuzzyMatch("fix-l-o-g", "log")
+	scattered, _, _ := FuzzyMatch("fixlxoxg", "log")
+
+	True(t, together > wordStart)
+	True(t, wordStart > scattered)
+}
+
+/* -------------------- MarkFuzzyMatch() -------------------- */
+
+func TestMarkFuzzyMatch(t *testing.T) {
+	Equal(t, "[::b]fe[::-]ature/[::b]b[::-]ar", MarkFuzzyMatch("feature/bar", []int{0, 1, 8}))
+	Equal(t, "master", MarkFuzzyMatch("master", []int{}))
+	Equal(t, "", MarkFuzzyMatch("", []int{}))
+}
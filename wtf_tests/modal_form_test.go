@@ -0,0 +1,52 @@
+package wtf_tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rivo/tview"
+	. "github.com/senorprogrammer/wtf/wtf"
+	. "github.com/stretchr/testify/assert"
+)
+
+func testForm(title, due string) *ModalForm {
+	date, _ := time.Parse(DateFormat, due)
+
+	return NewModalForm(tview.NewApplication(), tview.NewPages(), "New Task", nil).
+		AddTextField("title", "Title:", title, Required).
+		AddDateField("due", "Due:", date).
+		AddDropDown("priority", "Priority:", []string{"low", "high"}, "high")
+}
+
+/* -------------------- Validate() -------------------- */
+
+func TestModalFormValidate(t *testing.T) {
+	NoError(t, testForm("Write the docs", "2018-10-20").Validate())
+	NoError(t, testForm("Write the docs", "").Validate())
+
+	err := testForm("  ", "2018-10-20").Validate()
+	EqualError(t, err, "Title is required")
+}
+
+/* -------------------- Values() -------------------- */
+
+func TestModalFormValues(t *testing.T) {
+	values := testForm("Write the docs", "2018-10-20").Values()
+
+	Equal(t, "Write the docs", values["title"])
+	Equal(t, "high", values["priority"])
+
+	date, ok := values.Date("due")
+	True(t, ok)
+	Equal(t, time.Date(2018, 10, 20, 0, 0, 0, 0, time.UTC), date)
+
+	_, ok = testForm("Write the docs", "").Values().Date("due")
+	False(t, ok)
+}
+
+/* -------------------- Required() -------------------- */
+
+func TestRequired(t *testing.T) {
+	NoError(t, Required("text"))
+	Error(t, Required(""))
+}
@@ -0,0 +1,103 @@
+package wtf_tests
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell"
+	"github.com/olebedev/config"
+	"github.com/rivo/tview"
+	. "github.com/senorprogrammer/wtf/wtf"
+	. "github.com/stretchr/testify/assert"
+)
+
+type listWidget struct {
+	TextWidget
+
+	opened   int
+	selected int
+}
+
+func (widget *listWidget) Refresh() {}
+
+func (widget *listWidget) SelectItem(idx int) {
+	widget.selected = idx
+}
+
+func (widget *listWidget) OpenItem() {
+	widget.opened++
+}
+
+func newListWidget(app *tview.Application) *listWidget {
+	Config, _ = config.ParseYaml("wtf:\n  mods:\n    list:\n      enabled: true\n")
+
+	widget := listWidget{
+		TextWidget: NewTextWidget(app, "List", "list", true),
+		selected:   -1,
+	}
+
+	widget.View.SetRect(0, 0, 40, 10)
+	widget.SetText(" [red]Items[white]\n" + `["0"][""] first` + "\n" + `["1"][""] second` + "\n")
+
+	return &widget
+}
+
+func click(tracker *FocusTracker, x, y int) {
+	tracker.HandleMouse(tcell.NewEventMouse(x, y, tcell.Button1, tcell.ModNone))
+	tracker.HandleMouse(tcell.NewEventMouse(x, y, tcell.ButtonNone, tcell.ModNone))
+}
+
+/* -------------------- HandleMouse() -------------------- */
+
+func TestHandleMouseSelectsAndOpens(t *testing.T) {
+	app := tview.NewApplication()
+	widget := newListWidget(app)
+
+	tracker := &FocusTracker{App: app, Idx: -1, Widgets: []Wtfable{widget}}
+
+	// The border and the header aren't items
+	click(tracker, 5, 1)
+	Equal(t, -1, widget.selected)
+	Equal(t, widget.View, app.GetFocus())
+
+	click(tracker, 5, 3)
+	Equal(t, 1, widget.selected)
+	Equal(t, 0, widget.opened)
+
+	click(tracker, 5, 3)
+	Equal(t, 1, widget.opened)
+
+	click(tracker, 5, 2)
+	Equal(t, 0, widget.selected)
+	Equal(t, 1, widget.opened)
+
+	// Clicks outside the widget are ignored
+	click(tracker, 50, 2)
+	Equal(t, 0, widget.selected)
+}
+
+func TestHandleMouseIgnoresDrags(t *testing.T) {
+	app := tview.NewApplication()
+	widget := newListWidget(app)
+
+	tracker := &FocusTracker{App: app, Idx: -1, Widgets: []Wtfable{widget}}
+
+	tracker.HandleMouse(tcell.NewEventMouse(5, 2, tcell.Button1, tcell.ModNone))
+	tracker.HandleMouse(tcell.NewEventMouse(5, 3, tcell.Button1, tcell.ModNone))
+
+	Equal(t, 0, widget.selected)
+}
+
+func TestHandleMouseScrolls(t *testing.T) {
+	app := tview.NewApplication()
+	widget := newListWidget(app)
+
+	tracker := &FocusTracker{App: app, Idx: -1, Widgets: []Wtfable{widget}}
+
+	tracker.HandleMouse(tcell.NewEventMouse(5, 5, tcell.WheelDown, tcell.ModNone))
+	row, _ := widget.View.GetScrollOffset()
+	Equal(t, 3, row)
+
+	tracker.HandleMouse(tcell.NewEventMouse(5, 5, tcell.WheelUp, tcell.ModNone))
+	row, _ = widget.View.GetScrollOffset()
+	Equal(t, 0, row)
+}
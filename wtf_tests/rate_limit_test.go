@@ -0,0 +1,88 @@
+package wtf_tests
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	. "github.com/senorprogrammer/wtf/wtf"
+	. "github.com/stretchr/testify/assert"
+)
+
+/* -------------------- HostFor() -------------------- */
+
+func TestHostFor(t *testing.T) {
+	Equal(t, "api.github.com", HostFor("", "api.github.com"))
+	Equal(t, "github.example.com", HostFor("https://github.example.com/api/v3/", "api.github.com"))
+	Equal(t, "fallback", HostFor("not a url", "fallback"))
+}
+
+/* -------------------- RecordRateLimit() -------------------- */
+
+func TestRecordRateLimitGitHub(t *testing.T) {
+	reset := time.Now().Add(time.Hour).Unix()
+
+	header := http.Header{}
+	header.Set("X-RateLimit-Limit", "5000")
+	header.Set("X-RateLimit-Remaining", "42")
+	header.Set("X-RateLimit-Reset", strconv.FormatInt(reset, 10))
+
+	RecordRateLimit("github.test", http.StatusOK, header)
+
+	limit := RateLimitFor("github.test")
+	NotNil(t, limit)
+	Equal(t, 5000, limit.Limit)
+	Equal(t, 42, limit.Remaining)
+	Equal(t, reset, limit.Reset.Unix())
+	Equal(t, "42/5000", limit.String())
+	False(t, limit.Exhausted(time.Now()))
+	True(t, limit.MinInterval(time.Now()) > 0)
+}
+
+func TestRecordRateLimitGitLab(t *testing.T) {
+	header := http.Header{}
+	header.Set("RateLimit-Limit", "600")
+	header.Set("RateLimit-Remaining", "0")
+	header.Set("RateLimit-Reset", strconv.FormatInt(time.Now().Add(time.Minute).Unix(), 10))
+
+	RecordRateLimit("gitlab.test", http.StatusOK, header)
+
+	limit := RateLimitFor("gitlab.test")
+	NotNil(t, limit)
+	True(t, limit.Exhausted(time.Now()))
+}
+
+func TestRecordRateLimitRetryAfter(t *testing.T) {
+	header := http.Header{}
+	header.Set("Retry-After", "120")
+
+	RecordRateLimit("retry.test", http.StatusTooManyRequests, header)
+
+	limit := RateLimitFor("retry.test")
+	NotNil(t, limit)
+	True(t, limit.Wait(time.Now()) > 100*time.Second)
+	Equal(t, "", limit.String())
+}
+
+func TestRecordRateLimitWithoutHeaders(t *testing.T) {
+	RecordRateLimit("none.test", http.StatusOK, http.Header{})
+
+	Nil(t, RateLimitFor("none.test"))
+}
+
+/* -------------------- MinInterval() -------------------- */
+
+func TestMinIntervalWithPlentyOfBudget(t *testing.T) {
+	now := time.Now()
+	limit := RateLimit{Limit: 5000, Remaining: 4000, Reset: now.Add(time.Hour)}
+
+	Equal(t, time.Duration(0), limit.MinInterval(now))
+}
+
+func TestMinIntervalWithLowBudget(t *testing.T) {
+	now := time.Now()
+	limit := RateLimit{Limit: 5000, Remaining: 60, Reset: now.Add(time.Hour)}
+
+	Equal(t, time.Minute, limit.MinInterval(now))
+}
@@ -0,0 +1,103 @@
+package wtf_tests
+
+import (
+	"testing"
+
+	"github.com/rivo/tview"
+	. "github.com/senorprogrammer/wtf/wtf"
+	. "github.com/stretchr/testify/assert"
+)
+
+// slowWidget's refreshes each wait to be released, so that others can be
+// requested while one is running
+type slowWidget struct {
+	*listWidget
+
+	refreshes int
+	release   chan bool
+	started   chan bool
+}
+
+func (widget *slowWidget) Refresh() {
+	widget.refreshes++
+	widget.started <- true
+	<-widget.release
+}
+
+func newSlowWidget() *slowWidget {
+	return &slowWidget{
+		listWidget: newListWidget(tview.NewApplication()),
+		release:    make(chan bool),
+		started:    make(chan bool),
+	}
+}
+
+/* -------------------- RefreshWidget() -------------------- */
+
+func TestRefreshWidgetWhileRefreshing(t *testing.T) {
+	widget := newSlowWidget()
+
+	done := make(chan bool)
+	go func() {
+		RefreshWidget(widget)
+		close(done)
+	}()
+
+	<-widget.started
+	True(t, IsRefreshing(widget))
+
+	// A refresh that's requested while one is running is dropped
+	RefreshWidget(widget)
+
+	widget.release <- true
+	<-done
+
+	Equal(t, 1, widget.refreshes)
+	False(t, IsRefreshing(widget))
+}
+
+/* -------------------- QueueRefresh() -------------------- */
+
+func TestQueueRefresh(t *testing.T) {
+	widget := newSlowWidget()
+
+	done := make(chan bool)
+	go func() {
+		QueueRefresh(widget)
+		close(done)
+	}()
+
+	<-widget.started
+	widget.release <- true
+	<-done
+
+	Equal(t, 1, widget.refreshes)
+	False(t, IsRefreshing(widget))
+}
+
+func TestQueueRefreshWhileRefreshing(t *testing.T) {
+	widget := newSlowWidget()
+
+	done := make(chan bool)
+	go func() {
+		RefreshWidget(widget)
+		close(done)
+	}()
+
+	<-widget.started
+
+	// Refreshes that are queued while one is running are run once, after it
+	QueueRefresh(widget)
+	QueueRefresh(widget)
+	RefreshWidget(widget)
+
+	widget.release <- true
+	<-widget.started
+	True(t, IsRefreshing(widget))
+
+	widget.release <- true
+	<-done
+
+	Equal(t, 2, widget.refreshes)
+	False(t, IsRefreshing(widget))
+}
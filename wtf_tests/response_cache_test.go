@@ -0,0 +1,122 @@
+package wtf_tests
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/rivo/tview"
+	. "github.com/senorprogrammer/wtf/wtf"
+	. "github.com/stretchr/testify/assert"
+)
+
+func withCache(offline bool, dir string) func() {
+	Cache = NewResponseCache(dir, offline)
+
+	return func() { Cache = nil }
+}
+
+func getBody(key, url string) (string, error) {
+	resp, err := NewHTTPClient(key, nil).Get(url)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	return string(body), err
+}
+
+func TestResponseCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wtf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("fresh"))
+	}))
+
+	reset := withCache(false, dir)
+	body, err := getBody("list", server.URL+"/data")
+	Nil(t, err)
+	Equal(t, "fresh", body)
+	True(t, Cache.StaleSince("list").IsZero())
+	reset()
+
+	server.Close()
+
+	// The network is gone, so the cached response is served instead
+	reset = withCache(false, dir)
+	body, err = getBody("list", server.URL+"/data")
+	Nil(t, err)
+	Equal(t, "fresh", body)
+	False(t, Cache.StaleSince("list").IsZero())
+	reset()
+
+	// Offline, only cached responses are served
+	reset = withCache(true, dir)
+	defer reset()
+
+	body, err = getBody("list", server.URL+"/data")
+	Nil(t, err)
+	Equal(t, "fresh", body)
+
+	_, err = getBody("list", server.URL+"/missing")
+	NotNil(t, err)
+}
+
+// cachedWidget makes the requests of two modules when it's refreshed: its own,
+// and one another widget might make at the same time
+type cachedWidget struct {
+	*listWidget
+
+	url    string
+	bodies []string
+}
+
+func (widget *cachedWidget) Refresh() {
+	for _, key := range []string{"list", "other"} {
+		body, err := getBody(key, widget.url)
+		widget.bodies = append(widget.bodies, fmt.Sprintf("%s %v", body, err))
+	}
+}
+
+func TestRefreshFromCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wtf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	served := "cached"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(served))
+	}))
+	defer server.Close()
+
+	reset := withCache(false, dir)
+	defer reset()
+
+	_, err = getBody("list", server.URL+"/data")
+	Nil(t, err)
+
+	served = "live"
+	widget := &cachedWidget{listWidget: newListWidget(tview.NewApplication()), url: server.URL + "/data"}
+	RefreshFromCache([]Wtfable{widget})
+
+	// Only the refreshed widget's requests are served from the cache
+	Equal(t, []string{"cached <nil>", "live <nil>"}, widget.bodies)
+	False(t, Cache.StaleSince("list").IsZero())
+	True(t, Cache.StaleSince("other").IsZero())
+
+	// Once it's been refreshed from the cache, the widget's requests go to the network again
+	body, err := getBody("list", server.URL+"/data")
+	Nil(t, err)
+	Equal(t, "live", body)
+	True(t, Cache.StaleSince("list").IsZero())
+}
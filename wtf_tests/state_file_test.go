@@ -0,0 +1,79 @@
+package wtf_tests
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/senorprogrammer/wtf/wtf"
+	. "github.com/stretchr/testify/assert"
+)
+
+func tempStateFile(t *testing.T) (string, func()) {
+	dir, err := ioutil.TempDir("", "wtf")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return filepath.Join(dir, "state.yml"), func() { os.RemoveAll(dir) }
+}
+
+func TestStateFilePersists(t *testing.T) {
+	filePath, cleanup := tempStateFile(t)
+	defer cleanup()
+
+	state := LoadStateFile(filePath)
+	state.SetFocusedWidget("git")
+	state.SetSource("git", "/src/wtf")
+	state.SetSelected("jira", "WTF-1")
+	state.SetScrollRow("textfile", 12)
+	state.SetCollapsed("github", "stats", true)
+
+	reloaded := LoadStateFile(filePath)
+	Equal(t, "git", reloaded.FocusedWidget())
+	Equal(t, "/src/wtf", reloaded.Get("git").Source)
+	Equal(t, "WTF-1", reloaded.Get("jira").Selected)
+	Equal(t, 12, reloaded.Get("textfile").ScrollRow)
+	True(t, reloaded.IsCollapsed("github", "stats"))
+
+	reloaded.SetCollapsed("github", "stats", false)
+	False(t, LoadStateFile(filePath).IsCollapsed("github", "stats"))
+}
+
+func TestStateFileReset(t *testing.T) {
+	filePath, cleanup := tempStateFile(t)
+	defer cleanup()
+
+	state := LoadStateFile(filePath)
+	state.SetFocusedWidget("git")
+	state.SetSource("git", "/src/wtf")
+	state.Reset()
+
+	reloaded := LoadStateFile(filePath)
+	Equal(t, "", reloaded.FocusedWidget())
+	Equal(t, WidgetState{}, reloaded.Get("git"))
+}
+
+func TestStateFileErr(t *testing.T) {
+	filePath, cleanup := tempStateFile(t)
+	defer cleanup()
+
+	state := LoadStateFile(filePath)
+	state.SetSource("git", "/src/wtf")
+	NoError(t, state.Err())
+
+	// The state file's directory has gone, so it can't be written
+	state = LoadStateFile(filepath.Join(filepath.Dir(filePath), "missing", "state.yml"))
+	state.SetSource("git", "/src/wtf")
+	Error(t, state.Err())
+}
+
+func TestNilStateFile(t *testing.T) {
+	var state *StateFile
+
+	state.SetSource("git", "/src/wtf")
+	Equal(t, "", state.Get("git").Source)
+	Equal(t, "", state.FocusedWidget())
+	NoError(t, state.Err())
+}
@@ -0,0 +1,34 @@
+package wtf_tests
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	. "github.com/senorprogrammer/wtf/wtf"
+	. "github.com/stretchr/testify/assert"
+)
+
+/* -------------------- HighlightSyntax() -------------------- */
+
+func TestHighlightSyntax(t *testing.T) {
+	diff := "--- a/main.go\n+++ b/main.go\n@@ -1 +1 @@\n-old\n+new\n"
+
+	highlighted := HighlightSyntax(diff, "diff", "vim")
+
+	NotEqual(t, diff, highlighted)
+	Contains(t, highlighted, "[")
+	Equal(t, strings.Split(diff, "\n"), strings.Split(stripTags(highlighted), "\n"))
+}
+
+func TestHighlightSyntaxByFileName(t *testing.T) {
+	code := "package main\n"
+
+	Equal(t, code, stripTags(HighlightSyntax(code, "main.go", "vim")))
+	Equal(t, code, stripTags(HighlightSyntax(code, "main.go", "no such style")))
+}
+
+// stripTags removes tview's color tags, leaving the text that's displayed
+func stripTags(text string) string {
+	return regexp.MustCompile(`\[[a-zA-Z0-9#:\-]*\]`).ReplaceAllString(text, "")
+}
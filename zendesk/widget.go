@@ -9,6 +9,14 @@ import (
 	"github.com/senorprogrammer/wtf/wtf"
 )
 
+const ConfigDefaults = `
+apiKey: ""
+refreshInterval: 300
+status: "new"
+subdomain: ""
+username: ""
+`
+
 type Widget struct {
 	wtf.TextWidget
 
@@ -45,7 +53,7 @@ func (widget *Widget) Refresh() {
 
 func (widget *Widget) display() {
 	widget.View.SetTitle(fmt.Sprintf("%s (%d)", widget.Name, widget.result.Count))
-	widget.View.SetText(widget.textContent(widget.result.Tickets))
+	widget.SetText(widget.textContent(widget.result.Tickets))
 }
 
 func (widget *Widget) textContent(items []Ticket) string {